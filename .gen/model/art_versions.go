@@ -0,0 +1,21 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type ArtVersions struct {
+	ID        *int32 `sql:"primary_key"`
+	ArtID     int32
+	Version   int32
+	Note      string
+	CoverURL  string
+	CreatedAt *time.Time
+}
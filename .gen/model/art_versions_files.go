@@ -0,0 +1,16 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+type ArtVersionsFiles struct {
+	ID        *int32 `sql:"primary_key"`
+	VersionID int32
+	Filename  string
+	Filetype  string
+	URL       string
+}
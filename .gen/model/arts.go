@@ -12,12 +12,21 @@ import (
 )
 
 type Arts struct {
-	ID          *int32 `sql:"primary_key"`
-	CoverURL    string
-	Name        string
-	Description string
-	CreatorID   int32
-	Price       int32
-	CreatedAt   *time.Time
-	UpdatedAt   *time.Time
+	ID                *int32 `sql:"primary_key"`
+	CoverURL          string
+	Name              string
+	Description       string
+	CreatorID         int32
+	Price             int32
+	CreatedAt         *time.Time
+	UpdatedAt         *time.Time
+	Status            string
+	PublishAt         *time.Time
+	DeletedAt         *time.Time
+	EditionSize       *int32
+	PayWhatYouWant    bool
+	SuggestedPrice    *int32
+	MembersTierID     *int32
+	DownloadLimit     *int32
+	DownloadRateLimit *int32
 }
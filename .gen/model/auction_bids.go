@@ -0,0 +1,23 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type AuctionBids struct {
+	ID            *int32 `sql:"primary_key"`
+	AuctionID     int32
+	UserID        int32
+	Amount        int32
+	Status        string
+	ReservationID *int32
+	ReleaseID     *int32
+	CreatedAt     *time.Time
+}
@@ -12,8 +12,14 @@ import (
 )
 
 type Codes struct {
-	ID      *int32 `sql:"primary_key"`
-	Name    string
-	Value   int32
-	ExpTime time.Time
+	ID           *int32 `sql:"primary_key"`
+	Name         string
+	Value        int32
+	ExpTime      time.Time
+	MaxUses      *int32
+	PerUserLimit int32
+	StartsAt     *time.Time
+	Disabled     bool
+	Segment      string
+	Batch        string
 }
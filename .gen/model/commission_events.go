@@ -0,0 +1,22 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type CommissionEvents struct {
+	ID           *int32 `sql:"primary_key"`
+	CommissionID int32
+	ActorID      *int32
+	FromStatus   *string
+	ToStatus     string
+	Note         string
+	CreatedAt    *time.Time
+}
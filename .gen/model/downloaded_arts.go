@@ -15,4 +15,5 @@ type DownloadedArts struct {
 	ID        *int32 `sql:"primary_key"`
 	ArtID     int32
 	CreatedAt *time.Time
+	UserID    *int32
 }
@@ -0,0 +1,23 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+type OrderItems struct {
+	ID          *int32 `sql:"primary_key"`
+	OrderID     int32
+	ArtID       *int32
+	LicenseID   *int32
+	CreatorID   *int32
+	ArtName     string
+	LicenseName string
+	Price       int32
+	Fee         int32
+	ListPrice   int32
+	CouponCode  *string
+	BundleID    *int32
+}
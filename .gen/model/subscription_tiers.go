@@ -0,0 +1,22 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type SubscriptionTiers struct {
+	ID          *int32 `sql:"primary_key"`
+	CreatorID   int32
+	Name        string
+	Description string
+	Price       int32
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
@@ -21,4 +21,5 @@ type Users struct {
 	Coin      int32
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
+	Earnings  int32
 }
@@ -8,6 +8,9 @@
 package model
 
 type UsersBoughtArts struct {
-	UserID int32 `sql:"primary_key"`
-	ArtID  int32 `sql:"primary_key"`
+	UserID        int32 `sql:"primary_key"`
+	ArtID         int32 `sql:"primary_key"`
+	LicenseID     *int32
+	DownloadCount int32
+	EditionNumber *int32
 }
@@ -7,7 +7,14 @@
 
 package model
 
+import (
+	"time"
+)
+
 type UsersUsedCodes struct {
-	UserID int32 `sql:"primary_key"`
-	CodeID int32 `sql:"primary_key"`
+	ID        *int32 `sql:"primary_key"`
+	UserID    int32
+	CodeID    int32
+	Value     int32
+	CreatedAt *time.Time
 }
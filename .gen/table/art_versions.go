@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var ArtVersions = newArtVersionsTable("", "art_versions", "")
+
+type artVersionsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	ArtID     sqlite.ColumnInteger
+	Version   sqlite.ColumnInteger
+	Note      sqlite.ColumnString
+	CoverURL  sqlite.ColumnString
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type ArtVersionsTable struct {
+	artVersionsTable
+
+	EXCLUDED artVersionsTable
+}
+
+// AS creates new ArtVersionsTable with assigned alias
+func (a ArtVersionsTable) AS(alias string) *ArtVersionsTable {
+	return newArtVersionsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ArtVersionsTable with assigned schema name
+func (a ArtVersionsTable) FromSchema(schemaName string) *ArtVersionsTable {
+	return newArtVersionsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ArtVersionsTable with assigned table prefix
+func (a ArtVersionsTable) WithPrefix(prefix string) *ArtVersionsTable {
+	return newArtVersionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ArtVersionsTable with assigned table suffix
+func (a ArtVersionsTable) WithSuffix(suffix string) *ArtVersionsTable {
+	return newArtVersionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newArtVersionsTable(schemaName, tableName, alias string) *ArtVersionsTable {
+	return &ArtVersionsTable{
+		artVersionsTable: newArtVersionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newArtVersionsTableImpl("", "excluded", ""),
+	}
+}
+
+func newArtVersionsTableImpl(schemaName, tableName, alias string) artVersionsTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		ArtIDColumn     = sqlite.IntegerColumn("art_id")
+		VersionColumn   = sqlite.IntegerColumn("version")
+		NoteColumn      = sqlite.StringColumn("note")
+		CoverURLColumn  = sqlite.StringColumn("cover_url")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{IDColumn, ArtIDColumn, VersionColumn, NoteColumn, CoverURLColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{ArtIDColumn, VersionColumn, NoteColumn, CoverURLColumn, CreatedAtColumn}
+	)
+
+	return artVersionsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		ArtID:     ArtIDColumn,
+		Version:   VersionColumn,
+		Note:      NoteColumn,
+		CoverURL:  CoverURLColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
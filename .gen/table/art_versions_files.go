@@ -0,0 +1,87 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var ArtVersionsFiles = newArtVersionsFilesTable("", "art_versions_files", "")
+
+type artVersionsFilesTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	VersionID sqlite.ColumnInteger
+	Filename  sqlite.ColumnString
+	Filetype  sqlite.ColumnString
+	URL       sqlite.ColumnString
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type ArtVersionsFilesTable struct {
+	artVersionsFilesTable
+
+	EXCLUDED artVersionsFilesTable
+}
+
+// AS creates new ArtVersionsFilesTable with assigned alias
+func (a ArtVersionsFilesTable) AS(alias string) *ArtVersionsFilesTable {
+	return newArtVersionsFilesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ArtVersionsFilesTable with assigned schema name
+func (a ArtVersionsFilesTable) FromSchema(schemaName string) *ArtVersionsFilesTable {
+	return newArtVersionsFilesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ArtVersionsFilesTable with assigned table prefix
+func (a ArtVersionsFilesTable) WithPrefix(prefix string) *ArtVersionsFilesTable {
+	return newArtVersionsFilesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ArtVersionsFilesTable with assigned table suffix
+func (a ArtVersionsFilesTable) WithSuffix(suffix string) *ArtVersionsFilesTable {
+	return newArtVersionsFilesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newArtVersionsFilesTable(schemaName, tableName, alias string) *ArtVersionsFilesTable {
+	return &ArtVersionsFilesTable{
+		artVersionsFilesTable: newArtVersionsFilesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:              newArtVersionsFilesTableImpl("", "excluded", ""),
+	}
+}
+
+func newArtVersionsFilesTableImpl(schemaName, tableName, alias string) artVersionsFilesTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		VersionIDColumn = sqlite.IntegerColumn("version_id")
+		FilenameColumn  = sqlite.StringColumn("filename")
+		FiletypeColumn  = sqlite.StringColumn("filetype")
+		URLColumn       = sqlite.StringColumn("url")
+		allColumns      = sqlite.ColumnList{IDColumn, VersionIDColumn, FilenameColumn, FiletypeColumn, URLColumn}
+		mutableColumns  = sqlite.ColumnList{VersionIDColumn, FilenameColumn, FiletypeColumn, URLColumn}
+	)
+
+	return artVersionsFilesTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		VersionID: VersionIDColumn,
+		Filename:  FilenameColumn,
+		Filetype:  FiletypeColumn,
+		URL:       URLColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -17,14 +17,23 @@ type artsTable struct {
 	sqlite.Table
 
 	// Columns
-	ID          sqlite.ColumnInteger
-	CoverURL    sqlite.ColumnString
-	Name        sqlite.ColumnString
-	Description sqlite.ColumnString
-	CreatorID   sqlite.ColumnInteger
-	Price       sqlite.ColumnInteger
-	CreatedAt   sqlite.ColumnTimestamp
-	UpdatedAt   sqlite.ColumnTimestamp
+	ID                sqlite.ColumnInteger
+	CoverURL          sqlite.ColumnString
+	Name              sqlite.ColumnString
+	Description       sqlite.ColumnString
+	CreatorID         sqlite.ColumnInteger
+	Price             sqlite.ColumnInteger
+	CreatedAt         sqlite.ColumnTimestamp
+	UpdatedAt         sqlite.ColumnTimestamp
+	Status            sqlite.ColumnString
+	PublishAt         sqlite.ColumnTimestamp
+	DeletedAt         sqlite.ColumnTimestamp
+	EditionSize       sqlite.ColumnInteger
+	PayWhatYouWant    sqlite.ColumnBool
+	SuggestedPrice    sqlite.ColumnInteger
+	MembersTierID     sqlite.ColumnInteger
+	DownloadLimit     sqlite.ColumnInteger
+	DownloadRateLimit sqlite.ColumnInteger
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -65,30 +74,48 @@ func newArtsTable(schemaName, tableName, alias string) *ArtsTable {
 
 func newArtsTableImpl(schemaName, tableName, alias string) artsTable {
 	var (
-		IDColumn          = sqlite.IntegerColumn("id")
-		CoverURLColumn    = sqlite.StringColumn("cover_url")
-		NameColumn        = sqlite.StringColumn("name")
-		DescriptionColumn = sqlite.StringColumn("description")
-		CreatorIDColumn   = sqlite.IntegerColumn("creator_id")
-		PriceColumn       = sqlite.IntegerColumn("price")
-		CreatedAtColumn   = sqlite.TimestampColumn("created_at")
-		UpdatedAtColumn   = sqlite.TimestampColumn("updated_at")
-		allColumns        = sqlite.ColumnList{IDColumn, CoverURLColumn, NameColumn, DescriptionColumn, CreatorIDColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns    = sqlite.ColumnList{CoverURLColumn, NameColumn, DescriptionColumn, CreatorIDColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn                = sqlite.IntegerColumn("id")
+		CoverURLColumn          = sqlite.StringColumn("cover_url")
+		NameColumn              = sqlite.StringColumn("name")
+		DescriptionColumn       = sqlite.StringColumn("description")
+		CreatorIDColumn         = sqlite.IntegerColumn("creator_id")
+		PriceColumn             = sqlite.IntegerColumn("price")
+		CreatedAtColumn         = sqlite.TimestampColumn("created_at")
+		UpdatedAtColumn         = sqlite.TimestampColumn("updated_at")
+		StatusColumn            = sqlite.StringColumn("status")
+		PublishAtColumn         = sqlite.TimestampColumn("publish_at")
+		DeletedAtColumn         = sqlite.TimestampColumn("deleted_at")
+		EditionSizeColumn       = sqlite.IntegerColumn("edition_size")
+		PayWhatYouWantColumn    = sqlite.BoolColumn("pay_what_you_want")
+		SuggestedPriceColumn    = sqlite.IntegerColumn("suggested_price")
+		MembersTierIDColumn     = sqlite.IntegerColumn("members_tier_id")
+		DownloadLimitColumn     = sqlite.IntegerColumn("download_limit")
+		DownloadRateLimitColumn = sqlite.IntegerColumn("download_rate_limit")
+		allColumns              = sqlite.ColumnList{IDColumn, CoverURLColumn, NameColumn, DescriptionColumn, CreatorIDColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn, StatusColumn, PublishAtColumn, DeletedAtColumn, EditionSizeColumn, PayWhatYouWantColumn, SuggestedPriceColumn, MembersTierIDColumn, DownloadLimitColumn, DownloadRateLimitColumn}
+		mutableColumns          = sqlite.ColumnList{CoverURLColumn, NameColumn, DescriptionColumn, CreatorIDColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn, StatusColumn, PublishAtColumn, DeletedAtColumn, EditionSizeColumn, PayWhatYouWantColumn, SuggestedPriceColumn, MembersTierIDColumn, DownloadLimitColumn, DownloadRateLimitColumn}
 	)
 
 	return artsTable{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:          IDColumn,
-		CoverURL:    CoverURLColumn,
-		Name:        NameColumn,
-		Description: DescriptionColumn,
-		CreatorID:   CreatorIDColumn,
-		Price:       PriceColumn,
-		CreatedAt:   CreatedAtColumn,
-		UpdatedAt:   UpdatedAtColumn,
+		ID:                IDColumn,
+		CoverURL:          CoverURLColumn,
+		Name:              NameColumn,
+		Description:       DescriptionColumn,
+		CreatorID:         CreatorIDColumn,
+		Price:             PriceColumn,
+		CreatedAt:         CreatedAtColumn,
+		UpdatedAt:         UpdatedAtColumn,
+		Status:            StatusColumn,
+		PublishAt:         PublishAtColumn,
+		DeletedAt:         DeletedAtColumn,
+		EditionSize:       EditionSizeColumn,
+		PayWhatYouWant:    PayWhatYouWantColumn,
+		SuggestedPrice:    SuggestedPriceColumn,
+		MembersTierID:     MembersTierIDColumn,
+		DownloadLimit:     DownloadLimitColumn,
+		DownloadRateLimit: DownloadRateLimitColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
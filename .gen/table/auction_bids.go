@@ -0,0 +1,96 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var AuctionBids = newAuctionBidsTable("", "auction_bids", "")
+
+type auctionBidsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID            sqlite.ColumnInteger
+	AuctionID     sqlite.ColumnInteger
+	UserID        sqlite.ColumnInteger
+	Amount        sqlite.ColumnInteger
+	Status        sqlite.ColumnString
+	ReservationID sqlite.ColumnInteger
+	ReleaseID     sqlite.ColumnInteger
+	CreatedAt     sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type AuctionBidsTable struct {
+	auctionBidsTable
+
+	EXCLUDED auctionBidsTable
+}
+
+// AS creates new AuctionBidsTable with assigned alias
+func (a AuctionBidsTable) AS(alias string) *AuctionBidsTable {
+	return newAuctionBidsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new AuctionBidsTable with assigned schema name
+func (a AuctionBidsTable) FromSchema(schemaName string) *AuctionBidsTable {
+	return newAuctionBidsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new AuctionBidsTable with assigned table prefix
+func (a AuctionBidsTable) WithPrefix(prefix string) *AuctionBidsTable {
+	return newAuctionBidsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new AuctionBidsTable with assigned table suffix
+func (a AuctionBidsTable) WithSuffix(suffix string) *AuctionBidsTable {
+	return newAuctionBidsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newAuctionBidsTable(schemaName, tableName, alias string) *AuctionBidsTable {
+	return &AuctionBidsTable{
+		auctionBidsTable: newAuctionBidsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newAuctionBidsTableImpl("", "excluded", ""),
+	}
+}
+
+func newAuctionBidsTableImpl(schemaName, tableName, alias string) auctionBidsTable {
+	var (
+		IDColumn            = sqlite.IntegerColumn("id")
+		AuctionIDColumn     = sqlite.IntegerColumn("auction_id")
+		UserIDColumn        = sqlite.IntegerColumn("user_id")
+		AmountColumn        = sqlite.IntegerColumn("amount")
+		StatusColumn        = sqlite.StringColumn("status")
+		ReservationIDColumn = sqlite.IntegerColumn("reservation_id")
+		ReleaseIDColumn     = sqlite.IntegerColumn("release_id")
+		CreatedAtColumn     = sqlite.TimestampColumn("created_at")
+		allColumns          = sqlite.ColumnList{IDColumn, AuctionIDColumn, UserIDColumn, AmountColumn, StatusColumn, ReservationIDColumn, ReleaseIDColumn, CreatedAtColumn}
+		mutableColumns      = sqlite.ColumnList{AuctionIDColumn, UserIDColumn, AmountColumn, StatusColumn, ReservationIDColumn, ReleaseIDColumn, CreatedAtColumn}
+	)
+
+	return auctionBidsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:            IDColumn,
+		AuctionID:     AuctionIDColumn,
+		UserID:        UserIDColumn,
+		Amount:        AmountColumn,
+		Status:        StatusColumn,
+		ReservationID: ReservationIDColumn,
+		ReleaseID:     ReleaseIDColumn,
+		CreatedAt:     CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
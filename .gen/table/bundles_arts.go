@@ -0,0 +1,78 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var BundlesArts = newBundlesArtsTable("", "bundles_arts", "")
+
+type bundlesArtsTable struct {
+	sqlite.Table
+
+	// Columns
+	BundleID sqlite.ColumnInteger
+	ArtID    sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type BundlesArtsTable struct {
+	bundlesArtsTable
+
+	EXCLUDED bundlesArtsTable
+}
+
+// AS creates new BundlesArtsTable with assigned alias
+func (a BundlesArtsTable) AS(alias string) *BundlesArtsTable {
+	return newBundlesArtsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new BundlesArtsTable with assigned schema name
+func (a BundlesArtsTable) FromSchema(schemaName string) *BundlesArtsTable {
+	return newBundlesArtsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new BundlesArtsTable with assigned table prefix
+func (a BundlesArtsTable) WithPrefix(prefix string) *BundlesArtsTable {
+	return newBundlesArtsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new BundlesArtsTable with assigned table suffix
+func (a BundlesArtsTable) WithSuffix(suffix string) *BundlesArtsTable {
+	return newBundlesArtsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newBundlesArtsTable(schemaName, tableName, alias string) *BundlesArtsTable {
+	return &BundlesArtsTable{
+		bundlesArtsTable: newBundlesArtsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newBundlesArtsTableImpl("", "excluded", ""),
+	}
+}
+
+func newBundlesArtsTableImpl(schemaName, tableName, alias string) bundlesArtsTable {
+	var (
+		BundleIDColumn = sqlite.IntegerColumn("bundle_id")
+		ArtIDColumn    = sqlite.IntegerColumn("art_id")
+		allColumns     = sqlite.ColumnList{BundleIDColumn, ArtIDColumn}
+		mutableColumns = sqlite.ColumnList{}
+	)
+
+	return bundlesArtsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		BundleID: BundleIDColumn,
+		ArtID:    ArtIDColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
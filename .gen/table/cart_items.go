@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CartItems = newCartItemsTable("", "cart_items", "")
+
+type cartItemsTable struct {
+	sqlite.Table
+
+	// Columns
+	UserID    sqlite.ColumnInteger
+	ArtID     sqlite.ColumnInteger
+	LicenseID sqlite.ColumnInteger
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CartItemsTable struct {
+	cartItemsTable
+
+	EXCLUDED cartItemsTable
+}
+
+// AS creates new CartItemsTable with assigned alias
+func (a CartItemsTable) AS(alias string) *CartItemsTable {
+	return newCartItemsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CartItemsTable with assigned schema name
+func (a CartItemsTable) FromSchema(schemaName string) *CartItemsTable {
+	return newCartItemsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CartItemsTable with assigned table prefix
+func (a CartItemsTable) WithPrefix(prefix string) *CartItemsTable {
+	return newCartItemsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CartItemsTable with assigned table suffix
+func (a CartItemsTable) WithSuffix(suffix string) *CartItemsTable {
+	return newCartItemsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCartItemsTable(schemaName, tableName, alias string) *CartItemsTable {
+	return &CartItemsTable{
+		cartItemsTable: newCartItemsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:       newCartItemsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCartItemsTableImpl(schemaName, tableName, alias string) cartItemsTable {
+	var (
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		ArtIDColumn     = sqlite.IntegerColumn("art_id")
+		LicenseIDColumn = sqlite.IntegerColumn("license_id")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{UserIDColumn, ArtIDColumn, LicenseIDColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{LicenseIDColumn, CreatedAtColumn}
+	)
+
+	return cartItemsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		UserID:    UserIDColumn,
+		ArtID:     ArtIDColumn,
+		LicenseID: LicenseIDColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
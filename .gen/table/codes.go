@@ -17,10 +17,16 @@ type codesTable struct {
 	sqlite.Table
 
 	// Columns
-	ID      sqlite.ColumnInteger
-	Name    sqlite.ColumnString
-	Value   sqlite.ColumnInteger
-	ExpTime sqlite.ColumnTimestamp
+	ID           sqlite.ColumnInteger
+	Name         sqlite.ColumnString
+	Value        sqlite.ColumnInteger
+	ExpTime      sqlite.ColumnTimestamp
+	MaxUses      sqlite.ColumnInteger
+	PerUserLimit sqlite.ColumnInteger
+	StartsAt     sqlite.ColumnTimestamp
+	Disabled     sqlite.ColumnBool
+	Segment      sqlite.ColumnString
+	Batch        sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -61,22 +67,34 @@ func newCodesTable(schemaName, tableName, alias string) *CodesTable {
 
 func newCodesTableImpl(schemaName, tableName, alias string) codesTable {
 	var (
-		IDColumn       = sqlite.IntegerColumn("id")
-		NameColumn     = sqlite.StringColumn("name")
-		ValueColumn    = sqlite.IntegerColumn("value")
-		ExpTimeColumn  = sqlite.TimestampColumn("exp_time")
-		allColumns     = sqlite.ColumnList{IDColumn, NameColumn, ValueColumn, ExpTimeColumn}
-		mutableColumns = sqlite.ColumnList{NameColumn, ValueColumn, ExpTimeColumn}
+		IDColumn           = sqlite.IntegerColumn("id")
+		NameColumn         = sqlite.StringColumn("name")
+		ValueColumn        = sqlite.IntegerColumn("value")
+		ExpTimeColumn      = sqlite.TimestampColumn("exp_time")
+		MaxUsesColumn      = sqlite.IntegerColumn("max_uses")
+		PerUserLimitColumn = sqlite.IntegerColumn("per_user_limit")
+		StartsAtColumn     = sqlite.TimestampColumn("starts_at")
+		DisabledColumn     = sqlite.BoolColumn("disabled")
+		SegmentColumn      = sqlite.StringColumn("segment")
+		BatchColumn        = sqlite.StringColumn("batch")
+		allColumns         = sqlite.ColumnList{IDColumn, NameColumn, ValueColumn, ExpTimeColumn, MaxUsesColumn, PerUserLimitColumn, StartsAtColumn, DisabledColumn, SegmentColumn, BatchColumn}
+		mutableColumns     = sqlite.ColumnList{NameColumn, ValueColumn, ExpTimeColumn, MaxUsesColumn, PerUserLimitColumn, StartsAtColumn, DisabledColumn, SegmentColumn, BatchColumn}
 	)
 
 	return codesTable{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:      IDColumn,
-		Name:    NameColumn,
-		Value:   ValueColumn,
-		ExpTime: ExpTimeColumn,
+		ID:           IDColumn,
+		Name:         NameColumn,
+		Value:        ValueColumn,
+		ExpTime:      ExpTimeColumn,
+		MaxUses:      MaxUsesColumn,
+		PerUserLimit: PerUserLimitColumn,
+		StartsAt:     StartsAtColumn,
+		Disabled:     DisabledColumn,
+		Segment:      SegmentColumn,
+		Batch:        BatchColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
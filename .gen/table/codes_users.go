@@ -0,0 +1,78 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CodesUsers = newCodesUsersTable("", "codes_users", "")
+
+type codesUsersTable struct {
+	sqlite.Table
+
+	// Columns
+	CodeID sqlite.ColumnInteger
+	UserID sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CodesUsersTable struct {
+	codesUsersTable
+
+	EXCLUDED codesUsersTable
+}
+
+// AS creates new CodesUsersTable with assigned alias
+func (a CodesUsersTable) AS(alias string) *CodesUsersTable {
+	return newCodesUsersTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CodesUsersTable with assigned schema name
+func (a CodesUsersTable) FromSchema(schemaName string) *CodesUsersTable {
+	return newCodesUsersTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CodesUsersTable with assigned table prefix
+func (a CodesUsersTable) WithPrefix(prefix string) *CodesUsersTable {
+	return newCodesUsersTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CodesUsersTable with assigned table suffix
+func (a CodesUsersTable) WithSuffix(suffix string) *CodesUsersTable {
+	return newCodesUsersTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCodesUsersTable(schemaName, tableName, alias string) *CodesUsersTable {
+	return &CodesUsersTable{
+		codesUsersTable: newCodesUsersTableImpl(schemaName, tableName, alias),
+		EXCLUDED:        newCodesUsersTableImpl("", "excluded", ""),
+	}
+}
+
+func newCodesUsersTableImpl(schemaName, tableName, alias string) codesUsersTable {
+	var (
+		CodeIDColumn   = sqlite.IntegerColumn("code_id")
+		UserIDColumn   = sqlite.IntegerColumn("user_id")
+		allColumns     = sqlite.ColumnList{CodeIDColumn, UserIDColumn}
+		mutableColumns = sqlite.ColumnList{}
+	)
+
+	return codesUsersTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		CodeID: CodeIDColumn,
+		UserID: UserIDColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CoinPackages = newCoinPackagesTable("", "coin_packages", "")
+
+type coinPackagesTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	Name      sqlite.ColumnString
+	Coins     sqlite.ColumnInteger
+	Price     sqlite.ColumnInteger
+	IsActive  sqlite.ColumnBool
+	CreatedAt sqlite.ColumnTimestamp
+	UpdatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CoinPackagesTable struct {
+	coinPackagesTable
+
+	EXCLUDED coinPackagesTable
+}
+
+// AS creates new CoinPackagesTable with assigned alias
+func (a CoinPackagesTable) AS(alias string) *CoinPackagesTable {
+	return newCoinPackagesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CoinPackagesTable with assigned schema name
+func (a CoinPackagesTable) FromSchema(schemaName string) *CoinPackagesTable {
+	return newCoinPackagesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CoinPackagesTable with assigned table prefix
+func (a CoinPackagesTable) WithPrefix(prefix string) *CoinPackagesTable {
+	return newCoinPackagesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CoinPackagesTable with assigned table suffix
+func (a CoinPackagesTable) WithSuffix(suffix string) *CoinPackagesTable {
+	return newCoinPackagesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCoinPackagesTable(schemaName, tableName, alias string) *CoinPackagesTable {
+	return &CoinPackagesTable{
+		coinPackagesTable: newCoinPackagesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:          newCoinPackagesTableImpl("", "excluded", ""),
+	}
+}
+
+func newCoinPackagesTableImpl(schemaName, tableName, alias string) coinPackagesTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		NameColumn      = sqlite.StringColumn("name")
+		CoinsColumn     = sqlite.IntegerColumn("coins")
+		PriceColumn     = sqlite.IntegerColumn("price")
+		IsActiveColumn  = sqlite.BoolColumn("is_active")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		UpdatedAtColumn = sqlite.TimestampColumn("updated_at")
+		allColumns      = sqlite.ColumnList{IDColumn, NameColumn, CoinsColumn, PriceColumn, IsActiveColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{NameColumn, CoinsColumn, PriceColumn, IsActiveColumn, CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return coinPackagesTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		Name:      NameColumn,
+		Coins:     CoinsColumn,
+		Price:     PriceColumn,
+		IsActive:  IsActiveColumn,
+		CreatedAt: CreatedAtColumn,
+		UpdatedAt: UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CoinTransactionLegs = newCoinTransactionLegsTable("", "coin_transaction_legs", "")
+
+type coinTransactionLegsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID            sqlite.ColumnInteger
+	TransactionID sqlite.ColumnInteger
+	Account       sqlite.ColumnString
+	UserID        sqlite.ColumnInteger
+	Direction     sqlite.ColumnString
+	Amount        sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CoinTransactionLegsTable struct {
+	coinTransactionLegsTable
+
+	EXCLUDED coinTransactionLegsTable
+}
+
+// AS creates new CoinTransactionLegsTable with assigned alias
+func (a CoinTransactionLegsTable) AS(alias string) *CoinTransactionLegsTable {
+	return newCoinTransactionLegsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CoinTransactionLegsTable with assigned schema name
+func (a CoinTransactionLegsTable) FromSchema(schemaName string) *CoinTransactionLegsTable {
+	return newCoinTransactionLegsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CoinTransactionLegsTable with assigned table prefix
+func (a CoinTransactionLegsTable) WithPrefix(prefix string) *CoinTransactionLegsTable {
+	return newCoinTransactionLegsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CoinTransactionLegsTable with assigned table suffix
+func (a CoinTransactionLegsTable) WithSuffix(suffix string) *CoinTransactionLegsTable {
+	return newCoinTransactionLegsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCoinTransactionLegsTable(schemaName, tableName, alias string) *CoinTransactionLegsTable {
+	return &CoinTransactionLegsTable{
+		coinTransactionLegsTable: newCoinTransactionLegsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:                 newCoinTransactionLegsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCoinTransactionLegsTableImpl(schemaName, tableName, alias string) coinTransactionLegsTable {
+	var (
+		IDColumn            = sqlite.IntegerColumn("id")
+		TransactionIDColumn = sqlite.IntegerColumn("transaction_id")
+		AccountColumn       = sqlite.StringColumn("account")
+		UserIDColumn        = sqlite.IntegerColumn("user_id")
+		DirectionColumn     = sqlite.StringColumn("direction")
+		AmountColumn        = sqlite.IntegerColumn("amount")
+		allColumns          = sqlite.ColumnList{IDColumn, TransactionIDColumn, AccountColumn, UserIDColumn, DirectionColumn, AmountColumn}
+		mutableColumns      = sqlite.ColumnList{TransactionIDColumn, AccountColumn, UserIDColumn, DirectionColumn, AmountColumn}
+	)
+
+	return coinTransactionLegsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:            IDColumn,
+		TransactionID: TransactionIDColumn,
+		Account:       AccountColumn,
+		UserID:        UserIDColumn,
+		Direction:     DirectionColumn,
+		Amount:        AmountColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CommissionEvents = newCommissionEventsTable("", "commission_events", "")
+
+type commissionEventsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID           sqlite.ColumnInteger
+	CommissionID sqlite.ColumnInteger
+	ActorID      sqlite.ColumnInteger
+	FromStatus   sqlite.ColumnString
+	ToStatus     sqlite.ColumnString
+	Note         sqlite.ColumnString
+	CreatedAt    sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CommissionEventsTable struct {
+	commissionEventsTable
+
+	EXCLUDED commissionEventsTable
+}
+
+// AS creates new CommissionEventsTable with assigned alias
+func (a CommissionEventsTable) AS(alias string) *CommissionEventsTable {
+	return newCommissionEventsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CommissionEventsTable with assigned schema name
+func (a CommissionEventsTable) FromSchema(schemaName string) *CommissionEventsTable {
+	return newCommissionEventsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CommissionEventsTable with assigned table prefix
+func (a CommissionEventsTable) WithPrefix(prefix string) *CommissionEventsTable {
+	return newCommissionEventsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CommissionEventsTable with assigned table suffix
+func (a CommissionEventsTable) WithSuffix(suffix string) *CommissionEventsTable {
+	return newCommissionEventsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCommissionEventsTable(schemaName, tableName, alias string) *CommissionEventsTable {
+	return &CommissionEventsTable{
+		commissionEventsTable: newCommissionEventsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:              newCommissionEventsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCommissionEventsTableImpl(schemaName, tableName, alias string) commissionEventsTable {
+	var (
+		IDColumn           = sqlite.IntegerColumn("id")
+		CommissionIDColumn = sqlite.IntegerColumn("commission_id")
+		ActorIDColumn      = sqlite.IntegerColumn("actor_id")
+		FromStatusColumn   = sqlite.StringColumn("from_status")
+		ToStatusColumn     = sqlite.StringColumn("to_status")
+		NoteColumn         = sqlite.StringColumn("note")
+		CreatedAtColumn    = sqlite.TimestampColumn("created_at")
+		allColumns         = sqlite.ColumnList{IDColumn, CommissionIDColumn, ActorIDColumn, FromStatusColumn, ToStatusColumn, NoteColumn, CreatedAtColumn}
+		mutableColumns     = sqlite.ColumnList{CommissionIDColumn, ActorIDColumn, FromStatusColumn, ToStatusColumn, NoteColumn, CreatedAtColumn}
+	)
+
+	return commissionEventsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:           IDColumn,
+		CommissionID: CommissionIDColumn,
+		ActorID:      ActorIDColumn,
+		FromStatus:   FromStatusColumn,
+		ToStatus:     ToStatusColumn,
+		Note:         NoteColumn,
+		CreatedAt:    CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
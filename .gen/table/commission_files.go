@@ -0,0 +1,87 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CommissionFiles = newCommissionFilesTable("", "commission_files", "")
+
+type commissionFilesTable struct {
+	sqlite.Table
+
+	// Columns
+	ID           sqlite.ColumnInteger
+	CommissionID sqlite.ColumnInteger
+	Filename     sqlite.ColumnString
+	URL          sqlite.ColumnString
+	CreatedAt    sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CommissionFilesTable struct {
+	commissionFilesTable
+
+	EXCLUDED commissionFilesTable
+}
+
+// AS creates new CommissionFilesTable with assigned alias
+func (a CommissionFilesTable) AS(alias string) *CommissionFilesTable {
+	return newCommissionFilesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CommissionFilesTable with assigned schema name
+func (a CommissionFilesTable) FromSchema(schemaName string) *CommissionFilesTable {
+	return newCommissionFilesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CommissionFilesTable with assigned table prefix
+func (a CommissionFilesTable) WithPrefix(prefix string) *CommissionFilesTable {
+	return newCommissionFilesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CommissionFilesTable with assigned table suffix
+func (a CommissionFilesTable) WithSuffix(suffix string) *CommissionFilesTable {
+	return newCommissionFilesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCommissionFilesTable(schemaName, tableName, alias string) *CommissionFilesTable {
+	return &CommissionFilesTable{
+		commissionFilesTable: newCommissionFilesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:             newCommissionFilesTableImpl("", "excluded", ""),
+	}
+}
+
+func newCommissionFilesTableImpl(schemaName, tableName, alias string) commissionFilesTable {
+	var (
+		IDColumn           = sqlite.IntegerColumn("id")
+		CommissionIDColumn = sqlite.IntegerColumn("commission_id")
+		FilenameColumn     = sqlite.StringColumn("filename")
+		URLColumn          = sqlite.StringColumn("url")
+		CreatedAtColumn    = sqlite.TimestampColumn("created_at")
+		allColumns         = sqlite.ColumnList{IDColumn, CommissionIDColumn, FilenameColumn, URLColumn, CreatedAtColumn}
+		mutableColumns     = sqlite.ColumnList{CommissionIDColumn, FilenameColumn, URLColumn, CreatedAtColumn}
+	)
+
+	return commissionFilesTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:           IDColumn,
+		CommissionID: CommissionIDColumn,
+		Filename:     FilenameColumn,
+		URL:          URLColumn,
+		CreatedAt:    CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
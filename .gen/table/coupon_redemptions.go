@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CouponRedemptions = newCouponRedemptionsTable("", "coupon_redemptions", "")
+
+type couponRedemptionsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	CouponID  sqlite.ColumnInteger
+	UserID    sqlite.ColumnInteger
+	ArtID     sqlite.ColumnInteger
+	Price     sqlite.ColumnInteger
+	Discount  sqlite.ColumnInteger
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CouponRedemptionsTable struct {
+	couponRedemptionsTable
+
+	EXCLUDED couponRedemptionsTable
+}
+
+// AS creates new CouponRedemptionsTable with assigned alias
+func (a CouponRedemptionsTable) AS(alias string) *CouponRedemptionsTable {
+	return newCouponRedemptionsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CouponRedemptionsTable with assigned schema name
+func (a CouponRedemptionsTable) FromSchema(schemaName string) *CouponRedemptionsTable {
+	return newCouponRedemptionsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CouponRedemptionsTable with assigned table prefix
+func (a CouponRedemptionsTable) WithPrefix(prefix string) *CouponRedemptionsTable {
+	return newCouponRedemptionsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CouponRedemptionsTable with assigned table suffix
+func (a CouponRedemptionsTable) WithSuffix(suffix string) *CouponRedemptionsTable {
+	return newCouponRedemptionsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCouponRedemptionsTable(schemaName, tableName, alias string) *CouponRedemptionsTable {
+	return &CouponRedemptionsTable{
+		couponRedemptionsTable: newCouponRedemptionsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:               newCouponRedemptionsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCouponRedemptionsTableImpl(schemaName, tableName, alias string) couponRedemptionsTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		CouponIDColumn  = sqlite.IntegerColumn("coupon_id")
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		ArtIDColumn     = sqlite.IntegerColumn("art_id")
+		PriceColumn     = sqlite.IntegerColumn("price")
+		DiscountColumn  = sqlite.IntegerColumn("discount")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{IDColumn, CouponIDColumn, UserIDColumn, ArtIDColumn, PriceColumn, DiscountColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{CouponIDColumn, UserIDColumn, ArtIDColumn, PriceColumn, DiscountColumn, CreatedAtColumn}
+	)
+
+	return couponRedemptionsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		CouponID:  CouponIDColumn,
+		UserID:    UserIDColumn,
+		ArtID:     ArtIDColumn,
+		Price:     PriceColumn,
+		Discount:  DiscountColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
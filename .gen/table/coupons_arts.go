@@ -0,0 +1,78 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CouponsArts = newCouponsArtsTable("", "coupons_arts", "")
+
+type couponsArtsTable struct {
+	sqlite.Table
+
+	// Columns
+	CouponID sqlite.ColumnInteger
+	ArtID    sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CouponsArtsTable struct {
+	couponsArtsTable
+
+	EXCLUDED couponsArtsTable
+}
+
+// AS creates new CouponsArtsTable with assigned alias
+func (a CouponsArtsTable) AS(alias string) *CouponsArtsTable {
+	return newCouponsArtsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CouponsArtsTable with assigned schema name
+func (a CouponsArtsTable) FromSchema(schemaName string) *CouponsArtsTable {
+	return newCouponsArtsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CouponsArtsTable with assigned table prefix
+func (a CouponsArtsTable) WithPrefix(prefix string) *CouponsArtsTable {
+	return newCouponsArtsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CouponsArtsTable with assigned table suffix
+func (a CouponsArtsTable) WithSuffix(suffix string) *CouponsArtsTable {
+	return newCouponsArtsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCouponsArtsTable(schemaName, tableName, alias string) *CouponsArtsTable {
+	return &CouponsArtsTable{
+		couponsArtsTable: newCouponsArtsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newCouponsArtsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCouponsArtsTableImpl(schemaName, tableName, alias string) couponsArtsTable {
+	var (
+		CouponIDColumn = sqlite.IntegerColumn("coupon_id")
+		ArtIDColumn    = sqlite.IntegerColumn("art_id")
+		allColumns     = sqlite.ColumnList{CouponIDColumn, ArtIDColumn}
+		mutableColumns = sqlite.ColumnList{}
+	)
+
+	return couponsArtsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		CouponID: CouponIDColumn,
+		ArtID:    ArtIDColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
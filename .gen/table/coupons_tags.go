@@ -0,0 +1,78 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CouponsTags = newCouponsTagsTable("", "coupons_tags", "")
+
+type couponsTagsTable struct {
+	sqlite.Table
+
+	// Columns
+	CouponID sqlite.ColumnInteger
+	TagID    sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CouponsTagsTable struct {
+	couponsTagsTable
+
+	EXCLUDED couponsTagsTable
+}
+
+// AS creates new CouponsTagsTable with assigned alias
+func (a CouponsTagsTable) AS(alias string) *CouponsTagsTable {
+	return newCouponsTagsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CouponsTagsTable with assigned schema name
+func (a CouponsTagsTable) FromSchema(schemaName string) *CouponsTagsTable {
+	return newCouponsTagsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CouponsTagsTable with assigned table prefix
+func (a CouponsTagsTable) WithPrefix(prefix string) *CouponsTagsTable {
+	return newCouponsTagsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CouponsTagsTable with assigned table suffix
+func (a CouponsTagsTable) WithSuffix(suffix string) *CouponsTagsTable {
+	return newCouponsTagsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCouponsTagsTable(schemaName, tableName, alias string) *CouponsTagsTable {
+	return &CouponsTagsTable{
+		couponsTagsTable: newCouponsTagsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:         newCouponsTagsTableImpl("", "excluded", ""),
+	}
+}
+
+func newCouponsTagsTableImpl(schemaName, tableName, alias string) couponsTagsTable {
+	var (
+		CouponIDColumn = sqlite.IntegerColumn("coupon_id")
+		TagIDColumn    = sqlite.IntegerColumn("tag_id")
+		allColumns     = sqlite.ColumnList{CouponIDColumn, TagIDColumn}
+		mutableColumns = sqlite.ColumnList{}
+	)
+
+	return couponsTagsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		CouponID: CouponIDColumn,
+		TagID:    TagIDColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
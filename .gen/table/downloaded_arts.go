@@ -20,6 +20,7 @@ type downloadedArtsTable struct {
 	ID        sqlite.ColumnInteger
 	ArtID     sqlite.ColumnInteger
 	CreatedAt sqlite.ColumnTimestamp
+	UserID    sqlite.ColumnInteger
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -63,8 +64,9 @@ func newDownloadedArtsTableImpl(schemaName, tableName, alias string) downloadedA
 		IDColumn        = sqlite.IntegerColumn("id")
 		ArtIDColumn     = sqlite.IntegerColumn("art_id")
 		CreatedAtColumn = sqlite.TimestampColumn("created_at")
-		allColumns      = sqlite.ColumnList{IDColumn, ArtIDColumn, CreatedAtColumn}
-		mutableColumns  = sqlite.ColumnList{ArtIDColumn, CreatedAtColumn}
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		allColumns      = sqlite.ColumnList{IDColumn, ArtIDColumn, CreatedAtColumn, UserIDColumn}
+		mutableColumns  = sqlite.ColumnList{ArtIDColumn, CreatedAtColumn, UserIDColumn}
 	)
 
 	return downloadedArtsTable{
@@ -74,6 +76,7 @@ func newDownloadedArtsTableImpl(schemaName, tableName, alias string) downloadedA
 		ID:        IDColumn,
 		ArtID:     ArtIDColumn,
 		CreatedAt: CreatedAtColumn,
+		UserID:    UserIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
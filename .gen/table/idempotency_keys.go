@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var IdempotencyKeys = newIdempotencyKeysTable("", "idempotency_keys", "")
+
+type idempotencyKeysTable struct {
+	sqlite.Table
+
+	// Columns
+	UserID    sqlite.ColumnInteger
+	Key       sqlite.ColumnString
+	Request   sqlite.ColumnString
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type IdempotencyKeysTable struct {
+	idempotencyKeysTable
+
+	EXCLUDED idempotencyKeysTable
+}
+
+// AS creates new IdempotencyKeysTable with assigned alias
+func (a IdempotencyKeysTable) AS(alias string) *IdempotencyKeysTable {
+	return newIdempotencyKeysTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new IdempotencyKeysTable with assigned schema name
+func (a IdempotencyKeysTable) FromSchema(schemaName string) *IdempotencyKeysTable {
+	return newIdempotencyKeysTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new IdempotencyKeysTable with assigned table prefix
+func (a IdempotencyKeysTable) WithPrefix(prefix string) *IdempotencyKeysTable {
+	return newIdempotencyKeysTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new IdempotencyKeysTable with assigned table suffix
+func (a IdempotencyKeysTable) WithSuffix(suffix string) *IdempotencyKeysTable {
+	return newIdempotencyKeysTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newIdempotencyKeysTable(schemaName, tableName, alias string) *IdempotencyKeysTable {
+	return &IdempotencyKeysTable{
+		idempotencyKeysTable: newIdempotencyKeysTableImpl(schemaName, tableName, alias),
+		EXCLUDED:             newIdempotencyKeysTableImpl("", "excluded", ""),
+	}
+}
+
+func newIdempotencyKeysTableImpl(schemaName, tableName, alias string) idempotencyKeysTable {
+	var (
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		KeyColumn       = sqlite.StringColumn("key")
+		RequestColumn   = sqlite.StringColumn("request")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{UserIDColumn, KeyColumn, RequestColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{RequestColumn, CreatedAtColumn}
+	)
+
+	return idempotencyKeysTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		UserID:    UserIDColumn,
+		Key:       KeyColumn,
+		Request:   RequestColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,108 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var OrderItems = newOrderItemsTable("", "order_items", "")
+
+type orderItemsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID          sqlite.ColumnInteger
+	OrderID     sqlite.ColumnInteger
+	ArtID       sqlite.ColumnInteger
+	LicenseID   sqlite.ColumnInteger
+	CreatorID   sqlite.ColumnInteger
+	ArtName     sqlite.ColumnString
+	LicenseName sqlite.ColumnString
+	Price       sqlite.ColumnInteger
+	Fee         sqlite.ColumnInteger
+	ListPrice   sqlite.ColumnInteger
+	CouponCode  sqlite.ColumnString
+	BundleID    sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type OrderItemsTable struct {
+	orderItemsTable
+
+	EXCLUDED orderItemsTable
+}
+
+// AS creates new OrderItemsTable with assigned alias
+func (a OrderItemsTable) AS(alias string) *OrderItemsTable {
+	return newOrderItemsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new OrderItemsTable with assigned schema name
+func (a OrderItemsTable) FromSchema(schemaName string) *OrderItemsTable {
+	return newOrderItemsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new OrderItemsTable with assigned table prefix
+func (a OrderItemsTable) WithPrefix(prefix string) *OrderItemsTable {
+	return newOrderItemsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new OrderItemsTable with assigned table suffix
+func (a OrderItemsTable) WithSuffix(suffix string) *OrderItemsTable {
+	return newOrderItemsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newOrderItemsTable(schemaName, tableName, alias string) *OrderItemsTable {
+	return &OrderItemsTable{
+		orderItemsTable: newOrderItemsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:        newOrderItemsTableImpl("", "excluded", ""),
+	}
+}
+
+func newOrderItemsTableImpl(schemaName, tableName, alias string) orderItemsTable {
+	var (
+		IDColumn          = sqlite.IntegerColumn("id")
+		OrderIDColumn     = sqlite.IntegerColumn("order_id")
+		ArtIDColumn       = sqlite.IntegerColumn("art_id")
+		LicenseIDColumn   = sqlite.IntegerColumn("license_id")
+		CreatorIDColumn   = sqlite.IntegerColumn("creator_id")
+		ArtNameColumn     = sqlite.StringColumn("art_name")
+		LicenseNameColumn = sqlite.StringColumn("license_name")
+		PriceColumn       = sqlite.IntegerColumn("price")
+		FeeColumn         = sqlite.IntegerColumn("fee")
+		ListPriceColumn   = sqlite.IntegerColumn("list_price")
+		CouponCodeColumn  = sqlite.StringColumn("coupon_code")
+		BundleIDColumn    = sqlite.IntegerColumn("bundle_id")
+		allColumns        = sqlite.ColumnList{IDColumn, OrderIDColumn, ArtIDColumn, LicenseIDColumn, CreatorIDColumn, ArtNameColumn, LicenseNameColumn, PriceColumn, FeeColumn, ListPriceColumn, CouponCodeColumn, BundleIDColumn}
+		mutableColumns    = sqlite.ColumnList{OrderIDColumn, ArtIDColumn, LicenseIDColumn, CreatorIDColumn, ArtNameColumn, LicenseNameColumn, PriceColumn, FeeColumn, ListPriceColumn, CouponCodeColumn, BundleIDColumn}
+	)
+
+	return orderItemsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:          IDColumn,
+		OrderID:     OrderIDColumn,
+		ArtID:       ArtIDColumn,
+		LicenseID:   LicenseIDColumn,
+		CreatorID:   CreatorIDColumn,
+		ArtName:     ArtNameColumn,
+		LicenseName: LicenseNameColumn,
+		Price:       PriceColumn,
+		Fee:         FeeColumn,
+		ListPrice:   ListPriceColumn,
+		CouponCode:  CouponCodeColumn,
+		BundleID:    BundleIDColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
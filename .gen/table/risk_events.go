@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var RiskEvents = newRiskEventsTable("", "risk_events", "")
+
+type riskEventsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	UserID    sqlite.ColumnInteger
+	Action    sqlite.ColumnString
+	SubjectID sqlite.ColumnInteger
+	IP        sqlite.ColumnString
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type RiskEventsTable struct {
+	riskEventsTable
+
+	EXCLUDED riskEventsTable
+}
+
+// AS creates new RiskEventsTable with assigned alias
+func (a RiskEventsTable) AS(alias string) *RiskEventsTable {
+	return newRiskEventsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new RiskEventsTable with assigned schema name
+func (a RiskEventsTable) FromSchema(schemaName string) *RiskEventsTable {
+	return newRiskEventsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new RiskEventsTable with assigned table prefix
+func (a RiskEventsTable) WithPrefix(prefix string) *RiskEventsTable {
+	return newRiskEventsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new RiskEventsTable with assigned table suffix
+func (a RiskEventsTable) WithSuffix(suffix string) *RiskEventsTable {
+	return newRiskEventsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newRiskEventsTable(schemaName, tableName, alias string) *RiskEventsTable {
+	return &RiskEventsTable{
+		riskEventsTable: newRiskEventsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:        newRiskEventsTableImpl("", "excluded", ""),
+	}
+}
+
+func newRiskEventsTableImpl(schemaName, tableName, alias string) riskEventsTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		ActionColumn    = sqlite.StringColumn("action")
+		SubjectIDColumn = sqlite.IntegerColumn("subject_id")
+		IPColumn        = sqlite.StringColumn("ip")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{IDColumn, UserIDColumn, ActionColumn, SubjectIDColumn, IPColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{UserIDColumn, ActionColumn, SubjectIDColumn, IPColumn, CreatedAtColumn}
+	)
+
+	return riskEventsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		UserID:    UserIDColumn,
+		Action:    ActionColumn,
+		SubjectID: SubjectIDColumn,
+		IP:        IPColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
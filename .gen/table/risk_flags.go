@@ -0,0 +1,108 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var RiskFlags = newRiskFlagsTable("", "risk_flags", "")
+
+type riskFlagsTable struct {
+	sqlite.Table
+
+	// Columns
+	ID         sqlite.ColumnInteger
+	EventID    sqlite.ColumnInteger
+	UserID     sqlite.ColumnInteger
+	Action     sqlite.ColumnString
+	SubjectID  sqlite.ColumnInteger
+	Rules      sqlite.ColumnString
+	Score      sqlite.ColumnInteger
+	Decision   sqlite.ColumnString
+	Status     sqlite.ColumnString
+	ReviewedBy sqlite.ColumnInteger
+	CreatedAt  sqlite.ColumnTimestamp
+	UpdatedAt  sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type RiskFlagsTable struct {
+	riskFlagsTable
+
+	EXCLUDED riskFlagsTable
+}
+
+// AS creates new RiskFlagsTable with assigned alias
+func (a RiskFlagsTable) AS(alias string) *RiskFlagsTable {
+	return newRiskFlagsTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new RiskFlagsTable with assigned schema name
+func (a RiskFlagsTable) FromSchema(schemaName string) *RiskFlagsTable {
+	return newRiskFlagsTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new RiskFlagsTable with assigned table prefix
+func (a RiskFlagsTable) WithPrefix(prefix string) *RiskFlagsTable {
+	return newRiskFlagsTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new RiskFlagsTable with assigned table suffix
+func (a RiskFlagsTable) WithSuffix(suffix string) *RiskFlagsTable {
+	return newRiskFlagsTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newRiskFlagsTable(schemaName, tableName, alias string) *RiskFlagsTable {
+	return &RiskFlagsTable{
+		riskFlagsTable: newRiskFlagsTableImpl(schemaName, tableName, alias),
+		EXCLUDED:       newRiskFlagsTableImpl("", "excluded", ""),
+	}
+}
+
+func newRiskFlagsTableImpl(schemaName, tableName, alias string) riskFlagsTable {
+	var (
+		IDColumn         = sqlite.IntegerColumn("id")
+		EventIDColumn    = sqlite.IntegerColumn("event_id")
+		UserIDColumn     = sqlite.IntegerColumn("user_id")
+		ActionColumn     = sqlite.StringColumn("action")
+		SubjectIDColumn  = sqlite.IntegerColumn("subject_id")
+		RulesColumn      = sqlite.StringColumn("rules")
+		ScoreColumn      = sqlite.IntegerColumn("score")
+		DecisionColumn   = sqlite.StringColumn("decision")
+		StatusColumn     = sqlite.StringColumn("status")
+		ReviewedByColumn = sqlite.IntegerColumn("reviewed_by")
+		CreatedAtColumn  = sqlite.TimestampColumn("created_at")
+		UpdatedAtColumn  = sqlite.TimestampColumn("updated_at")
+		allColumns       = sqlite.ColumnList{IDColumn, EventIDColumn, UserIDColumn, ActionColumn, SubjectIDColumn, RulesColumn, ScoreColumn, DecisionColumn, StatusColumn, ReviewedByColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns   = sqlite.ColumnList{EventIDColumn, UserIDColumn, ActionColumn, SubjectIDColumn, RulesColumn, ScoreColumn, DecisionColumn, StatusColumn, ReviewedByColumn, CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return riskFlagsTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:         IDColumn,
+		EventID:    EventIDColumn,
+		UserID:     UserIDColumn,
+		Action:     ActionColumn,
+		SubjectID:  SubjectIDColumn,
+		Rules:      RulesColumn,
+		Score:      ScoreColumn,
+		Decision:   DecisionColumn,
+		Status:     StatusColumn,
+		ReviewedBy: ReviewedByColumn,
+		CreatedAt:  CreatedAtColumn,
+		UpdatedAt:  UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var SubscriptionTiers = newSubscriptionTiersTable("", "subscription_tiers", "")
+
+type subscriptionTiersTable struct {
+	sqlite.Table
+
+	// Columns
+	ID          sqlite.ColumnInteger
+	CreatorID   sqlite.ColumnInteger
+	Name        sqlite.ColumnString
+	Description sqlite.ColumnString
+	Price       sqlite.ColumnInteger
+	CreatedAt   sqlite.ColumnTimestamp
+	UpdatedAt   sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type SubscriptionTiersTable struct {
+	subscriptionTiersTable
+
+	EXCLUDED subscriptionTiersTable
+}
+
+// AS creates new SubscriptionTiersTable with assigned alias
+func (a SubscriptionTiersTable) AS(alias string) *SubscriptionTiersTable {
+	return newSubscriptionTiersTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new SubscriptionTiersTable with assigned schema name
+func (a SubscriptionTiersTable) FromSchema(schemaName string) *SubscriptionTiersTable {
+	return newSubscriptionTiersTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new SubscriptionTiersTable with assigned table prefix
+func (a SubscriptionTiersTable) WithPrefix(prefix string) *SubscriptionTiersTable {
+	return newSubscriptionTiersTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new SubscriptionTiersTable with assigned table suffix
+func (a SubscriptionTiersTable) WithSuffix(suffix string) *SubscriptionTiersTable {
+	return newSubscriptionTiersTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newSubscriptionTiersTable(schemaName, tableName, alias string) *SubscriptionTiersTable {
+	return &SubscriptionTiersTable{
+		subscriptionTiersTable: newSubscriptionTiersTableImpl(schemaName, tableName, alias),
+		EXCLUDED:               newSubscriptionTiersTableImpl("", "excluded", ""),
+	}
+}
+
+func newSubscriptionTiersTableImpl(schemaName, tableName, alias string) subscriptionTiersTable {
+	var (
+		IDColumn          = sqlite.IntegerColumn("id")
+		CreatorIDColumn   = sqlite.IntegerColumn("creator_id")
+		NameColumn        = sqlite.StringColumn("name")
+		DescriptionColumn = sqlite.StringColumn("description")
+		PriceColumn       = sqlite.IntegerColumn("price")
+		CreatedAtColumn   = sqlite.TimestampColumn("created_at")
+		UpdatedAtColumn   = sqlite.TimestampColumn("updated_at")
+		allColumns        = sqlite.ColumnList{IDColumn, CreatorIDColumn, NameColumn, DescriptionColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn}
+		mutableColumns    = sqlite.ColumnList{CreatorIDColumn, NameColumn, DescriptionColumn, PriceColumn, CreatedAtColumn, UpdatedAtColumn}
+	)
+
+	return subscriptionTiersTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:          IDColumn,
+		CreatorID:   CreatorIDColumn,
+		Name:        NameColumn,
+		Description: DescriptionColumn,
+		Price:       PriceColumn,
+		CreatedAt:   CreatedAtColumn,
+		UpdatedAt:   UpdatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
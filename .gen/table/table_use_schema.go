@@ -10,18 +10,53 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	ArtLicenses = ArtLicenses.FromSchema(schema)
+	ArtVersions = ArtVersions.FromSchema(schema)
+	ArtVersionsFiles = ArtVersionsFiles.FromSchema(schema)
 	Arts = Arts.FromSchema(schema)
 	ArtsTags = ArtsTags.FromSchema(schema)
+	AuctionBids = AuctionBids.FromSchema(schema)
+	Auctions = Auctions.FromSchema(schema)
+	Bundles = Bundles.FromSchema(schema)
+	BundlesArts = BundlesArts.FromSchema(schema)
+	CartItems = CartItems.FromSchema(schema)
 	Codes = Codes.FromSchema(schema)
+	CodesUsers = CodesUsers.FromSchema(schema)
+	CoinPackages = CoinPackages.FromSchema(schema)
+	CoinTransactionLegs = CoinTransactionLegs.FromSchema(schema)
+	CoinTransactions = CoinTransactions.FromSchema(schema)
+	CommissionEvents = CommissionEvents.FromSchema(schema)
+	CommissionFiles = CommissionFiles.FromSchema(schema)
+	Commissions = Commissions.FromSchema(schema)
+	CouponRedemptions = CouponRedemptions.FromSchema(schema)
+	Coupons = Coupons.FromSchema(schema)
+	CouponsArts = CouponsArts.FromSchema(schema)
+	CouponsTags = CouponsTags.FromSchema(schema)
 	DownloadedArts = DownloadedArts.FromSchema(schema)
 	Files = Files.FromSchema(schema)
 	Follow = Follow.FromSchema(schema)
+	Gifts = Gifts.FromSchema(schema)
+	IdempotencyKeys = IdempotencyKeys.FromSchema(schema)
+	Notifications = Notifications.FromSchema(schema)
 	Oauths = Oauths.FromSchema(schema)
+	OrderItems = OrderItems.FromSchema(schema)
+	Orders = Orders.FromSchema(schema)
+	Payments = Payments.FromSchema(schema)
+	Promotions = Promotions.FromSchema(schema)
+	PromotionsArts = PromotionsArts.FromSchema(schema)
+	RefundRequests = RefundRequests.FromSchema(schema)
+	RiskEvents = RiskEvents.FromSchema(schema)
+	RiskFlags = RiskFlags.FromSchema(schema)
 	SchemaMigrations = SchemaMigrations.FromSchema(schema)
+	SubscriptionTiers = SubscriptionTiers.FromSchema(schema)
+	Subscriptions = Subscriptions.FromSchema(schema)
 	Tags = Tags.FromSchema(schema)
+	Tips = Tips.FromSchema(schema)
 	Tokens = Tokens.FromSchema(schema)
 	Users = Users.FromSchema(schema)
 	UsersBoughtArts = UsersBoughtArts.FromSchema(schema)
+	UsersBoughtBundles = UsersBoughtBundles.FromSchema(schema)
 	UsersStarredArts = UsersStarredArts.FromSchema(schema)
 	UsersUsedCodes = UsersUsedCodes.FromSchema(schema)
+	Withdrawals = Withdrawals.FromSchema(schema)
 }
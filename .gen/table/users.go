@@ -26,6 +26,7 @@ type usersTable struct {
 	Coin      sqlite.ColumnInteger
 	CreatedAt sqlite.ColumnTimestamp
 	UpdatedAt sqlite.ColumnTimestamp
+	Earnings  sqlite.ColumnInteger
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -75,8 +76,9 @@ func newUsersTableImpl(schemaName, tableName, alias string) usersTable {
 		CoinColumn      = sqlite.IntegerColumn("coin")
 		CreatedAtColumn = sqlite.TimestampColumn("created_at")
 		UpdatedAtColumn = sqlite.TimestampColumn("updated_at")
-		allColumns      = sqlite.ColumnList{IDColumn, UsernameColumn, EmailColumn, PasswordColumn, AvatarURLColumn, IsAdminColumn, CoinColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns  = sqlite.ColumnList{UsernameColumn, EmailColumn, PasswordColumn, AvatarURLColumn, IsAdminColumn, CoinColumn, CreatedAtColumn, UpdatedAtColumn}
+		EarningsColumn  = sqlite.IntegerColumn("earnings")
+		allColumns      = sqlite.ColumnList{IDColumn, UsernameColumn, EmailColumn, PasswordColumn, AvatarURLColumn, IsAdminColumn, CoinColumn, CreatedAtColumn, UpdatedAtColumn, EarningsColumn}
+		mutableColumns  = sqlite.ColumnList{UsernameColumn, EmailColumn, PasswordColumn, AvatarURLColumn, IsAdminColumn, CoinColumn, CreatedAtColumn, UpdatedAtColumn, EarningsColumn}
 	)
 
 	return usersTable{
@@ -92,6 +94,7 @@ func newUsersTableImpl(schemaName, tableName, alias string) usersTable {
 		Coin:      CoinColumn,
 		CreatedAt: CreatedAtColumn,
 		UpdatedAt: UpdatedAtColumn,
+		Earnings:  EarningsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
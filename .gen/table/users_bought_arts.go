@@ -17,8 +17,11 @@ type usersBoughtArtsTable struct {
 	sqlite.Table
 
 	// Columns
-	UserID sqlite.ColumnInteger
-	ArtID  sqlite.ColumnInteger
+	UserID        sqlite.ColumnInteger
+	ArtID         sqlite.ColumnInteger
+	LicenseID     sqlite.ColumnInteger
+	DownloadCount sqlite.ColumnInteger
+	EditionNumber sqlite.ColumnInteger
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -59,18 +62,24 @@ func newUsersBoughtArtsTable(schemaName, tableName, alias string) *UsersBoughtAr
 
 func newUsersBoughtArtsTableImpl(schemaName, tableName, alias string) usersBoughtArtsTable {
 	var (
-		UserIDColumn   = sqlite.IntegerColumn("user_id")
-		ArtIDColumn    = sqlite.IntegerColumn("art_id")
-		allColumns     = sqlite.ColumnList{UserIDColumn, ArtIDColumn}
-		mutableColumns = sqlite.ColumnList{}
+		UserIDColumn        = sqlite.IntegerColumn("user_id")
+		ArtIDColumn         = sqlite.IntegerColumn("art_id")
+		LicenseIDColumn     = sqlite.IntegerColumn("license_id")
+		DownloadCountColumn = sqlite.IntegerColumn("download_count")
+		EditionNumberColumn = sqlite.IntegerColumn("edition_number")
+		allColumns          = sqlite.ColumnList{UserIDColumn, ArtIDColumn, LicenseIDColumn, DownloadCountColumn, EditionNumberColumn}
+		mutableColumns      = sqlite.ColumnList{LicenseIDColumn, DownloadCountColumn, EditionNumberColumn}
 	)
 
 	return usersBoughtArtsTable{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UserID: UserIDColumn,
-		ArtID:  ArtIDColumn,
+		UserID:        UserIDColumn,
+		ArtID:         ArtIDColumn,
+		LicenseID:     LicenseIDColumn,
+		DownloadCount: DownloadCountColumn,
+		EditionNumber: EditionNumberColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
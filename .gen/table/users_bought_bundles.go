@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var UsersBoughtBundles = newUsersBoughtBundlesTable("", "users_bought_bundles", "")
+
+type usersBoughtBundlesTable struct {
+	sqlite.Table
+
+	// Columns
+	UserID    sqlite.ColumnInteger
+	BundleID  sqlite.ColumnInteger
+	Price     sqlite.ColumnInteger
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type UsersBoughtBundlesTable struct {
+	usersBoughtBundlesTable
+
+	EXCLUDED usersBoughtBundlesTable
+}
+
+// AS creates new UsersBoughtBundlesTable with assigned alias
+func (a UsersBoughtBundlesTable) AS(alias string) *UsersBoughtBundlesTable {
+	return newUsersBoughtBundlesTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new UsersBoughtBundlesTable with assigned schema name
+func (a UsersBoughtBundlesTable) FromSchema(schemaName string) *UsersBoughtBundlesTable {
+	return newUsersBoughtBundlesTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new UsersBoughtBundlesTable with assigned table prefix
+func (a UsersBoughtBundlesTable) WithPrefix(prefix string) *UsersBoughtBundlesTable {
+	return newUsersBoughtBundlesTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new UsersBoughtBundlesTable with assigned table suffix
+func (a UsersBoughtBundlesTable) WithSuffix(suffix string) *UsersBoughtBundlesTable {
+	return newUsersBoughtBundlesTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newUsersBoughtBundlesTable(schemaName, tableName, alias string) *UsersBoughtBundlesTable {
+	return &UsersBoughtBundlesTable{
+		usersBoughtBundlesTable: newUsersBoughtBundlesTableImpl(schemaName, tableName, alias),
+		EXCLUDED:                newUsersBoughtBundlesTableImpl("", "excluded", ""),
+	}
+}
+
+func newUsersBoughtBundlesTableImpl(schemaName, tableName, alias string) usersBoughtBundlesTable {
+	var (
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		BundleIDColumn  = sqlite.IntegerColumn("bundle_id")
+		PriceColumn     = sqlite.IntegerColumn("price")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{UserIDColumn, BundleIDColumn, PriceColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{PriceColumn, CreatedAtColumn}
+	)
+
+	return usersBoughtBundlesTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		UserID:    UserIDColumn,
+		BundleID:  BundleIDColumn,
+		Price:     PriceColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -17,8 +17,11 @@ type usersUsedCodesTable struct {
 	sqlite.Table
 
 	// Columns
-	UserID sqlite.ColumnInteger
-	CodeID sqlite.ColumnInteger
+	ID        sqlite.ColumnInteger
+	UserID    sqlite.ColumnInteger
+	CodeID    sqlite.ColumnInteger
+	Value     sqlite.ColumnInteger
+	CreatedAt sqlite.ColumnTimestamp
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -59,18 +62,24 @@ func newUsersUsedCodesTable(schemaName, tableName, alias string) *UsersUsedCodes
 
 func newUsersUsedCodesTableImpl(schemaName, tableName, alias string) usersUsedCodesTable {
 	var (
-		UserIDColumn   = sqlite.IntegerColumn("user_id")
-		CodeIDColumn   = sqlite.IntegerColumn("code_id")
-		allColumns     = sqlite.ColumnList{UserIDColumn, CodeIDColumn}
-		mutableColumns = sqlite.ColumnList{}
+		IDColumn        = sqlite.IntegerColumn("id")
+		UserIDColumn    = sqlite.IntegerColumn("user_id")
+		CodeIDColumn    = sqlite.IntegerColumn("code_id")
+		ValueColumn     = sqlite.IntegerColumn("value")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{IDColumn, UserIDColumn, CodeIDColumn, ValueColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{UserIDColumn, CodeIDColumn, ValueColumn, CreatedAtColumn}
 	)
 
 	return usersUsedCodesTable{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UserID: UserIDColumn,
-		CodeID: CodeIDColumn,
+		ID:        IDColumn,
+		UserID:    UserIDColumn,
+		CodeID:    CodeIDColumn,
+		Value:     ValueColumn,
+		CreatedAt: CreatedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
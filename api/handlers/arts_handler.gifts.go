@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *ArtsHandler) GiftArt(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.GiftArtDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	req := types.GiftArtReq{
+		BuyArtReq: types.BuyArtReq{
+			UserId:         payload.UserId,
+			ArtId:          artId,
+			LicenseId:      dto.LicenseId,
+			Price:          dto.Price,
+			IdempotencyKey: c.Request().Header.Get("Idempotency-Key"),
+		},
+		RecipientName: dto.Username,
+		Message:       dto.Message,
+	}
+	if err := h.artsSvc.GiftArt(req); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *ArtsHandler) ReceivedGifts(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	gifts, err := h.artsSvc.FindManyReceivedGifts(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.ReceivedGifts(gifts), http.StatusOK)
+}
+
+func (h *ArtsHandler) ClaimGift(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.ErrToast, ErrPayloadNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	artId, err := h.artsSvc.ClaimGift(id, payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", fmt.Sprintf("/arts/%d", artId))
+	return c.NoContent(http.StatusOK)
+}
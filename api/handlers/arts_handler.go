@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,8 +9,10 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/DeepAung/deep-art/.gen/model"
 	"github.com/DeepAung/deep-art/api/services"
 	"github.com/DeepAung/deep-art/api/types"
 	"github.com/DeepAung/deep-art/pkg/config"
@@ -23,20 +26,26 @@ import (
 )
 
 type ArtsHandler struct {
-	artsSvc *services.ArtsSvc
-	storer  storer.Storer
-	cfg     *config.Config
+	artsSvc    *services.ArtsSvc
+	bundlesSvc *services.BundlesSvc
+	riskSvc    *services.RiskSvc
+	storer     storer.Storer
+	cfg        *config.Config
 }
 
 func NewArtsHandler(
 	artsSvc *services.ArtsSvc,
+	bundlesSvc *services.BundlesSvc,
+	riskSvc *services.RiskSvc,
 	storer storer.Storer,
 	cfg *config.Config,
 ) *ArtsHandler {
 	return &ArtsHandler{
-		artsSvc: artsSvc,
-		storer:  storer,
-		cfg:     cfg,
+		artsSvc:    artsSvc,
+		bundlesSvc: bundlesSvc,
+		riskSvc:    riskSvc,
+		storer:     storer,
+		cfg:        cfg,
 	}
 }
 
@@ -68,7 +77,12 @@ func (h *ArtsHandler) CreateArt(c echo.Context) error {
 		return utils.Render(c, components.Error("no \"files\" field"), http.StatusBadRequest)
 	}
 
-	if err := h.artsSvc.CreateArt(payload.UserId, dto); err != nil {
+	publishAt, err := parsePublishAt(dto.PublishAt)
+	if err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.artsSvc.CreateArt(payload.UserId, dto, publishAt); err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
@@ -90,12 +104,26 @@ func (h *ArtsHandler) UpdateArt(c echo.Context) error {
 		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
 	}
 
+	publishAt, err := parsePublishAt(dto.PublishAt)
+	if err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
 	if err := h.artsSvc.UpdateArtInfo(types.UpdateArtInfoReq{
 		ArtId:       artId,
 		Name:        dto.Name,
 		Description: dto.Description,
-		Price:       dto.Price,
 		TagsID:      dto.TagsID,
+		Status:      dto.Status,
+		PublishAt:   publishAt,
+		EditionSize: types.EditionSize(dto.EditionSize),
+
+		PayWhatYouWant: dto.PayWhatYouWant,
+		SuggestedPrice: types.SuggestedPrice(dto.PayWhatYouWant, dto.SuggestedPrice),
+		MembersTierId:  types.MembersTierId(dto.MembersTierId),
+
+		DownloadLimit:     types.DownloadLimit(dto.DownloadLimit),
+		DownloadRateLimit: types.DownloadLimit(dto.DownloadRateLimit),
 	}); err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
@@ -104,6 +132,20 @@ func (h *ArtsHandler) UpdateArt(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// parsePublishAt parses the value of a datetime-local input. An empty value
+// means the art is not scheduled.
+func parsePublishAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var publishAt types.CustomTime
+	if err := publishAt.UnmarshalParam(value); err != nil {
+		return nil, err
+	}
+	return &publishAt.Time, nil
+}
+
 func (h *ArtsHandler) DeleteArt(c echo.Context) error {
 	artId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -118,88 +160,168 @@ func (h *ArtsHandler) DeleteArt(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *ArtsHandler) RestoreArt(c echo.Context) error {
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.artsSvc.RestoreArt(artId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *ArtsHandler) DownloadArt(c echo.Context) error {
 	artId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
-	url := utils.Join(h.cfg.App.BasePath, fmt.Sprintf("zip-files/%d.zip", artId))
-	res, err := http.Get(url)
+	version, err := h.artsSvc.FindLatestArtVersion(artId)
 	if err != nil {
-		return err
+		return utils.RenderError(c, components.Error, err)
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		id := uuid.New().String()
-		folderPath := "tmp/" + id
-		zipName := fmt.Sprintf("%d.zip", artId)
-		zipDest := utils.Join(folderPath, zipName)
+	return h.downloadArtVersion(c, version)
+}
 
-		if err := os.MkdirAll(folderPath, os.ModePerm); err != nil {
-			return err
-		}
-		defer os.RemoveAll(folderPath)
+func (h *ArtsHandler) DownloadArtVersion(c echo.Context) error {
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
 
-		art, err := h.artsSvc.FindOneArt(artId)
-		if err != nil {
-			return err
-		}
+	versionNo, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
 
-		filespath := make([]string, len(art.Files))
-		urls := make([]string, len(art.Files))
-		for i := range len(art.Files) {
-			filespath[i] = utils.Join(folderPath, art.Files[i].Filename)
-			urls[i] = art.Files[i].URL
-		}
+	version, err := h.artsSvc.FindOneArtVersion(artId, versionNo)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
 
-		if err = utils.DownloadFiles(filespath, urls); err != nil {
-			return err
-		}
+	return h.downloadArtVersion(c, version)
+}
 
-		// create zip file locally
-		if err = utils.CreateZipFile(filespath, zipDest, zipName); err != nil {
-			return err
-		}
+// downloadArtVersion sends the zip file of the version with the license
+// terms of the user added as LICENSE.txt and the manifest of the download as
+// manifest.json.
+func (h *ArtsHandler) downloadArtVersion(c echo.Context, version types.ArtVersion) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
 
-		// sent zip file to user
-		if err := c.Attachment(zipDest, zipName); err != nil {
-			return err
-		}
+	artId := int(version.ArtID)
+	zipName := fmt.Sprintf("%d-v%d.zip", artId, version.Version)
+	folder := strings.Split(zipName, ".")[0]
 
-		// upload zip file to bucket
-		f, err := os.Open(zipDest)
+	b, err := h.findArtVersionZip(version, zipName)
+	if err != nil {
+		return err
+	}
+
+	manifest := types.DownloadManifest{
+		ArtId:        artId,
+		Version:      int(version.Version),
+		Files:        utils.Map(version.Files, func(f model.ArtVersionsFiles) string { return f.Filename }),
+		DownloadedBy: payload.UserId,
+		DownloadedAt: time.Now().UTC(),
+	}
+
+	// set by middlewares.CanDownload, the creator has no license
+	if license, ok := c.Get("license").(model.ArtLicenses); ok {
+		b, err = utils.AppendToZip(b, folder+"/LICENSE.txt", []byte(licenseText(license)))
 		if err != nil {
 			return err
 		}
-		if _, err := h.storer.UploadFile(f, "zip-files/"+zipName); err != nil {
-			slog.Error(err.Error())
-			return nil
-		}
+		manifest.License = license.Name
+	}
+	manifest.Edition, _ = c.Get("edition").(*types.Edition)
 
-		if err := h.artsSvc.AddDownloadedArt(artId); err != nil {
-			slog.Error(err.Error())
-			return nil
-		}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	b, err = utils.AppendToZip(b, folder+"/manifest.json", manifestJSON)
+	if err != nil {
+		return err
+	}
 
-		return nil
+	// claimed once the zip file is built, so a failed download is not counted
+	if err := h.artsSvc.ClaimDownload(payload.UserId, artId); err != nil {
+		return utils.RenderError(c, components.Error, err)
 	}
 
+	c.Response().
+		Header().
+		Add("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, zipName))
+	return c.Blob(http.StatusOK, "application/zip", b)
+}
+
+// findArtVersionZip returns the zip file of the version. Versions never change
+// after they are created, so the zip file is cached in the bucket per version.
+func (h *ArtsHandler) findArtVersionZip(version types.ArtVersion, zipName string) ([]byte, error) {
+	zipPath := fmt.Sprintf("zip-files/%d/v%d.zip", version.ArtID, version.Version)
+
+	url := utils.Join(h.cfg.App.BasePath, zipPath)
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
 	if res.StatusCode == 200 {
-		c.Response().
-			Header().
-			Add("Content-Disposition", fmt.Sprintf(`attachment; filename="%d.zip"`, artId))
+		return io.ReadAll(res.Body)
+	}
+	if res.StatusCode != 404 {
+		return nil, fmt.Errorf("cannot get zip file, status code %d", res.StatusCode)
+	}
 
-		b, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		c.Response().Write(b)
-		return nil
+	id := uuid.New().String()
+	folderPath := "tmp/" + id
+	zipDest := utils.Join(folderPath, zipName)
+
+	if err := os.MkdirAll(folderPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(folderPath)
+
+	filespath := make([]string, len(version.Files))
+	urls := make([]string, len(version.Files))
+	for i := range len(version.Files) {
+		filespath[i] = utils.Join(folderPath, version.Files[i].Filename)
+		urls[i] = version.Files[i].URL
+	}
+
+	if err = utils.DownloadFiles(filespath, urls); err != nil {
+		return nil, err
 	}
 
-	return c.NoContent(http.StatusInternalServerError)
+	// create zip file locally
+	if err = utils.CreateZipFile(filespath, zipDest, zipName); err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(zipDest)
+	if err != nil {
+		return nil, err
+	}
+
+	// upload zip file to bucket
+	if _, err := h.storer.UploadFile(bytes.NewReader(b), zipPath); err != nil {
+		slog.Error(err.Error())
+	}
+
+	return b, nil
+}
+
+func licenseText(license model.ArtLicenses) string {
+	return fmt.Sprintf("%s License\n\n%s\n", license.Name, license.Terms)
 }
 
 func tryDeleteFiles(causeErr error, files []string) {
@@ -210,6 +332,20 @@ func tryDeleteFiles(causeErr error, files []string) {
 	}
 }
 
+func (h *ArtsHandler) DownloadHistory(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	downloads, err := h.artsSvc.FindManyDownloads(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.DownloadHistory(downloads), http.StatusOK)
+}
+
 func (h *ArtsHandler) UploadFiles(c echo.Context) error {
 	artId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -225,7 +361,7 @@ func (h *ArtsHandler) UploadFiles(c echo.Context) error {
 		return utils.Render(c, components.Error("no \"files\" field"), http.StatusBadRequest)
 	}
 
-	if err := h.artsSvc.UploadFiles(artId, files); err != nil {
+	if err := h.artsSvc.UploadFiles(artId, files, c.FormValue("note")); err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
@@ -244,7 +380,10 @@ func (h *ArtsHandler) DeleteFile(c echo.Context) error {
 		return utils.RenderError(c, components.Error, err)
 	}
 
-	if err := h.artsSvc.DeleteFile(artId, fileId); err != nil {
+	// the changelog note comes from the hx-prompt of the delete button
+	note := c.Request().Header.Get("HX-Prompt")
+
+	if err := h.artsSvc.DeleteFile(artId, fileId, note); err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
@@ -267,7 +406,7 @@ func (h *ArtsHandler) ReplaceCover(c echo.Context) error {
 		return utils.Render(c, components.Error("no \"cover\" field"), http.StatusBadRequest)
 	}
 
-	if err := h.artsSvc.ReplaceCover(artId, covers[0]); err != nil {
+	if err := h.artsSvc.ReplaceCover(artId, covers[0], c.FormValue("note")); err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
@@ -289,6 +428,11 @@ func (h *ArtsHandler) FindManyArts(c echo.Context) error {
 		return utils.RenderError(c, components.Error, err)
 	}
 
+	arts.Bundles, err = h.bundlesSvc.FindManyBundles(req)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
 	time.Sleep(300 * time.Millisecond)
 	return utils.Render(c, components.ManyArts(arts, false), http.StatusOK)
 }
@@ -391,6 +535,74 @@ func (h *ArtsHandler) getValidatedManyArtsReq(c echo.Context) (types.ManyArtsReq
 	return req, nil
 }
 
+func (h *ArtsHandler) CreateArtLicense(c echo.Context) error {
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.ArtLicenseDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.artsSvc.CreateArtLicense(artId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *ArtsHandler) UpdateArtLicense(c echo.Context) error {
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	licenseId, err := strconv.Atoi(c.Param("licenseId"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.ArtLicenseDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.artsSvc.UpdateArtLicense(artId, licenseId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *ArtsHandler) DeleteArtLicense(c echo.Context) error {
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	licenseId, err := strconv.Atoi(c.Param("licenseId"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.artsSvc.DeleteArtLicense(artId, licenseId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *ArtsHandler) BuyArt(c echo.Context) error {
 	payload, ok := c.Get("payload").(mytoken.Payload)
 	if !ok {
@@ -402,12 +614,36 @@ func (h *ArtsHandler) BuyArt(c echo.Context) error {
 		return utils.RenderError(c, components.ErrToast, err)
 	}
 
+	licenseId, err := strconv.Atoi(c.FormValue("licenseId"))
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
 	price, err := strconv.Atoi(c.FormValue("price"))
 	if err != nil {
 		return utils.RenderError(c, components.ErrToast, err)
 	}
 
-	if err := h.artsSvc.BuyArt(payload.UserId, artId, price); err != nil {
+	req := types.BuyArtReq{
+		UserId:         payload.UserId,
+		ArtId:          artId,
+		LicenseId:      licenseId,
+		Price:          price,
+		CouponCode:     c.FormValue("couponCode"),
+		IdempotencyKey: c.Request().Header.Get("Idempotency-Key"),
+	}
+
+	err = h.riskSvc.Screen(types.RiskEventReq{
+		UserId:    payload.UserId,
+		Action:    types.RiskActionBuyArt,
+		SubjectId: artId,
+		IP:        c.RealIP(),
+	})
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	if err := h.artsSvc.BuyArt(req); err != nil {
 		return utils.RenderError(c, components.ErrToast, err)
 	}
 
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type AuctionsHandler struct {
+	auctionsSvc *services.AuctionsSvc
+}
+
+func NewAuctionsHandler(auctionsSvc *services.AuctionsSvc) *AuctionsHandler {
+	return &AuctionsHandler{
+		auctionsSvc: auctionsSvc,
+	}
+}
+
+// ArtAuction renders the latest auction of the art. The art's page polls it
+// to follow the bids.
+func (h *AuctionsHandler) ArtAuction(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	auction, err := h.auctionsSvc.FindLatestAuction(artId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(
+		c,
+		components.ArtAuction(auction, payload.UserId, time.Now().UTC()),
+		http.StatusOK,
+	)
+}
+
+func (h *AuctionsHandler) CreateAuction(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.AuctionDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.auctionsSvc.CreateAuction(artId, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *AuctionsHandler) CancelAuction(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.auctionsSvc.CancelAuction(payload.UserId, artId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *AuctionsHandler) PlaceBid(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.BidDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.auctionsSvc.PlaceBid(artId, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusCreated)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type BundlesHandler struct {
+	bundlesSvc *services.BundlesSvc
+}
+
+func NewBundlesHandler(bundlesSvc *services.BundlesSvc) *BundlesHandler {
+	return &BundlesHandler{
+		bundlesSvc: bundlesSvc,
+	}
+}
+
+func (h *BundlesHandler) CreateBundle(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	var dto types.BundleDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	bundleId, err := h.bundlesSvc.CreateBundle(payload.UserId, dto)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", fmt.Sprintf("/bundles/%d", bundleId))
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *BundlesHandler) UpdateBundle(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	bundleId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.BundleDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.bundlesSvc.UpdateBundle(bundleId, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *BundlesHandler) DeleteBundle(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	bundleId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.bundlesSvc.DeleteBundle(payload.UserId, bundleId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *BundlesHandler) BuyBundle(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	bundleId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	price, err := strconv.Atoi(c.FormValue("price"))
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	if err := h.bundlesSvc.BuyBundle(payload.UserId, bundleId, price); err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return nil
+}
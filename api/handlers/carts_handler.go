@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type CartsHandler struct {
+	cartsSvc *services.CartsSvc
+}
+
+func NewCartsHandler(cartsSvc *services.CartsSvc) *CartsHandler {
+	return &CartsHandler{
+		cartsSvc: cartsSvc,
+	}
+}
+
+func (h *CartsHandler) GetCart(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	cart, err := h.cartsSvc.FindCart(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.Cart(cart), http.StatusOK)
+}
+
+func (h *CartsHandler) CartBadge(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	count, err := h.cartsSvc.CountCartItems(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.CartBadge(count), http.StatusOK)
+}
+
+func (h *CartsHandler) AddCartItem(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	var dto types.CartItemDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.ErrToast(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.ErrToast(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.cartsSvc.AddCartItem(payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	c.Response().Header().Add("HX-Trigger", "cart-changed")
+	return utils.Render(c, components.AddedToCart(), http.StatusOK)
+}
+
+func (h *CartsHandler) RemoveCartItem(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("artId"))
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	if err := h.cartsSvc.RemoveCartItem(payload.UserId, artId); err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	cart, err := h.cartsSvc.FindCart(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	c.Response().Header().Add("HX-Trigger", "cart-changed")
+	return utils.Render(c, components.Cart(cart), http.StatusOK)
+}
+
+func (h *CartsHandler) Checkout(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	var dto types.CheckoutDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.ErrToast(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.ErrToast(err.Error()), http.StatusBadRequest)
+	}
+
+	if _, err := h.cartsSvc.Checkout(payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.ErrToast, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", "/orders")
+	return c.NoContent(http.StatusOK)
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/DeepAung/deep-art/api/services"
 	"github.com/DeepAung/deep-art/api/types"
@@ -15,12 +19,18 @@ import (
 
 type CodesHandler struct {
 	codesSvc *services.CodesSvc
+	riskSvc  *services.RiskSvc
 	cfg      *config.Config
 }
 
-func NewCodesHandler(codesSvc *services.CodesSvc, cfg *config.Config) *CodesHandler {
+func NewCodesHandler(
+	codesSvc *services.CodesSvc,
+	riskSvc *services.RiskSvc,
+	cfg *config.Config,
+) *CodesHandler {
 	return &CodesHandler{
 		codesSvc: codesSvc,
+		riskSvc:  riskSvc,
 		cfg:      cfg,
 	}
 }
@@ -40,29 +50,43 @@ func (h *CodesHandler) UseCode(c echo.Context) error {
 		return utils.Render(c, components.Error("code should not be empty"), http.StatusBadRequest)
 	}
 
-	err := h.codesSvc.UseCode(payload.UserId, name)
+	code, err := h.codesSvc.FindOneCodeByName(name)
 	if err != nil {
 		return utils.RenderError(c, components.Error, err)
 	}
 
+	err = h.riskSvc.Screen(types.RiskEventReq{
+		UserId:    payload.UserId,
+		Action:    types.RiskActionUseCode,
+		SubjectId: int(*code.ID),
+		IP:        c.RealIP(),
+	})
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.codesSvc.UseCode(payload.UserId, int(*code.ID)); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
 	c.Response().Header().Add("HX-Refresh", "true")
 	return nil
 }
 
 func (h *CodesHandler) CreateCode(c echo.Context) error {
-	var req types.CodeReq
-	if err := c.Bind(&req); err != nil {
+	var dto types.CodeDTO
+	if err := c.Bind(&dto); err != nil {
 		c.Response().Header().Add("HX-Retarget", "#create-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
 		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
 	}
-	if err := utils.Validate(&req); err != nil {
+	if err := utils.Validate(&dto); err != nil {
 		c.Response().Header().Add("HX-Retarget", "#create-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
 		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
 	}
 
-	code, err := h.codesSvc.CreateCode(req)
+	code, err := h.codesSvc.CreateCode(dto)
 	if err != nil {
 		c.Response().Header().Add("HX-Retarget", "#create-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
@@ -78,7 +102,79 @@ func (h *CodesHandler) GetCodes(c echo.Context) error {
 		return utils.RenderError(c, components.Error, err)
 	}
 
-	return utils.Render(c, components.Codes(codes), http.StatusOK)
+	batches, err := h.codesSvc.GetCodeBatches()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.Codes(codes, batches), http.StatusOK)
+}
+
+func (h *CodesHandler) CreateCodeBatch(c echo.Context) error {
+	var dto types.CodeBatchDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.codesSvc.CreateCodeBatch(dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusCreated)
+}
+
+// ExportCodeBatch sends the codes of the batch and their redemptions as a
+// CSV file.
+func (h *CodesHandler) ExportCodeBatch(c echo.Context) error {
+	batch := c.Param("batch")
+
+	codes, err := h.codesSvc.GetBatchCodes(batch)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	b, err := codesCSV(codes)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	c.Response().
+		Header().
+		Add("Content-Disposition", fmt.Sprintf(`attachment; filename="codes-%s.csv"`, batch))
+	return c.Blob(http.StatusOK, "text/csv", b)
+}
+
+func codesCSV(codes []types.Code) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{"code", "value", "starts_at", "exp_time", "max_uses", "per_user_limit", "uses"})
+	for _, code := range codes {
+		startsAt, maxUses := "", ""
+		if code.StartsAt != nil {
+			startsAt = code.StartsAt.Format(time.RFC3339)
+		}
+		if code.MaxUses != nil {
+			maxUses = strconv.Itoa(int(*code.MaxUses))
+		}
+
+		_ = w.Write([]string{
+			code.Name,
+			strconv.Itoa(int(code.Value)),
+			startsAt,
+			code.ExpTime.Format(time.RFC3339),
+			maxUses,
+			strconv.Itoa(int(code.PerUserLimit)),
+			strconv.Itoa(code.Uses),
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
 }
 
 func (h *CodesHandler) UpdateCode(c echo.Context) error {
@@ -89,19 +185,19 @@ func (h *CodesHandler) UpdateCode(c echo.Context) error {
 		return utils.RenderError(c, components.Error, err)
 	}
 
-	var req types.CodeReq
-	if err := c.Bind(&req); err != nil {
+	var dto types.CodeDTO
+	if err := c.Bind(&dto); err != nil {
 		c.Response().Header().Add("HX-Retarget", "#update-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
 		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
 	}
-	if err := utils.Validate(&req); err != nil {
+	if err := utils.Validate(&dto); err != nil {
 		c.Response().Header().Add("HX-Retarget", "#update-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
 		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
 	}
 
-	code, err := h.codesSvc.UpdateCode(id, req)
+	code, err := h.codesSvc.UpdateCode(id, dto)
 	if err != nil {
 		c.Response().Header().Add("HX-Retarget", "#update-code-error")
 		c.Response().Header().Add("HX-Reswap", "innerHTML")
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type CommissionsHandler struct {
+	commissionsSvc *services.CommissionsSvc
+}
+
+func NewCommissionsHandler(commissionsSvc *services.CommissionsSvc) *CommissionsHandler {
+	return &CommissionsHandler{
+		commissionsSvc: commissionsSvc,
+	}
+}
+
+func (h *CommissionsHandler) CreateCommission(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	creatorId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CommissionDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.commissionsSvc.CreateCommission(payload.UserId, creatorId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", "/commissions")
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *CommissionsHandler) MyCommissions(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	commissions, err := h.commissionsSvc.FindManyCommissions(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.Commissions(commissions, payload.UserId), http.StatusOK)
+}
+
+func (h *CommissionsHandler) DisputedCommissions(c echo.Context) error {
+	commissions, err := h.commissionsSvc.FindAllDisputedCommissions()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.DisputedCommissions(commissions), http.StatusOK)
+}
+
+// CommissionDetail renders the files and the history of the commission, with
+// the moves the user can make.
+func (h *CommissionsHandler) CommissionDetail(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrUserDataNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	commission, err := h.commissionsSvc.FindOneCommission(id, user.Id, user.IsAdmin)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	role := commission.RoleOf(user.Id)
+	if role == "" {
+		role = types.CommissionRoleAdmin
+	}
+
+	return utils.Render(c, components.CommissionDetail(commission, role), http.StatusOK)
+}
+
+func (h *CommissionsHandler) QuoteCommission(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CommissionQuoteDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.commissionsSvc.QuoteCommission(id, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CommissionsHandler) DeliverCommission(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	files, ok := form.File["files"]
+	if !ok || len(files) == 0 {
+		return utils.Render(c, components.Error("no \"files\" field"), http.StatusBadRequest)
+	}
+
+	err = h.commissionsSvc.DeliverCommission(id, payload.UserId, files, c.FormValue("note"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CommissionsHandler) AcceptCommission(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CommissionAcceptDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.commissionsSvc.AcceptCommission(id, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CommissionsHandler) CancelCommission(c echo.Context) error {
+	return h.moveCommission(c, types.CommissionRoleBuyer, types.CommissionStatusCancelled)
+}
+
+func (h *CommissionsHandler) CompleteCommission(c echo.Context) error {
+	return h.moveCommission(c, types.CommissionRoleBuyer, types.CommissionStatusCompleted)
+}
+
+func (h *CommissionsHandler) DisputeCommission(c echo.Context) error {
+	return h.moveCommission(c, types.CommissionRoleBuyer, types.CommissionStatusDisputed)
+}
+
+func (h *CommissionsHandler) DeclineCommission(c echo.Context) error {
+	return h.moveCommission(c, types.CommissionRoleCreator, types.CommissionStatusDeclined)
+}
+
+func (h *CommissionsHandler) moveCommission(c echo.Context, role, status string) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	return h.moveCommissionAs(c, payload.UserId, role, status)
+}
+
+// ReleaseCommission settles the dispute for the creator.
+func (h *CommissionsHandler) ReleaseCommission(c echo.Context) error {
+	return h.settleCommission(c, types.CommissionStatusCompleted)
+}
+
+// RefundCommission settles the dispute for the buyer.
+func (h *CommissionsHandler) RefundCommission(c echo.Context) error {
+	return h.settleCommission(c, types.CommissionStatusRefunded)
+}
+
+func (h *CommissionsHandler) settleCommission(c echo.Context, status string) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrUserDataNotFound)
+	}
+
+	return h.moveCommissionAs(c, user.Id, types.CommissionRoleAdmin, status)
+}
+
+func (h *CommissionsHandler) moveCommissionAs(
+	c echo.Context,
+	actorId int,
+	role, status string,
+) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CommissionNoteDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.commissionsSvc.MoveCommission(id, actorId, role, status, dto.Note); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
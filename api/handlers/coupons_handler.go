@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type CouponsHandler struct {
+	couponsSvc *services.CouponsSvc
+	artsSvc    *services.ArtsSvc
+}
+
+func NewCouponsHandler(couponsSvc *services.CouponsSvc, artsSvc *services.ArtsSvc) *CouponsHandler {
+	return &CouponsHandler{
+		couponsSvc: couponsSvc,
+		artsSvc:    artsSvc,
+	}
+}
+
+func (h *CouponsHandler) CreateCoupon(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	var dto types.CouponDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.couponsSvc.CreateCoupon(payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CouponsHandler) UpdateCoupon(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	couponId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CouponDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.couponsSvc.UpdateCoupon(couponId, payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *CouponsHandler) DeleteCoupon(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	couponId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.couponsSvc.DeleteCoupon(payload.UserId, couponId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+// ApplyCoupon renders the licenses of the art with the prices after the
+// running promotion and the discount of the coupon.
+func (h *CouponsHandler) ApplyCoupon(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	coupon, err := h.couponsSvc.FindApplicableCoupon(artId, c.QueryParam("code"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	art, err := h.artsSvc.FindOneArt(artId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	isBought, err := h.artsSvc.IsBought(payload.UserId, artId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var bought types.BoughtLicense
+	if isBought {
+		bought, err = h.artsSvc.FindBoughtLicense(payload.UserId, artId)
+		if err != nil {
+			return utils.RenderError(c, components.Error, err)
+		}
+	}
+
+	return utils.Render(
+		c,
+		components.ArtLicenses(art, isBought, bought, &coupon),
+		http.StatusOK,
+	)
+}
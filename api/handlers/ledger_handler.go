@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type LedgerHandler struct {
+	ledgerSvc *services.LedgerSvc
+}
+
+func NewLedgerHandler(ledgerSvc *services.LedgerSvc) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerSvc: ledgerSvc,
+	}
+}
+
+func (h *LedgerHandler) WalletHistory(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	entries, err := h.ledgerSvc.FindManyWalletEntries(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.WalletHistory(entries), http.StatusOK)
+}
+
+func (h *LedgerHandler) CreatorEarnings(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	earnings, err := h.ledgerSvc.FindCreatorEarnings(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.CreatorEarnings(earnings), http.StatusOK)
+}
+
+func (h *LedgerHandler) LedgerReport(c echo.Context) error {
+	report, err := h.ledgerSvc.FindLedgerReport()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.LedgerReport(report), http.StatusOK)
+}
+
+func (h *LedgerHandler) AdjustCoin(c echo.Context) error {
+	var dto types.AdjustCoinDTO
+	if err := c.Bind(&dto); err != nil {
+		c.Response().Header().Add("HX-Retarget", "#adjust-coin-error")
+		c.Response().Header().Add("HX-Reswap", "innerHTML")
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		c.Response().Header().Add("HX-Retarget", "#adjust-coin-error")
+		c.Response().Header().Add("HX-Reswap", "innerHTML")
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.ledgerSvc.AdjustCoin(dto); err != nil {
+		c.Response().Header().Add("HX-Retarget", "#adjust-coin-error")
+		c.Response().Header().Add("HX-Reswap", "innerHTML")
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return h.LedgerReport(c)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationsHandler struct {
+	notificationsSvc *services.NotificationsSvc
+}
+
+func NewNotificationsHandler(notificationsSvc *services.NotificationsSvc) *NotificationsHandler {
+	return &NotificationsHandler{
+		notificationsSvc: notificationsSvc,
+	}
+}
+
+func (h *NotificationsHandler) NotificationsBadge(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	count, err := h.notificationsSvc.CountUnreadNotifications(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.NotificationsBadge(count), http.StatusOK)
+}
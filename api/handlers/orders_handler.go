@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/pdf"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/DeepAung/deep-art/views/pages"
+	"github.com/labstack/echo/v4"
+)
+
+type OrdersHandler struct {
+	ordersSvc *services.OrdersSvc
+}
+
+func NewOrdersHandler(ordersSvc *services.OrdersSvc) *OrdersHandler {
+	return &OrdersHandler{
+		ordersSvc: ordersSvc,
+	}
+}
+
+func (h *OrdersHandler) MyOrders(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	orders, err := h.ordersSvc.FindManyOrders(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.Orders(orders), http.StatusOK)
+}
+
+func (h *OrdersHandler) MySales(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	sales, err := h.ordersSvc.FindManySales(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.OrderSales(sales), http.StatusOK)
+}
+
+// Receipt downloads the receipt of the order as HTML, or as PDF with
+// ?format=pdf.
+func (h *OrdersHandler) Receipt(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrPayloadNotFound)
+	}
+
+	orderId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.Render(c, pages.Error("Page Not Found"), http.StatusNotFound)
+	}
+
+	order, err := h.ordersSvc.FindMyOrder(payload.UserId, orderId)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	filename := "receipt-" + order.Receipt()
+	if c.QueryParam("format") == "pdf" {
+		c.Response().Header().Add("Content-Disposition", `attachment; filename="`+filename+`.pdf"`)
+		return c.Blob(http.StatusOK, "application/pdf", receiptPDF(order))
+	}
+
+	c.Response().Header().Add("Content-Disposition", `attachment; filename="`+filename+`.html"`)
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return utils.Render(c, pages.Receipt(order), http.StatusOK)
+}
+
+// receiptColumns lays out the items of the receipt in the monospaced font.
+const receiptColumns = "%-44.44s %10s %10s %10s"
+
+func receiptPDF(order types.Order) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	x, y := 50.0, 790.0
+	text := func(font pdf.Font, size float64, s string) {
+		if y < 50 {
+			page, y = doc.AddPage(), 790
+		}
+		page.Text(x, y, font, size, s)
+		y -= size + 6
+	}
+	line := func() {
+		page.Line(x, y+4, pdf.PageWidth-x, y+4)
+		y -= 10
+	}
+
+	text(pdf.HelveticaBold, 20, "DeepArt")
+	text(pdf.Helvetica, 12, "Receipt "+order.Receipt())
+	if order.CreatedAt != nil {
+		text(pdf.Helvetica, 10, "Date: "+order.CreatedAt.Format("2 Jan 2006 15:04 MST"))
+	}
+	text(pdf.Helvetica, 10, fmt.Sprintf("Billed to: %s <%s>", order.Buyer.Username, order.Buyer.Email))
+	text(pdf.Helvetica, 10, "Purchase: "+order.Title())
+	if order.IsRefunded() {
+		text(pdf.HelveticaBold, 10, "This order was refunded.")
+	}
+	y -= 10
+
+	text(pdf.Courier, 10, fmt.Sprintf(receiptColumns, "Item", "List", "Discount", "Paid"))
+	line()
+	for _, item := range order.Items {
+		text(pdf.Courier, 10, fmt.Sprintf(
+			receiptColumns,
+			types.OrderItemName(item),
+			fmt.Sprint(item.ListPrice),
+			fmt.Sprint(item.ListPrice-item.Price),
+			fmt.Sprint(item.Price),
+		))
+		if item.CouponCode != nil {
+			text(pdf.Courier, 10, "  coupon "+*item.CouponCode)
+		}
+	}
+	line()
+	text(pdf.Courier, 10, fmt.Sprintf(receiptColumns, "Subtotal", "", "", fmt.Sprint(order.Subtotal())))
+	text(pdf.Courier, 10, fmt.Sprintf(receiptColumns, "Discount", "", "", fmt.Sprint(-order.Discount())))
+	text(pdf.Courier, 10, fmt.Sprintf(receiptColumns, "Total (Coin)", "", "", fmt.Sprint(order.Total)))
+
+	return doc.Bytes()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/DeepAung/deep-art/api/types"
 	"github.com/DeepAung/deep-art/pkg/utils"
@@ -26,7 +27,12 @@ func (h *PagesHandler) CreatorCreateArt(c echo.Context) error {
 		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
 	}
 
-	return utils.Render(c, pages.CreatorCreateArt(user), http.StatusOK)
+	tiers, err := h.subscriptionsSvc.FindManyTiers(user.Id, user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.CreatorCreateArt(user, tiers), http.StatusOK)
 }
 
 func (h *PagesHandler) CreatorArtDetail(c echo.Context) error {
@@ -52,7 +58,12 @@ func (h *PagesHandler) CreatorArtDetail(c echo.Context) error {
 		return utils.RenderError(c, pages.Error, err)
 	}
 
-	return utils.Render(c, pages.CreatorArtDetail(user, art, tags), http.StatusOK)
+	tiers, err := h.subscriptionsSvc.FindManyTiers(user.Id, user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.CreatorArtDetail(user, art, tags, tiers), http.StatusOK)
 }
 
 func (h *PagesHandler) CreatorProfile(c echo.Context) error {
@@ -73,3 +84,106 @@ func (h *PagesHandler) CreatorProfile(c echo.Context) error {
 
 	return utils.Render(c, pages.CreatorProfile(me, creator), http.StatusOK)
 }
+
+func (h *PagesHandler) CreatorTrash(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	arts, err := h.artsSvc.FindManyDeletedArts(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(
+		c,
+		pages.CreatorTrash(user, arts, h.artsSvc.TrashRetention()),
+		http.StatusOK,
+	)
+}
+
+func (h *PagesHandler) CreatorBundles(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	bundles, err := h.bundlesSvc.FindManyCreatedBundles(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	arts, err := h.bundlesSvc.FindManyBundleableArts(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.CreatorBundles(user, bundles, arts), http.StatusOK)
+}
+
+func (h *PagesHandler) CreatorCoupons(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	coupons, err := h.couponsSvc.FindManyCreatedCoupons(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	arts, err := h.bundlesSvc.FindManyBundleableArts(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	tags, err := h.tagsSvc.GetTags()
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.CreatorCoupons(user, coupons, arts, tags), http.StatusOK)
+}
+
+func (h *PagesHandler) CreatorPromotions(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	promotions, err := h.promotionsSvc.FindManyCreatedPromotions(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	arts, err := h.bundlesSvc.FindManyBundleableArts(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(
+		c,
+		pages.CreatorPromotions(user, promotions, arts, time.Now().UTC()),
+		http.StatusOK,
+	)
+}
+
+func (h *PagesHandler) CreatorSubscriptions(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	tiers, err := h.subscriptionsSvc.FindManyTiers(user.Id, user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	subscribers, err := h.subscriptionsSvc.FindManySubscribers(user.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.CreatorSubscriptions(user, tiers, subscribers), http.StatusOK)
+}
@@ -12,20 +12,35 @@ import (
 )
 
 type PagesHandler struct {
-	usersSvc *services.UsersSvc
-	artsSvc  *services.ArtsSvc
-	tagsSvc  *services.TagsSvc
+	usersSvc         *services.UsersSvc
+	artsSvc          *services.ArtsSvc
+	tagsSvc          *services.TagsSvc
+	notificationsSvc *services.NotificationsSvc
+	bundlesSvc       *services.BundlesSvc
+	couponsSvc       *services.CouponsSvc
+	promotionsSvc    *services.PromotionsSvc
+	subscriptionsSvc *services.SubscriptionsSvc
 }
 
 func NewPagesHandler(
 	usersSvc *services.UsersSvc,
 	artsSvc *services.ArtsSvc,
 	tagsSvc *services.TagsSvc,
+	notificationsSvc *services.NotificationsSvc,
+	bundlesSvc *services.BundlesSvc,
+	couponsSvc *services.CouponsSvc,
+	promotionsSvc *services.PromotionsSvc,
+	subscriptionsSvc *services.SubscriptionsSvc,
 ) *PagesHandler {
 	return &PagesHandler{
-		usersSvc: usersSvc,
-		artsSvc:  artsSvc,
-		tagsSvc:  tagsSvc,
+		usersSvc:         usersSvc,
+		artsSvc:          artsSvc,
+		tagsSvc:          tagsSvc,
+		notificationsSvc: notificationsSvc,
+		bundlesSvc:       bundlesSvc,
+		couponsSvc:       couponsSvc,
+		promotionsSvc:    promotionsSvc,
+		subscriptionsSvc: subscriptionsSvc,
 	}
 }
 
@@ -66,6 +81,14 @@ func (h *PagesHandler) ArtDetail(c echo.Context) error {
 		return utils.RenderError(c, pages.Error, err)
 	}
 
+	canView, err := h.artsSvc.CanView(user.Id, art)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+	if !canView {
+		return utils.Render(c, pages.Error("Page Not Found"), http.StatusNotFound)
+	}
+
 	isFollowing, err := h.usersSvc.IsFollowing(user.Id, art.Creator.Id)
 	if err != nil {
 		return utils.RenderError(c, pages.Error, err)
@@ -81,13 +104,61 @@ func (h *PagesHandler) ArtDetail(c echo.Context) error {
 		return utils.RenderError(c, pages.Error, err)
 	}
 
+	var bought types.BoughtLicense
+	if isBought {
+		bought, err = h.artsSvc.FindBoughtLicense(user.Id, artId)
+		if err != nil {
+			return utils.RenderError(c, pages.Error, err)
+		}
+	}
+
+	var isMember bool
+	if art.IsMembersOnly() {
+		isMember, err = h.artsSvc.HasMembersAccess(user.Id, artId)
+		if err != nil {
+			return utils.RenderError(c, pages.Error, err)
+		}
+	}
+
 	return utils.Render(
 		c,
-		pages.ArtDetail(user, art, isFollowing, isStarred, isBought),
+		pages.ArtDetail(user, art, isFollowing, isStarred, isBought, bought, isMember),
 		http.StatusOK,
 	)
 }
 
+func (h *PagesHandler) BundleDetail(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	bundleId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.Render(c, pages.Error("Page Not Found"), http.StatusNotFound)
+	}
+
+	bundle, err := h.bundlesSvc.FindOneBundle(bundleId)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	forSale, err := h.bundlesSvc.IsForSale(bundleId)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+	if !forSale && int(bundle.CreatorID) != user.Id {
+		return utils.Render(c, pages.Error("Page Not Found"), http.StatusNotFound)
+	}
+
+	owned, err := h.bundlesSvc.FindOwnedArtsID(user.Id, bundleId)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.BundleDetail(user, bundle, owned, forSale), http.StatusOK)
+}
+
 func (h *PagesHandler) MyProfile(c echo.Context) error {
 	me, ok := c.Get("user").(types.User)
 	if !ok {
@@ -101,3 +172,48 @@ func (h *PagesHandler) MyProfile(c echo.Context) error {
 
 	return utils.Render(c, pages.MyProfile(me, oauthInfo), http.StatusOK)
 }
+
+func (h *PagesHandler) Notifications(c echo.Context) error {
+	me, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	notifications, err := h.notificationsSvc.FindManyNotifications(me.Id)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	if err := h.notificationsSvc.ReadAllNotifications(me.Id); err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.Notifications(me, notifications), http.StatusOK)
+}
+
+func (h *PagesHandler) Cart(c echo.Context) error {
+	me, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	return utils.Render(c, pages.Cart(me), http.StatusOK)
+}
+
+func (h *PagesHandler) Orders(c echo.Context) error {
+	me, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	return utils.Render(c, pages.Orders(me), http.StatusOK)
+}
+
+func (h *PagesHandler) Commissions(c echo.Context) error {
+	me, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	return utils.Render(c, pages.Commissions(me), http.StatusOK)
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/payment"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/DeepAung/deep-art/views/pages"
+	"github.com/labstack/echo/v4"
+)
+
+var ErrFakeGatewayNotUsed = httperror.New("fake payment provider is not used", http.StatusNotFound)
+
+type PaymentsHandler struct {
+	paymentsSvc *services.PaymentsSvc
+	gateway     payment.Gateway
+	cfg         *config.Config
+}
+
+func NewPaymentsHandler(
+	paymentsSvc *services.PaymentsSvc,
+	gateway payment.Gateway,
+	cfg *config.Config,
+) *PaymentsHandler {
+	return &PaymentsHandler{
+		paymentsSvc: paymentsSvc,
+		gateway:     gateway,
+		cfg:         cfg,
+	}
+}
+
+func (h *PaymentsHandler) GetCoinPackages(c echo.Context) error {
+	pkgs, err := h.paymentsSvc.GetCoinPackages(true)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.CoinPackages(pkgs, h.cfg.Payment.Currency), http.StatusOK)
+}
+
+func (h *PaymentsHandler) Checkout(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	packageId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	url, err := h.paymentsSvc.Checkout(payload.UserId, packageId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", url)
+	return c.NoContent(http.StatusOK)
+}
+
+// Webhook is called by the payment provider, so it is not authorized and
+// answers in JSON.
+func (h *PaymentsHandler) Webhook(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	if err := h.paymentsSvc.HandleWebhook(body, c.Request().Header); err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *PaymentsHandler) GetAllCoinPackages(c echo.Context) error {
+	pkgs, err := h.paymentsSvc.GetCoinPackages(false)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.CoinPackagesAdmin(pkgs, h.cfg.Payment.Currency), http.StatusOK)
+}
+
+func (h *PaymentsHandler) CreateCoinPackage(c echo.Context) error {
+	var dto types.CoinPackageDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.paymentsSvc.CreateCoinPackage(dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *PaymentsHandler) UpdateCoinPackage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.CoinPackageDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.paymentsSvc.UpdateCoinPackage(id, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *PaymentsHandler) DeleteCoinPackage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.paymentsSvc.DeleteCoinPackage(id); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *PaymentsHandler) GetPayments(c echo.Context) error {
+	payments, err := h.paymentsSvc.GetPayments()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.Payments(payments), http.StatusOK)
+}
+
+func (h *PaymentsHandler) Reconcile(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := h.paymentsSvc.Reconcile(id); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+// FakeCheckout is the checkout page of the fake provider, where the payment
+// can be paid or failed by hand.
+func (h *PaymentsHandler) FakeCheckout(c echo.Context) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrUserDataNotFound)
+	}
+
+	gateway, ok := h.gateway.(*payment.FakeGateway)
+	if !ok {
+		return utils.RenderError(c, pages.Error, ErrFakeGatewayNotUsed)
+	}
+
+	providerId := c.Param("id")
+	p, err := gateway.FindCheckout(providerId)
+	if err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+	if _, err := h.paymentsSvc.FindOwnPayment(user.Id, p.PaymentId); err != nil {
+		return utils.RenderError(c, pages.Error, err)
+	}
+
+	return utils.Render(c, pages.FakeCheckout(user, providerId, p), http.StatusOK)
+}
+
+// CompleteFakeCheckout settles the fake payment of the user and delivers its
+// webhook the same way the provider would.
+func (h *PaymentsHandler) CompleteFakeCheckout(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	gateway, ok := h.gateway.(*payment.FakeGateway)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrFakeGatewayNotUsed)
+	}
+
+	providerId := c.Param("id")
+	p, err := gateway.FindCheckout(providerId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+	if _, err := h.paymentsSvc.FindOwnPayment(payload.UserId, p.PaymentId); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	succeeded, _ := strconv.ParseBool(c.FormValue("succeeded"))
+	webhook, err := gateway.Complete(providerId, succeeded)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+	if err := h.paymentsSvc.HandleWebhook(webhook.Body, webhook.Header); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Redirect", "/me")
+	return c.NoContent(http.StatusOK)
+}
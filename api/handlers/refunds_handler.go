@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type RefundsHandler struct {
+	refundsSvc *services.RefundsSvc
+}
+
+func NewRefundsHandler(refundsSvc *services.RefundsSvc) *RefundsHandler {
+	return &RefundsHandler{
+		refundsSvc: refundsSvc,
+	}
+}
+
+func (h *RefundsHandler) RequestRefund(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	artId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.RefundRequestDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.refundsSvc.RequestRefund(payload.UserId, artId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *RefundsHandler) ApproveRefund(c echo.Context) error {
+	return h.decideRefund(c, h.refundsSvc.ApproveRefund)
+}
+
+func (h *RefundsHandler) RejectRefund(c echo.Context) error {
+	return h.decideRefund(c, h.refundsSvc.RejectRefund)
+}
+
+func (h *RefundsHandler) decideRefund(
+	c echo.Context,
+	decide func(id int, user types.User) error,
+) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrUserDataNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := decide(id, user); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *RefundsHandler) RequestedRefunds(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	refunds, err := h.refundsSvc.FindManyRequestedRefunds(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.RefundRequests(refunds, false), http.StatusOK)
+}
+
+func (h *RefundsHandler) IncomingRefunds(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	refunds, err := h.refundsSvc.FindManyIncomingRefunds(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.RefundRequests(refunds, true), http.StatusOK)
+}
+
+func (h *RefundsHandler) AllRefunds(c echo.Context) error {
+	refunds, err := h.refundsSvc.FindAllRefunds()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.RefundRequests(refunds, true), http.StatusOK)
+}
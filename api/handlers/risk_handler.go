@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type RiskHandler struct {
+	riskSvc *services.RiskSvc
+}
+
+func NewRiskHandler(riskSvc *services.RiskSvc) *RiskHandler {
+	return &RiskHandler{
+		riskSvc: riskSvc,
+	}
+}
+
+func (h *RiskHandler) RiskFlags(c echo.Context) error {
+	flags, err := h.riskSvc.FindManyOpenRiskFlags()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.RiskFlags(flags), http.StatusOK)
+}
+
+func (h *RiskHandler) ClearRiskFlag(c echo.Context) error {
+	return h.reviewRiskFlag(c, h.riskSvc.ClearRiskFlag)
+}
+
+func (h *RiskHandler) ConfirmRiskFlag(c echo.Context) error {
+	return h.reviewRiskFlag(c, h.riskSvc.ConfirmRiskFlag)
+}
+
+func (h *RiskHandler) reviewRiskFlag(c echo.Context, review func(id, reviewerId int) error) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrUserDataNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	if err := review(id, user.Id); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
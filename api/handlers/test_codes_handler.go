@@ -4,20 +4,27 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/services"
 	"github.com/DeepAung/deep-art/api/types"
 	"github.com/DeepAung/deep-art/pkg/utils"
 	"github.com/labstack/echo/v4"
 )
 
 type testCodesHandler struct {
-	codesRepo *repositories.CodesRepo
+	codesRepo     *repositories.CodesRepo
+	newUserWindow time.Duration
 }
 
-func NewTestCodesHandler(codesRepo *repositories.CodesRepo) *testCodesHandler {
+func NewTestCodesHandler(
+	codesRepo *repositories.CodesRepo,
+	newUserWindow time.Duration,
+) *testCodesHandler {
 	return &testCodesHandler{
-		codesRepo: codesRepo,
+		codesRepo:     codesRepo,
+		newUserWindow: newUserWindow,
 	}
 }
 
@@ -45,17 +52,27 @@ func (h *testCodesHandler) FindOneCodeById(c echo.Context) error {
 }
 
 func (h *testCodesHandler) CreateCode(c echo.Context) error {
-	var req types.CodeReq
-	if err := c.Bind(&req); err != nil {
+	var dto types.CodeDTO
+	if err := c.Bind(&dto); err != nil {
 		return c.JSON(http.StatusBadGateway, err.Error())
 	}
-	log.Printf("===== req: %+v", req)
+	log.Printf("===== dto: %+v", dto)
 
-	if err := utils.Validate(&req); err != nil {
+	if err := utils.Validate(&dto); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	code, err := h.codesRepo.CreateCode(req)
+	rules, err := services.NewCodeRules(dto.CodeRulesDTO)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	id, err := h.codesRepo.CreateCode(types.CodeReq{Name: dto.Name, CodeRules: rules})
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	code, err := h.codesRepo.FindOneCodeById(id)
 	if err != nil {
 		return utils.JSONError(c, err)
 	}
@@ -69,16 +86,21 @@ func (h *testCodesHandler) UpdateCode(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	var req types.CodeReq
-	if err := c.Bind(&req); err != nil {
+	var dto types.CodeDTO
+	if err := c.Bind(&dto); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	if err := utils.Validate(&req); err != nil {
+	if err := utils.Validate(&dto); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	if err := h.codesRepo.UpdateCode(id, req); err != nil {
+	rules, err := services.NewCodeRules(dto.CodeRulesDTO)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	if err := h.codesRepo.UpdateCode(id, types.CodeReq{Name: dto.Name, CodeRules: rules}); err != nil {
 		return utils.JSONError(c, err)
 	}
 
@@ -109,5 +131,11 @@ func (h *testCodesHandler) UseCode(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	return h.codesRepo.UseCode(userId, codeId)
+	now := time.Now().UTC()
+	return h.codesRepo.UseCode(types.UseCodeReq{
+		UserId:       userId,
+		CodeId:       codeId,
+		Now:          now,
+		NewUserSince: now.Add(-h.newUserWindow),
+	})
 }
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/mytoken"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/DeepAung/deep-art/views/components"
+	"github.com/labstack/echo/v4"
+)
+
+type WithdrawalsHandler struct {
+	withdrawalsSvc *services.WithdrawalsSvc
+}
+
+func NewWithdrawalsHandler(withdrawalsSvc *services.WithdrawalsSvc) *WithdrawalsHandler {
+	return &WithdrawalsHandler{
+		withdrawalsSvc: withdrawalsSvc,
+	}
+}
+
+func (h *WithdrawalsHandler) RequestWithdrawal(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	var dto types.WithdrawalDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+	if err := utils.Validate(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.withdrawalsSvc.RequestWithdrawal(payload.UserId, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *WithdrawalsHandler) MyWithdrawals(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrPayloadNotFound)
+	}
+
+	balance, err := h.withdrawalsSvc.FindWithdrawalBalance(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	withdrawals, err := h.withdrawalsSvc.FindManyWithdrawals(payload.UserId)
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(
+		c,
+		components.CreatorWithdrawals(
+			balance,
+			withdrawals,
+			h.withdrawalsSvc.WithdrawalMin(),
+			h.withdrawalsSvc.WithdrawalHold(),
+		),
+		http.StatusOK,
+	)
+}
+
+// ExportStatement sends every earnings entry of the creator as a CSV file.
+func (h *WithdrawalsHandler) ExportStatement(c echo.Context) error {
+	payload, ok := c.Get("payload").(mytoken.Payload)
+	if !ok {
+		return utils.JSONError(c, ErrPayloadNotFound)
+	}
+
+	entries, err := h.withdrawalsSvc.FindStatement(payload.UserId)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	b, err := statementCSV(entries)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	c.Response().Header().Add("Content-Disposition", `attachment; filename="earnings-statement.csv"`)
+	return c.Blob(http.StatusOK, "text/csv", b)
+}
+
+func statementCSV(entries []types.WalletEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{"date", "transaction_id", "type", "item", "amount", "balance"})
+	balance := 0
+	for _, entry := range entries {
+		date, item := "", ""
+		if entry.Transaction.CreatedAt != nil {
+			date = entry.Transaction.CreatedAt.Format(time.RFC3339)
+		}
+		if entry.Art != nil {
+			item = entry.Art.Name
+		} else if entry.Bundle != nil {
+			item = "Bundle " + entry.Bundle.Name
+		} else {
+			item = entry.Transaction.Description
+		}
+		balance += entry.SignedAmount()
+
+		_ = w.Write([]string{
+			date,
+			strconv.Itoa(int(entry.TransactionID)),
+			entry.Transaction.Type,
+			item,
+			strconv.Itoa(entry.SignedAmount()),
+			strconv.Itoa(balance),
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (h *WithdrawalsHandler) AllWithdrawals(c echo.Context) error {
+	withdrawals, err := h.withdrawalsSvc.FindAllWithdrawals()
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	return utils.Render(c, components.WithdrawalQueue(withdrawals), http.StatusOK)
+}
+
+// ExportWithdrawals sends the withdrawals of every creator as a CSV file.
+func (h *WithdrawalsHandler) ExportWithdrawals(c echo.Context) error {
+	withdrawals, err := h.withdrawalsSvc.FindAllWithdrawals()
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	b, err := withdrawalsCSV(withdrawals)
+	if err != nil {
+		return utils.JSONError(c, err)
+	}
+
+	c.Response().Header().Add("Content-Disposition", `attachment; filename="withdrawals.csv"`)
+	return c.Blob(http.StatusOK, "text/csv", b)
+}
+
+func withdrawalsCSV(withdrawals []types.Withdrawal) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{
+		"id", "creator", "email", "amount", "status", "note", "admin_note", "requested_at", "paid_at",
+	})
+	for _, withdrawal := range withdrawals {
+		requestedAt, paidAt := "", ""
+		if withdrawal.CreatedAt != nil {
+			requestedAt = withdrawal.CreatedAt.Format(time.RFC3339)
+		}
+		if withdrawal.PaidAt != nil {
+			paidAt = withdrawal.PaidAt.Format(time.RFC3339)
+		}
+
+		_ = w.Write([]string{
+			strconv.Itoa(int(*withdrawal.ID)),
+			withdrawal.Creator.Username,
+			withdrawal.Creator.Email,
+			strconv.Itoa(int(withdrawal.Amount)),
+			withdrawal.Status,
+			withdrawal.Note,
+			withdrawal.AdminNote,
+			requestedAt,
+			paidAt,
+		})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (h *WithdrawalsHandler) ApproveWithdrawal(c echo.Context) error {
+	return h.decideWithdrawal(c, types.WithdrawalStatusApproved)
+}
+
+func (h *WithdrawalsHandler) RejectWithdrawal(c echo.Context) error {
+	return h.decideWithdrawal(c, types.WithdrawalStatusRejected)
+}
+
+func (h *WithdrawalsHandler) MarkWithdrawalPaid(c echo.Context) error {
+	return h.decideWithdrawal(c, types.WithdrawalStatusPaid)
+}
+
+func (h *WithdrawalsHandler) decideWithdrawal(c echo.Context, status string) error {
+	user, ok := c.Get("user").(types.User)
+	if !ok {
+		return utils.RenderError(c, components.Error, ErrUserDataNotFound)
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	var dto types.WithdrawalNoteDTO
+	if err := c.Bind(&dto); err != nil {
+		return utils.Render(c, components.Error(err.Error()), http.StatusBadRequest)
+	}
+
+	if err := h.withdrawalsSvc.DecideWithdrawal(id, status, user.Id, dto); err != nil {
+		return utils.RenderError(c, components.Error, err)
+	}
+
+	c.Response().Header().Add("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
@@ -231,6 +231,11 @@ func (m *Middleware) OwnedArt(artIdParam string) echo.MiddlewareFunc {
 	}
 }
 
+// CanDownload sets the license that the user downloads the art under as
+// "license", and the copy they hold of a limited-edition art as "edition".
+// The creator downloads the art without a license. Everyone else is held to
+// the rate limit of the art, and buyers to the download limit of their
+// purchase. Both are checked again when the download is claimed.
 func (m *Middleware) CanDownload(artIdParam string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -248,14 +253,9 @@ func (m *Middleware) CanDownload(artIdParam string) echo.MiddlewareFunc {
 				return utils.Render(c, components.Error("invalid art id"), http.StatusBadRequest)
 			}
 
-			// Free
-			art, err := m.artsSvc.FindOneArt(artId)
-			if err != nil {
+			if err := m.artsSvc.CheckDownloadRate(userId, artId); err != nil {
 				return utils.RenderError(c, components.Error, err)
 			}
-			if art.Price == 0 {
-				return next(c)
-			}
 
 			// Bought
 			bought, err := m.artsSvc.IsBought(userId, artId)
@@ -263,9 +263,49 @@ func (m *Middleware) CanDownload(artIdParam string) echo.MiddlewareFunc {
 				return utils.RenderError(c, components.Error, err)
 			}
 			if bought {
+				license, err := m.artsSvc.FindBoughtLicense(userId, artId)
+				if err != nil {
+					return utils.RenderError(c, components.Error, err)
+				}
+				if !license.CanDownload() {
+					return utils.Render(
+						c,
+						components.Error("you have reached the download limit of your purchase"),
+						http.StatusBadRequest,
+					)
+				}
+				c.Set("license", license.License)
+				c.Set("edition", license.Edition())
+				return next(c)
+			}
+
+			// Free, downloaded under the cheapest license
+			art, err := m.artsSvc.FindOneArt(artId)
+			if err != nil {
+				return utils.RenderError(c, components.Error, err)
+			}
+			if art.Price == 0 && art.IsPublic() && !art.IsMembersOnly() {
+				if len(art.Licenses) > 0 {
+					c.Set("license", art.Licenses[0])
+				}
 				return next(c)
 			}
 
+			// Members-only, downloaded by the subscribers under the cheapest
+			// license
+			if art.IsMembersOnly() && art.IsPublic() {
+				member, err := m.artsSvc.HasMembersAccess(userId, artId)
+				if err != nil {
+					return utils.RenderError(c, components.Error, err)
+				}
+				if member {
+					if len(art.Licenses) > 0 {
+						c.Set("license", art.Licenses[0])
+					}
+					return next(c)
+				}
+			}
+
 			// Owned
 			owned, err := m.artsSvc.Owned(userId, artId)
 			if err != nil {
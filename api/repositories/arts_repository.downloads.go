@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+const downloadHistoryLimit = 50
+
+var (
+	ErrDownloadRateLimited = httperror.New(
+		"you have reached the hourly download limit of this art, try again later",
+		http.StatusTooManyRequests,
+	)
+	ErrDownloadLimitReached = httperror.New(
+		"you have reached the download limit of your purchase",
+		http.StatusBadRequest,
+	)
+)
+
+// FindDownloadRate counts the downloads of the art by the user since the given
+// time, for the rate limit of the art.
+func (r *ArtsRepo) FindDownloadRate(userId, artId int, since time.Time) (types.DownloadRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(
+		Arts.DownloadRateLimit,
+		IntExp(recentDownloadsQuery(userId, artId, since)).AS("rate.count"),
+		Arts.CreatorID.EQ(Int(int64(userId))).AS("rate.is_creator"),
+	).
+		FROM(Arts).
+		WHERE(Arts.ID.EQ(Int(int64(artId))))
+
+	var dest types.DownloadRate
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art")
+	return dest, err
+}
+
+// ClaimDownload records the download of the art by the user before the art is
+// sent. It fails when the user has reached the rate limit of the art, or the
+// download limit of their purchase when they bought the art. The limits are
+// checked by the statements that count the download, so concurrent downloads
+// cannot go over them.
+func (r *ArtsRepo) ClaimDownload(userId, artId int, since time.Time) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := DownloadedArts.INSERT(DownloadedArts.UserID, DownloadedArts.ArtID).
+		QUERY(
+			SELECT(Int(int64(userId)), Arts.ID).
+				FROM(Arts).
+				WHERE(
+					Arts.ID.EQ(Int(int64(artId))).
+						AND(
+							Arts.DownloadRateLimit.IS_NULL().
+								OR(Arts.CreatorID.EQ(Int(int64(userId)))).
+								OR(IntExp(recentDownloadsQuery(userId, artId, since)).LT(Arts.DownloadRateLimit)),
+						),
+				),
+		)
+	if err := HandleExecCtxWithErr(stmt1, ctx, tx, ErrDownloadRateLimited); err != nil {
+		return err
+	}
+
+	bought, err := r.hasUsersBoughtArtsWithDB(ctx, tx, userId, artId)
+	if err != nil {
+		return err
+	}
+	if bought {
+		downloadCap := SELECT(ArtLicenses.DownloadCap).
+			FROM(ArtLicenses).
+			WHERE(ArtLicenses.ID.EQ(UsersBoughtArts.LicenseID))
+		downloadLimit := SELECT(Arts.DownloadLimit).
+			FROM(Arts).
+			WHERE(Arts.ID.EQ(UsersBoughtArts.ArtID))
+		unlimited := UsersBoughtArts.DownloadCount.ADD(Int(1))
+
+		stmt2 := UsersBoughtArts.UPDATE(UsersBoughtArts.DownloadCount).
+			SET(UsersBoughtArts.DownloadCount.ADD(Int(1))).
+			WHERE(
+				UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+					AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))).
+					AND(UsersBoughtArts.DownloadCount.LT(IntExp(COALESCE(downloadCap, unlimited)))).
+					AND(UsersBoughtArts.DownloadCount.LT(IntExp(COALESCE(downloadLimit, unlimited)))),
+			)
+		if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrDownloadLimitReached); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// recentDownloadsQuery counts the downloads of the art by the user since the
+// given time.
+func recentDownloadsQuery(userId, artId int, since time.Time) SelectStatement {
+	return SELECT(COUNT(DownloadedArts.ID)).
+		FROM(DownloadedArts).
+		WHERE(
+			DownloadedArts.ArtID.EQ(Int(int64(artId))).
+				AND(DownloadedArts.UserID.EQ(Int(int64(userId)))).
+				AND(DATETIME(DownloadedArts.CreatedAt).GT_EQ(DATETIME(since))),
+		)
+}
+
+// FindManyDownloads is the download history of the user, newest first.
+func (r *ArtsRepo) FindManyDownloads(userId int) ([]types.Download, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	art := Arts.AS("Art")
+	stmt := SELECT(
+		DownloadedArts.AllColumns,
+		art.ID,
+		art.Name,
+		art.CoverURL,
+	).
+		FROM(
+			DownloadedArts.
+				INNER_JOIN(art, art.ID.EQ(DownloadedArts.ArtID)),
+		).
+		WHERE(DownloadedArts.UserID.EQ(Int(int64(userId)))).
+		ORDER_BY(DownloadedArts.CreatedAt.DESC(), DownloadedArts.ID.DESC()).
+		LIMIT(downloadHistoryLimit)
+
+	dest := []types.Download{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "download")
+	return dest, err
+}
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrGiftNotFound     = ErrNotFound("gift")
+	ErrGiftToSelf       = httperror.New("you cannot gift an art to yourself", http.StatusBadRequest)
+	ErrGiftAlreadyOwned = httperror.New(
+		"the recipient already owns this art",
+		http.StatusBadRequest,
+	)
+	ErrGiftAlreadyClaimed = httperror.New("gift is already claimed", http.StatusBadRequest)
+)
+
+// GiftArt buys the license of the art for another user. The recipient is
+// checked before the sender pays, and gets the art with a gift to claim.
+func (r *ArtsRepo) GiftArt(req types.GiftArtReq) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	done, err := checkIdempotencyKeyWithDB(ctx, tx, req.UserId, req.IdempotencyKey, req.Request())
+	if err != nil {
+		return err
+	}
+	if done {
+		return nil
+	}
+
+	stmt1 := SELECT(Users.ID, Users.Username).
+		FROM(Users).
+		WHERE(Users.Username.EQ(String(req.RecipientName)))
+	var recipient model.Users
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &recipient, ErrUserNotFound); err != nil {
+		return err
+	}
+	recipientId := int(*recipient.ID)
+	if recipientId == req.UserId {
+		return ErrGiftToSelf
+	}
+
+	stmt2 := SELECT(Arts.CreatorID, Arts.Name).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(req.ArtId))))
+	var art model.Arts
+	if err := HandleQueryCtx(stmt2, ctx, tx, &art, "art"); err != nil {
+		return err
+	}
+	if int(art.CreatorID) == recipientId {
+		return ErrGiftAlreadyOwned
+	}
+
+	forSale, err := r.isArtForSaleWithDB(ctx, tx, req.ArtId)
+	if err != nil {
+		return err
+	}
+	if !forSale {
+		return ErrArtNotForSale
+	}
+
+	owned, err := r.hasUsersBoughtArtsWithDB(ctx, tx, recipientId, req.ArtId)
+	if err != nil {
+		return err
+	}
+	if owned {
+		return ErrGiftAlreadyOwned
+	}
+
+	description := "Gift for " + recipient.Username
+	if err := r.buyArtWithDB(ctx, tx, req.BuyArtReq, recipientId, description); err != nil {
+		return err
+	}
+
+	stmt3 := Gifts.
+		INSERT(
+			Gifts.SenderID,
+			Gifts.RecipientID,
+			Gifts.ArtID,
+			Gifts.LicenseID,
+			Gifts.Message,
+			Gifts.Price,
+		).
+		VALUES(req.UserId, recipientId, req.ArtId, req.LicenseId, req.Message, req.Price)
+	if err := HandleExecCtx(stmt3, ctx, tx, "gifts"); err != nil {
+		return err
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		recipientId,
+		fmt.Sprintf("You received %q as a gift", art.Name),
+		"/me",
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := saveIdempotencyKeyWithDB(ctx, tx, req.UserId, req.IdempotencyKey, req.Request()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindManyReceivedGifts finds the gifts of the recipient, newest first.
+func (r *ArtsRepo) FindManyReceivedGifts(recipientId int) ([]types.Gift, error) {
+	sender := Users.AS("Sender")
+
+	stmt := SELECT(
+		Gifts.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Arts.CoverURL,
+		ArtLicenses.ID,
+		ArtLicenses.Name,
+		sender.ID,
+		sender.Username,
+		sender.AvatarURL,
+	).
+		FROM(
+			Gifts.
+				INNER_JOIN(Arts, Arts.ID.EQ(Gifts.ArtID)).
+				INNER_JOIN(sender, sender.ID.EQ(Gifts.SenderID)).
+				LEFT_JOIN(ArtLicenses, ArtLicenses.ID.EQ(Gifts.LicenseID)),
+		).
+		WHERE(Gifts.RecipientID.EQ(Int(int64(recipientId)))).
+		ORDER_BY(Gifts.CreatedAt.DESC(), Gifts.ID.DESC()).
+		LIMIT(50)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.Gift{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "gift")
+	return dest, err
+}
+
+// ClaimGift opens the gift of the recipient and returns the gifted art.
+func (r *ArtsRepo) ClaimGift(id, recipientId int, now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt1 := SELECT(Gifts.ArtID).
+		FROM(Gifts).
+		WHERE(
+			Gifts.ID.EQ(Int(int64(id))).
+				AND(Gifts.RecipientID.EQ(Int(int64(recipientId)))),
+		)
+	var gift model.Gifts
+	if err := HandleQueryCtxWithErr(stmt1, ctx, r.db, &gift, ErrGiftNotFound); err != nil {
+		return 0, err
+	}
+
+	stmt2 := Gifts.UPDATE(Gifts.ClaimedAt).
+		SET(TimestampExp(DATETIME(now))).
+		WHERE(
+			Gifts.ID.EQ(Int(int64(id))).
+				AND(Gifts.ClaimedAt.IS_NULL()),
+		)
+	if err := HandleExecCtxWithErr(stmt2, ctx, r.db, ErrGiftAlreadyClaimed); err != nil {
+		return 0, err
+	}
+
+	return int(gift.ArtID), nil
+}
@@ -26,6 +26,14 @@ var (
 	)
 	ErrArtsTagsNoRowsAffected = ErrNoRowsAffected("arts_tags")
 	ErrFilesNoRowsAffected    = ErrNoRowsAffected("files")
+	ErrRestoreExpired         = httperror.New(
+		"this art can no longer be restored",
+		http.StatusBadRequest,
+	)
+	ErrEditionSizeTooSmall = httperror.New(
+		"edition size cannot be lower than the copies already sold",
+		http.StatusBadRequest,
+	)
 )
 
 type ArtsRepo struct {
@@ -63,13 +71,47 @@ func (r *ArtsRepo) CreateArtWithDB(
 	db qrm.DB,
 	req types.CreateArtReq,
 ) (artId int, err error) {
+	if req.MembersTierId != nil {
+		if err = checkCreatorTierWithDB(ctx, db, *req.MembersTierId, Int(int64(req.CreatorId))); err != nil {
+			return
+		}
+	}
+
 	var art struct {
 		ID int `alias:"Arts.ID"`
 	}
 	// insert arts
 	stmt1 := Arts.
-		INSERT(Arts.Name, Arts.Description, Arts.CreatorID, Arts.Price, Arts.CoverURL).
-		VALUES(req.Name, req.Description, req.CreatorId, req.Price, "unset").
+		INSERT(
+			Arts.Name,
+			Arts.Description,
+			Arts.CreatorID,
+			Arts.Price,
+			Arts.CoverURL,
+			Arts.Status,
+			Arts.PublishAt,
+			Arts.EditionSize,
+			Arts.PayWhatYouWant,
+			Arts.SuggestedPrice,
+			Arts.MembersTierID,
+			Arts.DownloadLimit,
+			Arts.DownloadRateLimit,
+		).
+		VALUES(
+			req.Name,
+			req.Description,
+			req.CreatorId,
+			req.Price,
+			"unset",
+			req.Status,
+			req.PublishAt,
+			req.EditionSize,
+			req.PayWhatYouWant,
+			req.SuggestedPrice,
+			req.MembersTierId,
+			req.DownloadLimit,
+			req.DownloadRateLimit,
+		).
 		RETURNING(Arts.ID)
 	if err = HandleQueryCtx(stmt1, ctx, db, &art, "art"); err != nil {
 		return
@@ -77,6 +119,16 @@ func (r *ArtsRepo) CreateArtWithDB(
 
 	fmt.Println("artId: ", art.ID)
 
+	// every art starts with the default license, sold at the art's price
+	if err = r.CreateArtLicenseWithDB(ctx, db, types.ArtLicenseReq{
+		ArtId: art.ID,
+		Name:  types.DefaultLicenseName,
+		Price: req.Price,
+		Terms: types.DefaultLicenseTerms,
+	}); err != nil {
+		return
+	}
+
 	// insert tags
 	if req.TagsID != nil && len(req.TagsID) > 0 {
 		fmt.Println("insert tags")
@@ -105,26 +157,72 @@ func (r *ArtsRepo) UpdateArtInfoWithDB(
 	db qrm.DB,
 	req types.UpdateArtInfoReq,
 ) error {
-	stmt1 := Arts.
-		UPDATE(Arts.Name, Arts.Description, Arts.Price).
-		SET(req.Name, req.Description, req.Price).
+	// the edition size can be raised at any time, but never below the copies
+	// already sold
+	if req.EditionSize != nil {
+		stmt1 := SELECT(editionsSoldExp().AS("sold")).
+			FROM(Arts).
+			WHERE(Arts.ID.EQ(Int(int64(req.ArtId))))
+		var art struct {
+			Sold int `alias:"sold"`
+		}
+		if err := HandleQueryCtx(stmt1, ctx, db, &art, "art"); err != nil {
+			return err
+		}
+		if *req.EditionSize < art.Sold {
+			return ErrEditionSizeTooSmall
+		}
+	}
+
+	if req.MembersTierId != nil {
+		creatorId := SELECT(Arts.CreatorID).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(req.ArtId))))
+		if err := checkCreatorTierWithDB(ctx, db, *req.MembersTierId, IntExp(creatorId)); err != nil {
+			return err
+		}
+	}
+
+	stmt2 := Arts.
+		UPDATE(
+			Arts.Name,
+			Arts.Description,
+			Arts.Status,
+			Arts.PublishAt,
+			Arts.EditionSize,
+			Arts.PayWhatYouWant,
+			Arts.SuggestedPrice,
+			Arts.MembersTierID,
+			Arts.DownloadLimit,
+			Arts.DownloadRateLimit,
+		).
+		SET(
+			req.Name,
+			req.Description,
+			req.Status,
+			req.PublishAt,
+			req.EditionSize,
+			req.PayWhatYouWant,
+			req.SuggestedPrice,
+			req.MembersTierId,
+			req.DownloadLimit,
+			req.DownloadRateLimit,
+		).
 		WHERE(Arts.ID.EQ(Int(int64(req.ArtId))))
-	if err := HandleExecCtx(stmt1, ctx, db, "arts"); err != nil {
+	if err := HandleExecCtx(stmt2, ctx, db, "arts"); err != nil {
 		return err
 	}
 
-	stmt2 := ArtsTags.DELETE().WHERE(ArtsTags.ArtID.EQ(Int(int64(req.ArtId))))
-	if err := HandleExecCtxWithErr(stmt2, ctx, db, ErrArtsTagsNoRowsAffected); err != nil &&
+	stmt3 := ArtsTags.DELETE().WHERE(ArtsTags.ArtID.EQ(Int(int64(req.ArtId))))
+	if err := HandleExecCtxWithErr(stmt3, ctx, db, ErrArtsTagsNoRowsAffected); err != nil &&
 		!errors.Is(err, ErrArtsTagsNoRowsAffected) {
 		return err
 	}
 
 	if req.TagsID != nil && len(req.TagsID) > 0 {
-		stmt3 := ArtsTags.INSERT(ArtsTags.ArtID, ArtsTags.TagID)
+		stmt4 := ArtsTags.INSERT(ArtsTags.ArtID, ArtsTags.TagID)
 		for _, tagID := range req.TagsID {
-			stmt3 = stmt3.VALUES(req.ArtId, tagID)
+			stmt4 = stmt4.VALUES(req.ArtId, tagID)
 		}
-		if err := HandleExecCtx(stmt3, ctx, db, "arts_tags"); err != nil {
+		if err := HandleExecCtx(stmt4, ctx, db, "arts_tags"); err != nil {
 			return err
 		}
 	}
@@ -150,6 +248,8 @@ func (r *ArtsRepo) FindManyArts(req types.ManyArtsReq) (types.ManyArtsRes, error
 	cond = r.withFilterCond(cond, req.Filter)
 	cond = r.withSearchCond(cond, req.Search)
 	cond = r.withCreatorIdCond(cond, req.CreatorId)
+	cond = r.withStatusCond(cond, types.ArtStatusPublished)
+	cond = r.withNotDeletedCond(cond)
 
 	// stmt
 	stmt := SELECT(
@@ -159,6 +259,10 @@ func (r *ArtsRepo) FindManyArts(req types.ManyArtsReq) (types.ManyArtsRes, error
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
 	).FROM(
 		Arts.
 			LEFT_JOIN(creator, creator.ID.EQ(Arts.CreatorID)).
@@ -220,6 +324,8 @@ func (r *ArtsRepo) FindManyStarredArts(
 	var cond BoolExpression = Int(1).EQ(Int(1))
 	cond = r.withFilterCond(cond, req.Filter)
 	cond = r.withSearchCond(cond, req.Search)
+	cond = r.withStatusCond(cond, types.ArtStatusPublished, types.ArtStatusUnlisted)
+	cond = r.withNotDeletedCond(cond)
 
 	// starred arts stmt
 	stmt := SELECT(
@@ -229,6 +335,10 @@ func (r *ArtsRepo) FindManyStarredArts(
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
 	).FROM(
 		Arts.
 			INNER_JOIN(
@@ -309,6 +419,11 @@ func (r *ArtsRepo) FindManyBoughtArts(
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
+		COALESCE(MAX(UsersBoughtArts.EditionNumber), Int(0)).AS("Edition.Number"),
 	).FROM(
 		Arts.
 			INNER_JOIN(
@@ -380,6 +495,7 @@ func (r *ArtsRepo) FindManyCreatedArts(
 	var cond BoolExpression = Int(1).EQ(Int(1))
 	cond = r.withFilterCond(cond, req.Filter)
 	cond = r.withSearchCond(cond, req.Search)
+	cond = r.withNotDeletedCond(cond)
 
 	// created arts stmt
 	stmt := SELECT(
@@ -389,6 +505,10 @@ func (r *ArtsRepo) FindManyCreatedArts(
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
 	).FROM(
 		Arts.
 			INNER_JOIN(
@@ -461,6 +581,11 @@ func (r *ArtsRepo) FindOneArt(id int) (types.Art, error) {
 		Raw("group_concat(DISTINCT tags.name)").AS("Temp.TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("Temp.TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
+		EXISTS(openAuctionExp()).AS("Temp.OnAuction"),
 	).FROM(
 		Arts.
 			LEFT_JOIN(creator, creator.ID.EQ(Arts.CreatorID)).
@@ -484,7 +609,29 @@ func (r *ArtsRepo) FindOneArt(id int) (types.Art, error) {
 		return types.Art{}, err
 	}
 
+	stmt3 := SELECT(Arts.ID, ArtVersions.AllColumns).
+		FROM(Arts.LEFT_JOIN(ArtVersions, ArtVersions.ArtID.EQ(Arts.ID))).
+		WHERE(Arts.ID.EQ(Int(int64(id)))).
+		ORDER_BY(ArtVersions.Version.DESC())
+
+	var versionsDest types.Art
+	if err := HandleQueryCtx(stmt3, ctx, r.db, &versionsDest, "art"); err != nil {
+		return types.Art{}, err
+	}
+
+	stmt4 := SELECT(Arts.ID, ArtLicenses.AllColumns).
+		FROM(Arts.LEFT_JOIN(ArtLicenses, ArtLicenses.ArtID.EQ(Arts.ID))).
+		WHERE(Arts.ID.EQ(Int(int64(id)))).
+		ORDER_BY(ArtLicenses.Price.ASC(), ArtLicenses.ID.ASC())
+
+	var licensesDest types.Art
+	if err := HandleQueryCtx(stmt4, ctx, r.db, &licensesDest, "art"); err != nil {
+		return types.Art{}, err
+	}
+
 	dest.Files = filesDest.Files
+	dest.Versions = versionsDest.Versions
+	dest.Licenses = licensesDest.Licenses
 	err := dest.FillTags()
 	if err != nil {
 		return types.Art{}, err
@@ -494,6 +641,17 @@ func (r *ArtsRepo) FindOneArt(id int) (types.Art, error) {
 }
 
 func (r *ArtsRepo) HasUsersBoughtArts(userId, artId int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.hasUsersBoughtArtsWithDB(ctx, r.db, userId, artId)
+}
+
+func (r *ArtsRepo) hasUsersBoughtArtsWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId, artId int,
+) (bool, error) {
 	stmt := SELECT(Int(1)).
 		FROM(UsersBoughtArts).
 		WHERE(
@@ -501,6 +659,33 @@ func (r *ArtsRepo) HasUsersBoughtArts(userId, artId int) (bool, error) {
 				AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
 		)
 
+	var tmp struct{ int }
+	return HandleHasCtx(stmt, ctx, db, &tmp)
+}
+
+// HasMembersAccess reports whether the user subscribes to the tier of the
+// members-only art, or to a tier of the same creator that costs at least as
+// much.
+func (r *ArtsRepo) HasMembersAccess(userId, artId int, now time.Time) (bool, error) {
+	membersTier := SubscriptionTiers.AS("MembersTier")
+
+	stmt := SELECT(Int(1)).
+		FROM(
+			Arts.
+				INNER_JOIN(membersTier, membersTier.ID.EQ(Arts.MembersTierID)).
+				INNER_JOIN(
+					SubscriptionTiers,
+					SubscriptionTiers.CreatorID.EQ(membersTier.CreatorID).
+						AND(SubscriptionTiers.Price.GT_EQ(membersTier.Price)),
+				).
+				INNER_JOIN(Subscriptions, Subscriptions.TierID.EQ(SubscriptionTiers.ID)),
+		).
+		WHERE(
+			Arts.ID.EQ(Int(int64(artId))).
+				AND(Subscriptions.UserID.EQ(Int(int64(userId)))).
+				AND(subscriptionAccessCond(now)),
+		)
+
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
@@ -521,51 +706,6 @@ func (r *ArtsRepo) FindUserCoin(userId int) (int, error) {
 	return int(user.Coin), nil
 }
 
-func (r *ArtsRepo) BuyArt(userId, artId, price int) error {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
-	defer cancel()
-
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	stmt1 := UsersBoughtArts.
-		INSERT(UsersBoughtArts.UserID, UsersBoughtArts.ArtID).
-		VALUES(userId, artId)
-	if err := HandleExecCtx(stmt1, ctx, tx, "users_bought_arts"); err != nil {
-		return err
-	}
-
-	stmt2 := SELECT(Arts.Price).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(artId))))
-	var art model.Arts
-	if err := HandleQueryCtx(stmt2, ctx, tx, &art, "art"); err != nil {
-		return err
-	}
-
-	if art.Price != int32(price) {
-		return ErrInvalidPrice
-	}
-
-	stmt3 := Users.UPDATE(Users.Coin).
-		SET(Users.Coin.SUB(Int(int64(art.Price)))).
-		WHERE(Users.ID.EQ(Int(int64(userId))))
-	if err := HandleExecCtx(stmt3, ctx, tx, "users"); err != nil {
-		return err
-	}
-
-	return tx.Commit()
-}
-
-func (r *ArtsRepo) InsertDownloadedArt(artId int) error {
-	stmt := DownloadedArts.INSERT(DownloadedArts.ArtID).VALUES(Int(int64(artId)))
-
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
-	defer cancel()
-
-	return HandleExecCtx(stmt, ctx, r.db, "downloaded_arts")
-}
-
 func (r *ArtsRepo) HasUsersStarredArts(userId, artId int) (bool, error) {
 	stmt := SELECT(Int(1)).
 		FROM(UsersStarredArts).
@@ -635,6 +775,7 @@ func (r *ArtsRepo) statsTable() SelectStatement {
 	time := DownloadedArts.CreatedAt
 
 	totalDownloads := COUNT(DISTINCT(id))
+	totalDownloaders := COUNT(DISTINCT(DownloadedArts.UserID))
 	weeklyDownloads := r.countInterval(id, time, DAYS(-7))
 	monthlyDownloads := r.countInterval(id, time, MONTHS(-1))
 	yearlyDownloads := r.countInterval(id, time, YEARS(-1))
@@ -651,6 +792,7 @@ func (r *ArtsRepo) statsTable() SelectStatement {
 		Arts.ID,
 
 		totalDownloads.AS("TotalDownloads"),
+		totalDownloaders.AS("TotalDownloaders"),
 		weeklyDownloads.AS("WeeklyDownloads"),
 		monthlyDownloads.AS("MonthlyDownloads"),
 		yearlyDownloads.AS("YearlyDownloads"),
@@ -663,7 +805,7 @@ func (r *ArtsRepo) statsTable() SelectStatement {
 		Arts.
 			LEFT_JOIN(DownloadedArts, DownloadedArts.ArtID.EQ(Arts.ID)).
 			LEFT_JOIN(UsersStarredArts, UsersStarredArts.ArtID.EQ(Arts.ID)),
-	)
+	).GROUP_BY(Arts.ID)
 }
 
 func (r *ArtsRepo) statsColumn(statsTable SelectTable) statsColumn {
@@ -689,6 +831,10 @@ func (r *ArtsRepo) findManyArtsColumns(statsTable SelectTable) []Projection {
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
 	}
 }
 
@@ -726,6 +872,10 @@ func (r *ArtsRepo) findManyArtsStmt(
 		Raw("group_concat(DISTINCT tags.name)").AS("TagNames"),
 		Raw("group_concat(DISTINCT tags.id)").AS("TagIDs"),
 		statsTable.AllColumns().As("Stats.*"),
+		promotionPercentExp().AS("Promotion.Percent"),
+		effectivePriceExp().AS("Promotion.Price"),
+		Arts.EditionSize.AS("Edition.Size"),
+		editionsSoldExp().AS("Edition.Sold"),
 	).FROM(
 		Arts.
 			LEFT_JOIN(creator, creator.ID.EQ(Arts.CreatorID)).
@@ -773,12 +923,13 @@ func (r *ArtsRepo) withFilterCond(cond BoolExpression, filter types.Filter) Bool
 		cond = cond.AND(Arts.ID.IN(artsIDs))
 	}
 
+	// the effective price of pay-what-you-want arts is their minimum
 	if filter.MinPrice != -1 {
-		cond = cond.AND(Arts.Price.GT_EQ(Int(int64(filter.MinPrice))))
+		cond = cond.AND(effectivePriceExp().GT_EQ(Int(int64(filter.MinPrice))))
 	}
 
 	if filter.MaxPrice != -1 {
-		cond = cond.AND(Arts.Price.LT_EQ(Int(int64(filter.MaxPrice))))
+		cond = cond.AND(effectivePriceExp().LT_EQ(Int(int64(filter.MaxPrice))))
 	}
 
 	// TODO:
@@ -810,6 +961,15 @@ func (r *ArtsRepo) withCreatorIdCond(cond BoolExpression, creatorId int) BoolExp
 	return cond.AND(Arts.CreatorID.EQ(Int(int64(creatorId))))
 }
 
+func (r *ArtsRepo) withStatusCond(cond BoolExpression, statuses ...string) BoolExpression {
+	exps := utils.Map(statuses, func(status string) Expression { return String(status) })
+	return cond.AND(Arts.Status.IN(exps...))
+}
+
+func (r *ArtsRepo) withNotDeletedCond(cond BoolExpression) BoolExpression {
+	return cond.AND(Arts.DeletedAt.IS_NULL())
+}
+
 // func (r *ArtsRepo) withStarredArtsCond(cond BoolExpression) BoolExpression {
 // 	return cond.AND(Arts.ID.BETWEEN)
 // }
@@ -843,7 +1003,8 @@ func (r *ArtsRepo) withSortStmt(
 	case types.YearlyStars:
 		orderBy = stats.yearlyStars
 	case types.Price:
-		orderBy = Arts.Price
+		// pay-what-you-want arts are sorted by their minimum
+		orderBy = effectivePriceExp()
 	default:
 		return nil, ErrInvalidSortingType
 	}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrArtLicenseNotFound = ErrNotFound("art license")
+	ErrInvalidUpgrade     = httperror.New(
+		"you can only upgrade to a more expensive license",
+		http.StatusBadRequest,
+	)
+)
+
+// FindManyArtLicenses returns the license tiers of the art from the cheapest.
+func (r *ArtsRepo) FindManyArtLicenses(artId int) ([]model.ArtLicenses, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(ArtLicenses.AllColumns).
+		FROM(ArtLicenses).
+		WHERE(ArtLicenses.ArtID.EQ(Int(int64(artId)))).
+		ORDER_BY(ArtLicenses.Price.ASC(), ArtLicenses.ID.ASC())
+
+	var dest []model.ArtLicenses
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art license")
+	return dest, err
+}
+
+func (r *ArtsRepo) FindOneArtLicense(artId, licenseId int) (model.ArtLicenses, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(ArtLicenses.AllColumns).
+		FROM(ArtLicenses).
+		WHERE(
+			ArtLicenses.ID.EQ(Int(int64(licenseId))).
+				AND(ArtLicenses.ArtID.EQ(Int(int64(artId)))),
+		)
+
+	var dest model.ArtLicenses
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrArtLicenseNotFound)
+	return dest, err
+}
+
+// FindBoughtLicense returns the license tier that the user bought for the art,
+// the edition they hold and the download limit of the art.
+func (r *ArtsRepo) FindBoughtLicense(userId, artId int) (types.BoughtLicense, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(
+		UsersBoughtArts.AllColumns,
+		ArtLicenses.AllColumns,
+		Arts.EditionSize,
+		Arts.DownloadLimit,
+	).
+		FROM(
+			UsersBoughtArts.
+				INNER_JOIN(ArtLicenses, ArtLicenses.ID.EQ(UsersBoughtArts.LicenseID)).
+				INNER_JOIN(Arts, Arts.ID.EQ(UsersBoughtArts.ArtID)),
+		).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
+		)
+
+	var dest types.BoughtLicense
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrArtLicenseNotFound)
+	return dest, err
+}
+
+func (r *ArtsRepo) HasLicenseHolders(licenseId int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(Int(1)).
+		FROM(UsersBoughtArts).
+		WHERE(UsersBoughtArts.LicenseID.EQ(Int(int64(licenseId)))).
+		LIMIT(1)
+
+	var tmp struct{ int }
+	return HandleHasCtx(stmt, ctx, r.db, &tmp)
+}
+
+func (r *ArtsRepo) CreateArtLicense(req types.ArtLicenseReq) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.CreateArtLicenseWithDB(ctx, tx, req); err != nil {
+		return err
+	}
+	if err := r.syncArtPriceWithDB(ctx, tx, req.ArtId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ArtsRepo) CreateArtLicenseWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.ArtLicenseReq,
+) error {
+	stmt := ArtLicenses.
+		INSERT(
+			ArtLicenses.ArtID,
+			ArtLicenses.Name,
+			ArtLicenses.Price,
+			ArtLicenses.Terms,
+			ArtLicenses.DownloadCap,
+		).
+		VALUES(req.ArtId, req.Name, req.Price, req.Terms, req.DownloadCap)
+
+	return HandleExecCtx(stmt, ctx, db, "art_licenses")
+}
+
+func (r *ArtsRepo) UpdateArtLicense(req types.ArtLicenseReq) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := ArtLicenses.
+		UPDATE(ArtLicenses.Name, ArtLicenses.Price, ArtLicenses.Terms, ArtLicenses.DownloadCap).
+		SET(req.Name, req.Price, req.Terms, req.DownloadCap).
+		WHERE(
+			ArtLicenses.ID.EQ(Int(int64(req.LicenseId))).
+				AND(ArtLicenses.ArtID.EQ(Int(int64(req.ArtId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt, ctx, tx, ErrArtLicenseNotFound); err != nil {
+		return err
+	}
+	if err := r.syncArtPriceWithDB(ctx, tx, req.ArtId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ArtsRepo) DeleteArtLicense(artId, licenseId int) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := ArtLicenses.DELETE().
+		WHERE(
+			ArtLicenses.ID.EQ(Int(int64(licenseId))).
+				AND(ArtLicenses.ArtID.EQ(Int(int64(artId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt, ctx, tx, ErrArtLicenseNotFound); err != nil {
+		return err
+	}
+	if err := r.syncArtPriceWithDB(ctx, tx, artId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// syncArtPriceWithDB sets the art's price to its cheapest license, the price
+// is used for listing, filtering and sorting the arts.
+func (r *ArtsRepo) syncArtPriceWithDB(ctx context.Context, db qrm.DB, artId int) error {
+	cheapest := SELECT(MIN(ArtLicenses.Price)).
+		FROM(ArtLicenses).
+		WHERE(ArtLicenses.ArtID.EQ(Int(int64(artId))))
+
+	stmt := Arts.UPDATE(Arts.Price).
+		SET(IntExp(cheapest)).
+		WHERE(Arts.ID.EQ(Int(int64(artId))))
+
+	return HandleExecCtx(stmt, ctx, db, "arts")
+}
@@ -0,0 +1,446 @@
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrArtNotForSale     = httperror.New("this art is not for sale", http.StatusBadRequest)
+	ErrArtSoldOut        = httperror.New("every edition of this art is sold", http.StatusBadRequest)
+	ErrPriceBelowMinimum = httperror.New(
+		"the price is below the minimum of this art",
+		http.StatusBadRequest,
+	)
+	ErrCouponPayWhatYouWant = httperror.New(
+		"coupons cannot be used on pay-what-you-want arts",
+		http.StatusBadRequest,
+	)
+	ErrLicenseAlreadyBought = httperror.New(
+		"user already bought this license",
+		http.StatusBadRequest,
+	)
+	ErrIdempotencyKeyReused = httperror.New(
+		"idempotency key was already used for another request",
+		http.StatusUnprocessableEntity,
+	)
+)
+
+// BuyArt buys the license of the art, or upgrades the license that the user
+// already holds, at the price after the running promotion and the coupon of
+// the request. The creator is credited with the price minus the platform
+// fee. Every check runs in the same transaction as the payment, so concurrent
+// purchases cannot overspend the balance or buy the same art twice.
+func (r *ArtsRepo) BuyArt(req types.BuyArtReq) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	done, err := checkIdempotencyKeyWithDB(ctx, tx, req.UserId, req.IdempotencyKey, req.Request())
+	if err != nil {
+		return err
+	}
+	if done {
+		return nil
+	}
+
+	forSale, err := r.isArtForSaleWithDB(ctx, tx, req.ArtId)
+	if err != nil {
+		return err
+	}
+	if !forSale {
+		return ErrArtNotForSale
+	}
+
+	bought, err := r.hasUsersBoughtArtsWithDB(ctx, tx, req.UserId, req.ArtId)
+	if err != nil {
+		return err
+	}
+
+	if bought {
+		err = r.upgradeArtLicenseWithDB(ctx, tx, req)
+	} else {
+		err = r.buyArtWithDB(ctx, tx, req, req.UserId, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := saveIdempotencyKeyWithDB(ctx, tx, req.UserId, req.IdempotencyKey, req.Request()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// buyArtWithDB charges the user of the request and gives the art to the
+// owner, who is someone else for gifts.
+func (r *ArtsRepo) buyArtWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.BuyArtReq,
+	ownerId int,
+	description string,
+) error {
+	stmt1 := SELECT(
+		ArtLicenses.Name,
+		ArtLicenses.Price,
+		Arts.Name,
+		Arts.CreatorID,
+		Arts.PayWhatYouWant,
+		promotionPercentExp().AS("percent"),
+	).
+		FROM(ArtLicenses.INNER_JOIN(Arts, Arts.ID.EQ(ArtLicenses.ArtID))).
+		WHERE(
+			ArtLicenses.ID.EQ(Int(int64(req.LicenseId))).
+				AND(ArtLicenses.ArtID.EQ(Int(int64(req.ArtId)))),
+		)
+	var license struct {
+		Name           string `alias:"art_licenses.name"`
+		Price          int    `alias:"art_licenses.price"`
+		ArtName        string `alias:"arts.name"`
+		CreatorId      int    `alias:"arts.creator_id"`
+		PayWhatYouWant bool   `alias:"arts.pay_what_you_want"`
+		Percent        int    `alias:"percent"`
+	}
+	if err := HandleQueryCtxWithErr(stmt1, ctx, db, &license, ErrArtLicenseNotFound); err != nil {
+		return err
+	}
+
+	price, err := salePriceWithDB(ctx, db, req, license.Price, license.Percent, license.PayWhatYouWant)
+	if err != nil {
+		return err
+	}
+
+	if err := insertBoughtArtWithDB(ctx, db, ownerId, req.ArtId, req.LicenseId); err != nil {
+		return err
+	}
+
+	kind, txType := types.OrderKindArt, types.CoinTxPurchase
+	if ownerId != req.UserId {
+		kind, txType = types.OrderKindGift, types.CoinTxGift
+	}
+	order, err := insertOrderWithDB(ctx, db, types.OrderReq{
+		UserId:      req.UserId,
+		Kind:        kind,
+		Description: description,
+		FeePercent:  req.FeePercent,
+		Items: []types.OrderItemReq{{
+			ArtId:       &req.ArtId,
+			LicenseId:   &req.LicenseId,
+			CreatorId:   license.CreatorId,
+			Name:        license.ArtName,
+			LicenseName: license.Name,
+			ListPrice:   max(license.Price, price),
+			Price:       price,
+			CouponCode:  req.CouponCode,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+		Type:        txType,
+		Description: description,
+		ArtId:       &req.ArtId,
+		Legs:        types.SaleLegs(req.UserId, license.CreatorId, price, req.FeePercent),
+	})
+	if err != nil {
+		return err
+	}
+
+	return setOrderTransactionWithDB(ctx, db, int(*order.ID), transactionId)
+}
+
+// insertBoughtArtWithDB gives the license of the art to the user. A copy of a
+// limited-edition art gets the lowest edition number that is not taken, so
+// the numbers of refunded copies are sold again. It returns ErrArtSoldOut
+// when every copy is sold.
+func insertBoughtArtWithDB(ctx context.Context, db qrm.DB, userId, artId, licenseId int) error {
+	stmt1 := SELECT(Arts.EditionSize, editionsSoldExp().AS("sold")).
+		FROM(Arts).
+		WHERE(Arts.ID.EQ(Int(int64(artId))))
+	var art struct {
+		EditionSize *int `alias:"arts.edition_size"`
+		Sold        int  `alias:"sold"`
+	}
+	if err := HandleQueryCtx(stmt1, ctx, db, &art, "art"); err != nil {
+		return err
+	}
+
+	var editionNumber *int
+	if art.EditionSize != nil {
+		if art.Sold >= *art.EditionSize {
+			return ErrArtSoldOut
+		}
+
+		stmt2 := SELECT(UsersBoughtArts.UserID, UsersBoughtArts.ArtID, UsersBoughtArts.EditionNumber).
+			FROM(UsersBoughtArts).
+			WHERE(
+				UsersBoughtArts.ArtID.EQ(Int(int64(artId))).
+					AND(UsersBoughtArts.EditionNumber.IS_NOT_NULL()),
+			).
+			ORDER_BY(UsersBoughtArts.EditionNumber.ASC())
+		var taken []model.UsersBoughtArts
+		if err := HandleQueryCtx(stmt2, ctx, db, &taken, "users_bought_arts"); err != nil {
+			return err
+		}
+
+		number := 1
+		for _, bought := range taken {
+			if int(*bought.EditionNumber) != number {
+				break
+			}
+			number++
+		}
+		editionNumber = &number
+	}
+
+	stmt3 := UsersBoughtArts.
+		INSERT(
+			UsersBoughtArts.UserID,
+			UsersBoughtArts.ArtID,
+			UsersBoughtArts.LicenseID,
+			UsersBoughtArts.EditionNumber,
+		).
+		VALUES(userId, artId, licenseId, editionNumber)
+	return HandleExecCtx(stmt3, ctx, db, "users_bought_arts")
+}
+
+// editionsSoldExp is the number of copies sold of the art of the outer query.
+func editionsSoldExp() IntegerExpression {
+	return IntExp(
+		SELECT(COUNT(STAR)).
+			FROM(UsersBoughtArts).
+			WHERE(UsersBoughtArts.ArtID.EQ(Arts.ID)),
+	)
+}
+
+// upgradeArtLicenseWithDB moves the user to a more expensive license of the
+// art. The user only pays the difference between the two licenses.
+func (r *ArtsRepo) upgradeArtLicenseWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.BuyArtReq,
+) error {
+	current := ArtLicenses.AS("current")
+	stmt1 := SELECT(
+		current.ID.AS("current_id"),
+		current.Price.AS("current"),
+		ArtLicenses.Price.AS("next"),
+		ArtLicenses.Name.AS("next_name"),
+		Arts.Name.AS("art_name"),
+		Arts.CreatorID.AS("creator_id"),
+		Arts.PayWhatYouWant.AS("pay_what_you_want"),
+		promotionPercentExp().AS("percent"),
+	).
+		FROM(
+			UsersBoughtArts.
+				INNER_JOIN(current, current.ID.EQ(UsersBoughtArts.LicenseID)).
+				INNER_JOIN(ArtLicenses, ArtLicenses.ArtID.EQ(UsersBoughtArts.ArtID)).
+				INNER_JOIN(Arts, Arts.ID.EQ(UsersBoughtArts.ArtID)),
+		).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(req.UserId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(req.ArtId)))).
+				AND(ArtLicenses.ID.EQ(Int(int64(req.LicenseId)))),
+		)
+	var prices struct {
+		CurrentId int    `alias:"current_id"`
+		Current   int    `alias:"current"`
+		Next      int    `alias:"next"`
+		NextName  string `alias:"next_name"`
+		ArtName   string `alias:"art_name"`
+		CreatorId int    `alias:"creator_id"`
+		Percent   int    `alias:"percent"`
+
+		PayWhatYouWant bool `alias:"pay_what_you_want"`
+	}
+	if err := HandleQueryCtxWithErr(stmt1, ctx, db, &prices, ErrArtLicenseNotFound); err != nil {
+		return err
+	}
+
+	if prices.CurrentId == req.LicenseId {
+		return ErrLicenseAlreadyBought
+	}
+	listDiff := prices.Next - prices.Current
+	if listDiff <= 0 {
+		return ErrInvalidUpgrade
+	}
+	diff, err := salePriceWithDB(ctx, db, req, listDiff, prices.Percent, prices.PayWhatYouWant)
+	if err != nil {
+		return err
+	}
+
+	stmt2 := UsersBoughtArts.UPDATE(UsersBoughtArts.LicenseID).
+		SET(Int(int64(req.LicenseId))).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(req.UserId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(req.ArtId)))),
+		)
+	if err := HandleExecCtx(stmt2, ctx, db, "users_bought_arts"); err != nil {
+		return err
+	}
+
+	order, err := insertOrderWithDB(ctx, db, types.OrderReq{
+		UserId:      req.UserId,
+		Kind:        types.OrderKindUpgrade,
+		Description: "License upgrade",
+		FeePercent:  req.FeePercent,
+		Items: []types.OrderItemReq{{
+			ArtId:       &req.ArtId,
+			LicenseId:   &req.LicenseId,
+			CreatorId:   prices.CreatorId,
+			Name:        prices.ArtName,
+			LicenseName: prices.NextName,
+			ListPrice:   max(listDiff, diff),
+			Price:       diff,
+			CouponCode:  req.CouponCode,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+		Type:        types.CoinTxPurchase,
+		Description: "License upgrade",
+		ArtId:       &req.ArtId,
+		Legs:        types.SaleLegs(req.UserId, prices.CreatorId, diff, req.FeePercent),
+	})
+	if err != nil {
+		return err
+	}
+
+	return setOrderTransactionWithDB(ctx, db, int(*order.ID), transactionId)
+}
+
+// salePriceWithDB checks the price of the request against the list price
+// after the promotion and the coupon, and returns the price to be paid. Buyers
+// of pay-what-you-want arts pay what they offered, from the list price up,
+// and cannot use coupons.
+func salePriceWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.BuyArtReq,
+	listPrice, percent int,
+	payWhatYouWant bool,
+) (int, error) {
+	if payWhatYouWant {
+		if req.CouponCode != "" {
+			return 0, ErrCouponPayWhatYouWant
+		}
+		if req.Price < listPrice {
+			return 0, ErrPriceBelowMinimum
+		}
+		return req.Price, nil
+	}
+
+	price := types.PromotionPrice(listPrice, percent)
+	price, err := applyCouponWithDB(ctx, db, req, price)
+	if err != nil {
+		return 0, err
+	}
+	if price != req.Price {
+		return 0, ErrInvalidPrice
+	}
+	return price, nil
+}
+
+// applyCouponWithDB returns the price after the discount of the request's
+// coupon and records the redemption. Without a coupon the price is unchanged.
+func applyCouponWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.BuyArtReq,
+	price int,
+) (int, error) {
+	if req.CouponCode == "" {
+		return price, nil
+	}
+
+	coupon, err := findApplicableCouponWithDB(ctx, db, req.ArtId, req.CouponCode, req.Now)
+	if err != nil {
+		return 0, err
+	}
+
+	discount := coupon.Discount(price)
+	err = redeemCouponWithDB(ctx, db, int(*coupon.ID), req.UserId, req.ArtId, price-discount, discount)
+	if err != nil {
+		return 0, err
+	}
+
+	return price - discount, nil
+}
+
+// checkIdempotencyKeyWithDB reports whether a request with the key already
+// succeeded. Requests without a key are never done.
+func checkIdempotencyKeyWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId int,
+	key string,
+	request string,
+) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+
+	stmt := SELECT(IdempotencyKeys.Request).
+		FROM(IdempotencyKeys).
+		WHERE(
+			IdempotencyKeys.UserID.EQ(Int(int64(userId))).
+				AND(IdempotencyKeys.Key.EQ(String(key))),
+		)
+
+	var dest model.IdempotencyKeys
+	found, err := HandleHasCtx(stmt, ctx, db, &dest)
+	if err != nil || !found {
+		return false, err
+	}
+	if dest.Request != request {
+		return false, ErrIdempotencyKeyReused
+	}
+	return true, nil
+}
+
+func saveIdempotencyKeyWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId int,
+	key string,
+	request string,
+) error {
+	if key == "" {
+		return nil
+	}
+
+	stmt := IdempotencyKeys.
+		INSERT(IdempotencyKeys.UserID, IdempotencyKeys.Key, IdempotencyKeys.Request).
+		VALUES(userId, key, request)
+	return HandleExecCtx(stmt, ctx, db, "idempotency_keys")
+}
+
+// PurgeIdempotencyKeys deletes the keys created before the given time.
+func (r *ArtsRepo) PurgeIdempotencyKeys(before time.Time) error {
+	stmt := IdempotencyKeys.DELETE().
+		WHERE(DATETIME(IdempotencyKeys.CreatedAt).LT(DATETIME(before)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	_, err := stmt.ExecContext(ctx, r.db)
+	return err
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+// IsArtForSale reports whether the art is visible to everyone, not deleted and
+// not members-only.
+func (r *ArtsRepo) IsArtForSale(artId int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.isArtForSaleWithDB(ctx, r.db, artId)
+}
+
+func (r *ArtsRepo) isArtForSaleWithDB(ctx context.Context, db qrm.DB, artId int) (bool, error) {
+	stmt := SELECT(Int(1)).
+		FROM(Arts).
+		WHERE(
+			Arts.ID.EQ(Int(int64(artId))).
+				AND(Arts.Status.IN(String(types.ArtStatusPublished), String(types.ArtStatusUnlisted))).
+				AND(Arts.DeletedAt.IS_NULL()).
+				AND(Arts.MembersTierID.IS_NULL()).
+				AND(NOT(EXISTS(openAuctionExp()))),
+		)
+
+	var tmp struct{ int }
+	return HandleHasCtx(stmt, ctx, db, &tmp)
+}
+
+// PublishScheduledArts publishes every scheduled art whose publish time has
+// passed and returns the ids of the published arts.
+func (r *ArtsRepo) PublishScheduledArts(now time.Time) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Arts.UPDATE(Arts.Status).
+		SET(String(types.ArtStatusPublished)).
+		WHERE(
+			Arts.Status.EQ(String(types.ArtStatusScheduled)).
+				AND(DATETIME(Arts.PublishAt).LT_EQ(DATETIME(now))),
+		).
+		RETURNING(Arts.ID)
+
+	var dest []struct {
+		ID int `alias:"Arts.ID"`
+	}
+	if err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art"); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(dest))
+	for i := range dest {
+		ids[i] = dest[i].ID
+	}
+	return ids, nil
+}
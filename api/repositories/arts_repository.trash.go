@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+func (r *ArtsRepo) SoftDeleteArt(artId int, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Arts.UPDATE(Arts.DeletedAt).
+		SET(TimestampExp(DATETIME(now))).
+		WHERE(Arts.ID.EQ(Int(int64(artId))).AND(Arts.DeletedAt.IS_NULL()))
+	return HandleExecCtx(stmt, ctx, r.db, "arts")
+}
+
+// RestoreArt restores the art if it was deleted after deletedAfter.
+func (r *ArtsRepo) RestoreArt(artId int, deletedAfter time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Arts.UPDATE(Arts.DeletedAt).
+		SET(NULL).
+		WHERE(
+			Arts.ID.EQ(Int(int64(artId))).
+				AND(DATETIME(Arts.DeletedAt).GT(DATETIME(deletedAfter))),
+		)
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrRestoreExpired)
+}
+
+func (r *ArtsRepo) FindManyDeletedArts(creatorId int) ([]model.Arts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(Arts.AllColumns).
+		FROM(Arts).
+		WHERE(
+			Arts.CreatorID.EQ(Int(int64(creatorId))).
+				AND(Arts.DeletedAt.IS_NOT_NULL()),
+		).
+		ORDER_BY(Arts.DeletedAt.DESC())
+
+	var dest []model.Arts
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art")
+	return dest, err
+}
+
+// FindManyPurgeableArtIds returns the ids of arts that were deleted before
+// deletedBefore and were never bought.
+func (r *ArtsRepo) FindManyPurgeableArtIds(deletedBefore time.Time) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	bought := SELECT(UsersBoughtArts.ArtID).
+		FROM(UsersBoughtArts).
+		WHERE(UsersBoughtArts.ArtID.EQ(Arts.ID))
+
+	stmt := SELECT(Arts.ID).
+		FROM(Arts).
+		WHERE(
+			DATETIME(Arts.DeletedAt).LT_EQ(DATETIME(deletedBefore)).
+				AND(NOT(EXISTS(bought))),
+		)
+
+	var dest []struct {
+		ID int `alias:"Arts.ID"`
+	}
+	if err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art"); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(dest))
+	for i := range dest {
+		ids[i] = dest[i].ID
+	}
+	return ids, nil
+}
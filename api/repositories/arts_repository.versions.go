@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrArtVersionNotFound             = ErrNotFound("art version")
+	ErrArtVersionsFilesNoRowsAffected = ErrNoRowsAffected("art_versions_files")
+	ErrNotificationsNoRowsAffected    = ErrNoRowsAffected("notifications")
+)
+
+func (r *ArtsRepo) FindManyArtVersions(artId int) ([]model.ArtVersions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(ArtVersions.AllColumns).
+		FROM(ArtVersions).
+		WHERE(ArtVersions.ArtID.EQ(Int(int64(artId)))).
+		ORDER_BY(ArtVersions.Version.DESC())
+
+	var dest []model.ArtVersions
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art version")
+	return dest, err
+}
+
+func (r *ArtsRepo) FindLatestArtVersion(artId int) (types.ArtVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	latest := SELECT(MAX(ArtVersions.Version)).
+		FROM(ArtVersions).
+		WHERE(ArtVersions.ArtID.EQ(Int(int64(artId))))
+
+	return r.findOneArtVersion(ctx, artId, IntExp(latest))
+}
+
+func (r *ArtsRepo) FindOneArtVersion(artId, version int) (types.ArtVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.findOneArtVersion(ctx, artId, Int(int64(version)))
+}
+
+func (r *ArtsRepo) findOneArtVersion(
+	ctx context.Context,
+	artId int,
+	version IntegerExpression,
+) (types.ArtVersion, error) {
+	stmt := SELECT(ArtVersions.AllColumns, ArtVersionsFiles.AllColumns).
+		FROM(
+			ArtVersions.
+				LEFT_JOIN(ArtVersionsFiles, ArtVersionsFiles.VersionID.EQ(ArtVersions.ID)),
+		).
+		WHERE(
+			ArtVersions.ArtID.EQ(Int(int64(artId))).
+				AND(ArtVersions.Version.EQ(version)),
+		)
+
+	var dest types.ArtVersion
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrArtVersionNotFound)
+	return dest, err
+}
+
+// FindManyArtVersionsURLs returns every cover and file url that was ever used
+// by the art. Blobs are kept as long as one of the versions refers to them.
+func (r *ArtsRepo) FindManyArtVersionsURLs(artId int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(ArtVersions.CoverURL.AS("url")).
+		FROM(ArtVersions).
+		WHERE(ArtVersions.ArtID.EQ(Int(int64(artId)))).
+		UNION(
+			SELECT(ArtVersionsFiles.URL.AS("url")).
+				FROM(
+					ArtVersionsFiles.
+						INNER_JOIN(ArtVersions, ArtVersions.ID.EQ(ArtVersionsFiles.VersionID)),
+				).
+				WHERE(ArtVersions.ArtID.EQ(Int(int64(artId)))),
+		).
+		UNION(
+			SELECT(Files.URL.AS("url")).
+				FROM(Files).
+				WHERE(Files.ArtID.EQ(Int(int64(artId)))),
+		).
+		UNION(
+			SELECT(Arts.CoverURL.AS("url")).
+				FROM(Arts).
+				WHERE(Arts.ID.EQ(Int(int64(artId)))),
+		)
+
+	var dest []struct {
+		URL string `alias:"url"`
+	}
+	if err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art version"); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(dest))
+	for i := range dest {
+		urls[i] = dest[i].URL
+	}
+	return urls, nil
+}
+
+// CreateArtVersionWithDB snapshots the current cover and files of the art as
+// a new version and returns the new version number.
+func (r *ArtsRepo) CreateArtVersionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	artId int,
+	note string,
+) (int, error) {
+	latest := SELECT(COALESCE(MAX(ArtVersions.Version), Int(0))).
+		FROM(ArtVersions).
+		WHERE(ArtVersions.ArtID.EQ(Int(int64(artId))))
+
+	stmt1 := ArtVersions.
+		INSERT(ArtVersions.ArtID, ArtVersions.Version, ArtVersions.Note, ArtVersions.CoverURL).
+		QUERY(
+			SELECT(Arts.ID, IntExp(latest).ADD(Int(1)), String(note), Arts.CoverURL).
+				FROM(Arts).
+				WHERE(Arts.ID.EQ(Int(int64(artId)))),
+		).
+		RETURNING(ArtVersions.ID, ArtVersions.Version)
+
+	var version model.ArtVersions
+	if err := HandleQueryCtx(stmt1, ctx, db, &version, "art"); err != nil {
+		return 0, err
+	}
+
+	stmt2 := ArtVersionsFiles.
+		INSERT(
+			ArtVersionsFiles.VersionID,
+			ArtVersionsFiles.Filename,
+			ArtVersionsFiles.Filetype,
+			ArtVersionsFiles.URL,
+		).
+		QUERY(
+			SELECT(Int(int64(*version.ID)), Files.Filename, Files.Filetype, Files.URL).
+				FROM(Files).
+				WHERE(Files.ArtID.EQ(Int(int64(artId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt2, ctx, db, ErrArtVersionsFilesNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrArtVersionsFilesNoRowsAffected) {
+		return 0, err
+	}
+
+	return int(version.Version), nil
+}
+
+// NotifyFollowersAndBuyersWithDB sends the notification to everyone who
+// follows the art's creator or has bought the art. The creator is excluded.
+func (r *ArtsRepo) NotifyFollowersAndBuyersWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	artId int,
+	message string,
+	link string,
+) error {
+	creatorId := SELECT(Arts.CreatorID).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(artId))))
+
+	recipients := SELECT(Follow.UserIDFollower.AS("user_id")).
+		FROM(Follow).
+		WHERE(Follow.UserIDFollowee.EQ(IntExp(creatorId))).
+		UNION(
+			SELECT(UsersBoughtArts.UserID.AS("user_id")).
+				FROM(UsersBoughtArts).
+				WHERE(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
+		)
+
+	stmt := Notifications.
+		INSERT(Notifications.UserID, Notifications.Message, Notifications.Link).
+		QUERY(
+			SELECT(IntegerColumn("user_id"), String(message), String(link)).
+				FROM(recipients.AsTable("recipients")).
+				WHERE(IntegerColumn("user_id").NOT_EQ(IntExp(creatorId))),
+		)
+
+	err := HandleExecCtxWithErr(stmt, ctx, db, ErrNotificationsNoRowsAffected)
+	if err != nil && !errors.Is(err, ErrNotificationsNoRowsAffected) {
+		return err
+	}
+	return nil
+}
+
+func (r *ArtsRepo) FindNextArtVersionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	artId int,
+) (int, error) {
+	stmt := SELECT(IntExp(COALESCE(MAX(ArtVersions.Version), Int(0))).ADD(Int(1)).AS("next")).
+		FROM(ArtVersions).
+		WHERE(ArtVersions.ArtID.EQ(Int(int64(artId))))
+
+	var dest struct {
+		Next int `alias:"next"`
+	}
+	err := HandleQueryCtx(stmt, ctx, db, &dest, "art version")
+	return dest.Next, err
+}
@@ -0,0 +1,585 @@
+package repositories_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+const (
+	buyerId    = 3
+	buyerName  = "user1"
+	buyerCoin  = 100
+	artPrice   = 40
+	goroutines = 20
+)
+
+// setupPurchase resets the database with three arts of the first user and
+// gives the buyer some coin.
+func setupPurchase(t *testing.T) (*repositories.ArtsRepo, *repositories.LedgerRepo) {
+	t.Helper()
+
+	repositories.ResetDB(migrateDB)
+	t.Cleanup(func() { repositories.ResetDB(migrateDB) })
+
+	artsRepo := repositories.NewArtsRepo(nil, testDB, 5*time.Second)
+	ledgerRepo := repositories.NewLedgerRepo(testDB, 5*time.Second)
+
+	for id := 1; id <= 3; id++ {
+		_, err := testDB.Exec(
+			`INSERT INTO "arts" ("id", "name", "description", "creator_id", "price", "cover_url")
+			VALUES (?, ?, '', 1, ?, ?)`,
+			id, fmt.Sprint("art ", id), artPrice, fmt.Sprint("cover ", id),
+		)
+		asserts.EqualError(t, err, nil)
+		_, err = testDB.Exec(
+			`INSERT INTO "art_licenses" ("id", "art_id", "name", "price") VALUES (?, ?, 'Personal', ?)`,
+			id, id, artPrice,
+		)
+		asserts.EqualError(t, err, nil)
+	}
+	asserts.EqualError(t, ledgerRepo.AdjustCoin(buyerName, buyerCoin, "test"), nil)
+
+	return artsRepo, ledgerRepo
+}
+
+// buyInParallel runs every request at the same time and returns their errors.
+func buyInParallel(artsRepo *repositories.ArtsRepo, reqs []types.BuyArtReq) []error {
+	errs := make([]error, len(reqs))
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = artsRepo.BuyArt(req)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+func countBoughtArts(t *testing.T) int {
+	t.Helper()
+
+	var count int
+	err := testDB.QueryRow(`SELECT COUNT(*) FROM "users_bought_arts" WHERE "user_id" = ?`, buyerId).
+		Scan(&count)
+	asserts.EqualError(t, err, nil)
+	return count
+}
+
+func Test_ArtsRepo_BuyArt_Concurrent(t *testing.T) {
+	t.Run("parallel purchases cannot overspend", func(t *testing.T) {
+		artsRepo, ledgerRepo := setupPurchase(t)
+
+		reqs := make([]types.BuyArtReq, goroutines)
+		for i := range reqs {
+			artId := i%3 + 1
+			reqs[i] = types.BuyArtReq{UserId: buyerId, ArtId: artId, LicenseId: artId, Price: artPrice}
+		}
+
+		succeeded := 0
+		for _, err := range buyInParallel(artsRepo, reqs) {
+			if err == nil {
+				succeeded++
+			}
+		}
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "succeeded", succeeded, buyerCoin/artPrice)
+		asserts.Equal(t, "bought arts", countBoughtArts(t), buyerCoin/artPrice)
+		asserts.Equal(t, "coin", coin, buyerCoin%artPrice)
+
+		report, err := ledgerRepo.FindLedgerReport()
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+	})
+
+	t.Run("parallel purchases of the same art buy it once", func(t *testing.T) {
+		artsRepo, _ := setupPurchase(t)
+
+		reqs := make([]types.BuyArtReq, goroutines)
+		for i := range reqs {
+			reqs[i] = types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice}
+		}
+
+		succeeded := 0
+		for _, err := range buyInParallel(artsRepo, reqs) {
+			if err == nil {
+				succeeded++
+			} else {
+				asserts.EqualError(t, err, repositories.ErrLicenseAlreadyBought)
+			}
+		}
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "succeeded", succeeded, 1)
+		asserts.Equal(t, "coin", coin, buyerCoin-artPrice)
+	})
+
+	t.Run("retries with the same idempotency key return the original result", func(t *testing.T) {
+		artsRepo, _ := setupPurchase(t)
+
+		reqs := make([]types.BuyArtReq, goroutines)
+		for i := range reqs {
+			reqs[i] = types.BuyArtReq{
+				UserId:         buyerId,
+				ArtId:          1,
+				LicenseId:      1,
+				Price:          artPrice,
+				IdempotencyKey: "key",
+			}
+		}
+
+		for _, err := range buyInParallel(artsRepo, reqs) {
+			asserts.EqualError(t, err, nil)
+		}
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "bought arts", countBoughtArts(t), 1)
+		asserts.Equal(t, "coin", coin, buyerCoin-artPrice)
+
+		err = artsRepo.BuyArt(types.BuyArtReq{
+			UserId:         buyerId,
+			ArtId:          2,
+			LicenseId:      2,
+			Price:          artPrice,
+			IdempotencyKey: "key",
+		})
+		asserts.EqualError(t, err, repositories.ErrIdempotencyKeyReused)
+	})
+}
+
+func Test_ArtsRepo_BuyArt_Coupon(t *testing.T) {
+	const discountedPrice = artPrice / 2
+
+	createCoupon := func(t *testing.T, req types.CouponReq) {
+		t.Helper()
+
+		couponsRepo := repositories.NewCouponsRepo(testDB, 5*time.Second)
+		req.CreatorId = 1
+		req.Kind = types.CouponKindPercent
+		req.Value = 50
+		req.Scope = types.CouponScopeAll
+		asserts.EqualError(t, couponsRepo.CreateCoupon(req), nil)
+	}
+
+	t.Run("parallel purchases cannot exceed the usage cap", func(t *testing.T) {
+		artsRepo, ledgerRepo := setupPurchase(t)
+		maxUses := 1
+		createCoupon(t, types.CouponReq{Code: "HALF", MaxUses: &maxUses})
+
+		reqs := make([]types.BuyArtReq, 3)
+		for i := range reqs {
+			artId := i + 1
+			reqs[i] = types.BuyArtReq{
+				UserId:     buyerId,
+				ArtId:      artId,
+				LicenseId:  artId,
+				Price:      discountedPrice,
+				CouponCode: "HALF",
+				Now:        time.Now().UTC(),
+			}
+		}
+
+		succeeded := 0
+		for _, err := range buyInParallel(artsRepo, reqs) {
+			if err == nil {
+				succeeded++
+			} else {
+				asserts.EqualError(t, err, repositories.ErrCouponUsedUp)
+			}
+		}
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "succeeded", succeeded, maxUses)
+		asserts.Equal(t, "coin", coin, buyerCoin-discountedPrice)
+
+		report, err := ledgerRepo.FindLedgerReport()
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+	})
+
+	t.Run("expired coupons are rejected", func(t *testing.T) {
+		artsRepo, _ := setupPurchase(t)
+		expTime := time.Now().UTC().Add(-time.Hour)
+		createCoupon(t, types.CouponReq{Code: "OLD", ExpTime: &expTime})
+
+		err := artsRepo.BuyArt(types.BuyArtReq{
+			UserId:     buyerId,
+			ArtId:      1,
+			LicenseId:  1,
+			Price:      discountedPrice,
+			CouponCode: "OLD",
+			Now:        time.Now().UTC(),
+		})
+		asserts.EqualError(t, err, repositories.ErrCouponExpired)
+		asserts.Equal(t, "bought arts", countBoughtArts(t), 0)
+	})
+}
+
+func Test_ArtsRepo_BuyArt_Promotion(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	promotionsRepo := repositories.NewPromotionsRepo(testDB, 5*time.Second)
+	now := time.Now().UTC()
+
+	err := promotionsRepo.CreatePromotion(types.PromotionReq{
+		CreatorId: 1,
+		Name:      "sale",
+		Percent:   25,
+		Scope:     types.PromotionScopeArts,
+		ArtsID:    []int{1},
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(time.Hour),
+	})
+	asserts.EqualError(t, err, nil)
+
+	salePrice := types.PromotionPrice(artPrice, 25)
+
+	art, err := artsRepo.FindOneArt(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "effective price", art.EffectivePrice, salePrice)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, repositories.ErrInvalidPrice)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: salePrice})
+	asserts.EqualError(t, err, nil)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 2, LicenseId: 2, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin", coin, buyerCoin-salePrice-artPrice)
+}
+
+func Test_ArtsRepo_BuyArt_Editions(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+	_, err := testDB.Exec(`UPDATE "arts" SET "edition_size" = 2 WHERE "id" = 1`)
+	asserts.EqualError(t, err, nil)
+
+	buyers := map[int]string{buyerId: buyerName, 4: "user2", 5: "user3"}
+	reqs := []types.BuyArtReq{}
+	for userId, username := range buyers {
+		if userId != buyerId {
+			asserts.EqualError(t, ledgerRepo.AdjustCoin(username, buyerCoin, "test"), nil)
+		}
+		reqs = append(reqs, types.BuyArtReq{UserId: userId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	}
+
+	succeeded := 0
+	editions := map[int]bool{}
+	for i, err := range buyInParallel(artsRepo, reqs) {
+		if err != nil {
+			asserts.EqualError(t, err, repositories.ErrArtSoldOut)
+			continue
+		}
+		succeeded++
+
+		bought, err := artsRepo.FindBoughtLicense(reqs[i].UserId, 1)
+		asserts.EqualError(t, err, nil)
+		edition := bought.Edition()
+		asserts.Equal(t, "has an edition", edition != nil, true)
+		asserts.Equal(t, "edition size", edition.Size, 2)
+		editions[edition.Number] = true
+	}
+	asserts.Equal(t, "succeeded", succeeded, 2)
+	asserts.Equal(t, "edition numbers", editions, map[int]bool{1: true, 2: true})
+
+	art, err := artsRepo.FindOneArt(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "sold out", art.Edition.IsSoldOut(), true)
+
+	editionSize := 1
+	err = artsRepo.UpdateArtInfo(types.UpdateArtInfoReq{
+		ArtId:       1,
+		Name:        art.Name,
+		Status:      art.Status,
+		EditionSize: &editionSize,
+	})
+	asserts.EqualError(t, err, repositories.ErrEditionSizeTooSmall)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
+
+func Test_ArtsRepo_BuyArt_PayWhatYouWant(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+	ordersRepo := repositories.NewOrdersRepo(testDB, 5*time.Second)
+	_, err := testDB.Exec(`UPDATE "arts" SET "pay_what_you_want" = 1, "suggested_price" = 50 WHERE "id" = 1`)
+	asserts.EqualError(t, err, nil)
+
+	const paid = artPrice + 20
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice - 1})
+	asserts.EqualError(t, err, repositories.ErrPriceBelowMinimum)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{
+		UserId:     buyerId,
+		ArtId:      1,
+		LicenseId:  1,
+		Price:      paid,
+		CouponCode: "HALF",
+	})
+	asserts.EqualError(t, err, repositories.ErrCouponPayWhatYouWant)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: paid})
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin", coin, buyerCoin-paid)
+
+	orders, err := ordersRepo.FindManyOrders(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "orders", len(orders), 1)
+	asserts.Equal(t, "paid", int(orders[0].Items[0].Price), paid)
+	asserts.Equal(t, "discount", orders[0].Discount(), 0)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
+
+func Test_ArtsRepo_Downloads(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	_, err := testDB.Exec(`UPDATE "arts" SET "download_limit" = 1, "download_rate_limit" = 2 WHERE "id" = 1`)
+	asserts.EqualError(t, err, nil)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+
+	bought, err := artsRepo.FindBoughtLicense(buyerId, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "can download", bought.CanDownload(), true)
+
+	since := time.Now().UTC().Add(-time.Hour)
+	asserts.EqualError(t, artsRepo.ClaimDownload(buyerId, 1, since), nil)
+	asserts.EqualError(t, artsRepo.ClaimDownload(buyerId, 1, since), repositories.ErrDownloadLimitReached)
+
+	bought, err = artsRepo.FindBoughtLicense(buyerId, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "can download after the limit", bought.CanDownload(), false)
+	asserts.Equal(t, "download count", bought.DownloadCount, int32(1))
+
+	asserts.EqualError(t, artsRepo.ClaimDownload(2, 1, since), nil)
+	asserts.EqualError(t, artsRepo.ClaimDownload(2, 1, since), nil)
+	asserts.EqualError(t, artsRepo.ClaimDownload(2, 1, since), repositories.ErrDownloadRateLimited)
+	for range 3 {
+		asserts.EqualError(t, artsRepo.ClaimDownload(1, 1, since), nil)
+	}
+
+	rate, err := artsRepo.FindDownloadRate(2, 1, since)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "recent downloads", rate.Count, 2)
+	asserts.Equal(t, "rate limit reached", rate.IsReached(), true)
+
+	rate, err = artsRepo.FindDownloadRate(1, 1, since)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "creator is rate limited", rate.IsReached(), false)
+
+	downloads, err := artsRepo.FindManyDownloads(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "downloads", len(downloads), 1)
+	asserts.Equal(t, "downloaded art", downloads[0].Art.Name, "art 1")
+
+	art, err := artsRepo.FindOneArt(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "total downloads", art.TotalDownloads, 6)
+	asserts.Equal(t, "unique downloaders", art.TotalDownloaders, 3)
+}
+
+func Test_ArtsRepo_ArtVersions(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	notificationsRepo := repositories.NewNotificationsRepo(testDB, 5*time.Second)
+	ctx := context.Background()
+
+	_, err := testDB.Exec(
+		`INSERT INTO "files" ("art_id", "filename", "filetype", "url") VALUES (1, 'cat.png', 'png', 'file v1')`,
+	)
+	asserts.EqualError(t, err, nil)
+
+	version, err := artsRepo.CreateArtVersionWithDB(ctx, testDB, 1, "first")
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "first version", version, 1)
+
+	_, err = testDB.Exec(`UPDATE "arts" SET "cover_url" = 'cover v2' WHERE "id" = 1`)
+	asserts.EqualError(t, err, nil)
+	_, err = testDB.Exec(`UPDATE "files" SET "url" = 'file v2' WHERE "art_id" = 1`)
+	asserts.EqualError(t, err, nil)
+
+	next, err := artsRepo.FindNextArtVersionWithDB(ctx, testDB, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "next version", next, 2)
+	version, err = artsRepo.CreateArtVersionWithDB(ctx, testDB, 1, "second")
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "second version", version, 2)
+
+	versions, err := artsRepo.FindManyArtVersions(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "versions", len(versions), 2)
+	asserts.Equal(t, "newest first", versions[0].Version, int32(2))
+
+	latest, err := artsRepo.FindLatestArtVersion(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "latest cover", latest.CoverURL, "cover v2")
+	asserts.Equal(t, "latest files", len(latest.Files), 1)
+	asserts.Equal(t, "latest file", latest.Files[0].URL, "file v2")
+
+	first, err := artsRepo.FindOneArtVersion(1, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "first cover", first.CoverURL, "cover 1")
+	asserts.Equal(t, "first file", first.Files[0].URL, "file v1")
+
+	_, err = artsRepo.FindOneArtVersion(1, 3)
+	asserts.EqualError(t, err, repositories.ErrArtVersionNotFound)
+
+	urls, err := artsRepo.FindManyArtVersionsURLs(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "urls of every version", len(urls), 4)
+
+	// the buyer and a follower of the creator are told, the creator is not
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+	_, err = testDB.Exec(`INSERT OR IGNORE INTO "follow" ("user_id_follower", "user_id_followee") VALUES (4, 1)`)
+	asserts.EqualError(t, err, nil)
+
+	err = artsRepo.NotifyFollowersAndBuyersWithDB(ctx, testDB, 1, "art 1 has a new version", "/arts/1")
+	asserts.EqualError(t, err, nil)
+
+	for _, tt := range []struct {
+		name   string
+		userId int
+		count  int
+	}{
+		{name: "buyer", userId: buyerId, count: 1},
+		{name: "follower", userId: 4, count: 1},
+		{name: "creator", userId: 1, count: 0},
+	} {
+		count, err := notificationsRepo.CountUnreadNotifications(tt.userId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, tt.name+" notifications", count, tt.count)
+	}
+}
+
+func Test_ArtsRepo_Trash(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	now := time.Now().UTC()
+
+	asserts.EqualError(t, artsRepo.SoftDeleteArt(1, now), nil)
+	asserts.EqualError(t, artsRepo.SoftDeleteArt(1, now), repositories.ErrNoRowsAffected("arts"))
+
+	deleted, err := artsRepo.FindManyDeletedArts(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "deleted arts", len(deleted), 1)
+
+	forSale, err := artsRepo.IsArtForSale(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "deleted art for sale", forSale, false)
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, repositories.ErrArtNotForSale)
+
+	// restored within the retention
+	asserts.EqualError(t, artsRepo.RestoreArt(1, now.Add(-time.Hour)), nil)
+	deleted, err = artsRepo.FindManyDeletedArts(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "deleted arts after the restore", len(deleted), 0)
+
+	// past the retention
+	asserts.EqualError(t, artsRepo.SoftDeleteArt(1, now), nil)
+	asserts.EqualError(t, artsRepo.RestoreArt(1, now.Add(time.Hour)), repositories.ErrRestoreExpired)
+
+	// bought arts are kept for their buyers
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 2, LicenseId: 2, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+	asserts.EqualError(t, artsRepo.SoftDeleteArt(2, now), nil)
+
+	ids, err := artsRepo.FindManyPurgeableArtIds(now.Add(time.Hour))
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "purgeable arts", ids, []int{1})
+
+	ids, err = artsRepo.FindManyPurgeableArtIds(now.Add(-time.Hour))
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "purgeable arts within the retention", ids, []int{})
+}
+
+func Test_ArtsRepo_BuyArt_Ledger(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+
+	err := artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+
+	entries, err := ledgerRepo.FindManyWalletEntries(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "wallet entries", len(entries), 2)
+	asserts.Equal(t, "purchase type", entries[0].Transaction.Type, types.CoinTxPurchase)
+	asserts.Equal(t, "purchase amount", entries[0].SignedAmount(), -artPrice)
+	asserts.Equal(t, "purchased art", entries[0].Art.Name, "art 1")
+	asserts.Equal(t, "adjustment type", entries[1].Transaction.Type, types.CoinTxAdminAdjustment)
+	asserts.Equal(t, "adjustment amount", entries[1].SignedAmount(), buyerCoin)
+
+	err = ledgerRepo.AdjustCoin(buyerName, -buyerCoin, "too much")
+	asserts.EqualError(t, err, repositories.ErrNotEnoughCoin)
+	err = ledgerRepo.AdjustCoin("nobody", 10, "no user")
+	asserts.EqualError(t, err, repositories.ErrUserNotFound)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin is the sum of the entries", coin, buyerCoin-artPrice)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+
+	// a balance changed outside of the ledger no longer matches its entries
+	_, err = testDB.Exec(`UPDATE "users" SET "coin" = "coin" + 1 WHERE "id" = ?`, buyerId)
+	asserts.EqualError(t, err, nil)
+
+	report, err = ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), false)
+	asserts.Equal(t, "mismatched wallets", len(report.MismatchedWallets), 1)
+	asserts.Equal(t, "mismatched wallet", report.MismatchedWallets[0].UserId, buyerId)
+}
+
+func Test_ArtsRepo_BuyArt_PlatformFee(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+
+	// 15% of 40 is 6, and 12% of 40 is rounded down from 4.8 to 4
+	for _, req := range []types.BuyArtReq{
+		{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice, FeePercent: 15},
+		{UserId: buyerId, ArtId: 2, LicenseId: 2, Price: artPrice, FeePercent: 12},
+	} {
+		asserts.EqualError(t, artsRepo.BuyArt(req), nil)
+	}
+
+	earnings, err := ledgerRepo.FindCreatorEarnings(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings", earnings.Balance, 2*artPrice-6-4)
+	asserts.Equal(t, "sales", len(earnings.Sales), 2)
+	for _, sale := range earnings.Sales {
+		asserts.Equal(t, "sale price", sale.Price(), artPrice)
+	}
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "collected fees", report.CollectedFees, 6+4)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
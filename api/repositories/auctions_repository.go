@@ -0,0 +1,554 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrAuctionNotFound   = ErrNotFound("auction")
+	ErrArtNotAuctionable = httperror.New(
+		"only arts for sale that nobody bought yet can be auctioned",
+		http.StatusBadRequest,
+	)
+	ErrAuctionExists = httperror.New(
+		"this art is already being auctioned",
+		http.StatusBadRequest,
+	)
+	ErrAuctionHasBids = httperror.New(
+		"an auction with bids cannot be cancelled",
+		http.StatusBadRequest,
+	)
+	ErrAuctionClosed   = httperror.New("this auction is closed", http.StatusBadRequest)
+	ErrBidOnOwnAuction = httperror.New(
+		"you cannot bid on your own auction",
+		http.StatusBadRequest,
+	)
+	ErrBidTooLow = func(min int) error {
+		return httperror.New(
+			fmt.Sprintf("a bid should be at least %d Coin", min),
+			http.StatusBadRequest,
+		)
+	}
+)
+
+type AuctionsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewAuctionsRepo(db *sql.DB, timeout time.Duration) *AuctionsRepo {
+	return &AuctionsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// openAuctionExp finds the open auction of the art of the outer query. An
+// art is not sold at its price while it is auctioned.
+func openAuctionExp() SelectStatement {
+	return SELECT(Int(1)).
+		FROM(Auctions).
+		WHERE(
+			Auctions.ArtID.EQ(Arts.ID).
+				AND(Auctions.Status.EQ(String(types.AuctionStatusOpen))),
+		)
+}
+
+// CreateAuction starts an auction of the art of the creator. The art becomes
+// a one-of-a-kind art, so it can only be auctioned before anyone bought it.
+// When the auction does not sell, the single copy can still be bought at
+// the price of the art.
+func (r *AuctionsRepo) CreateAuction(req types.AuctionReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(Arts.ID).
+		FROM(Arts.INNER_JOIN(ArtLicenses, ArtLicenses.ArtID.EQ(Arts.ID))).
+		WHERE(
+			Arts.ID.EQ(Int(int64(req.ArtId))).
+				AND(Arts.CreatorID.EQ(Int(int64(req.CreatorId)))).
+				AND(ArtLicenses.ID.EQ(Int(int64(req.LicenseId)))).
+				AND(Arts.Price.GT(Int(0))).
+				AND(Arts.Status.IN(String(types.ArtStatusPublished), String(types.ArtStatusUnlisted))).
+				AND(Arts.DeletedAt.IS_NULL()).
+				AND(Arts.MembersTierID.IS_NULL()).
+				AND(editionsSoldExp().EQ(Int(0))),
+		)
+	var art model.Arts
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &art, ErrArtNotAuctionable); err != nil {
+		return err
+	}
+
+	stmt2 := Auctions.
+		INSERT(
+			Auctions.ArtID,
+			Auctions.LicenseID,
+			Auctions.StartPrice,
+			Auctions.MinIncrement,
+			Auctions.ReservePrice,
+			Auctions.EndsAt,
+			Auctions.ExtensionSeconds,
+		).
+		VALUES(
+			req.ArtId,
+			req.LicenseId,
+			req.StartPrice,
+			req.MinIncrement,
+			req.ReservePrice,
+			DATETIME(req.EndsAt),
+			req.ExtensionSeconds,
+		)
+	err = HandleExecCtx(stmt2, ctx, tx, "auctions")
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed: auctions.art_id") {
+		return ErrAuctionExists
+	}
+	if err != nil {
+		return err
+	}
+
+	stmt3 := Arts.UPDATE(Arts.EditionSize).
+		SET(Int(1)).
+		WHERE(Arts.ID.EQ(Int(int64(req.ArtId))))
+	if err := HandleExecCtx(stmt3, ctx, tx, "arts"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CancelAuction stops the open auction of the art of the creator before
+// anyone bids.
+func (r *AuctionsRepo) CancelAuction(creatorId, artId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	auction, err := findOneAuctionWithDB(
+		ctx,
+		tx,
+		Auctions.ArtID.EQ(Int(int64(artId))).
+			AND(Arts.CreatorID.EQ(Int(int64(creatorId)))).
+			AND(Auctions.Status.EQ(String(types.AuctionStatusOpen))),
+	)
+	if err != nil {
+		return err
+	}
+	if auction.LeadingBid != nil {
+		return ErrAuctionHasBids
+	}
+
+	err = setAuctionStatusWithDB(ctx, tx, int(*auction.ID), types.AuctionStatusCancelled, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindLatestAuction finds the newest auction of the art, or nil when the art
+// was never auctioned.
+func (r *AuctionsRepo) FindLatestAuction(artId int) (*types.Auction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := auctionsQuery(Auctions.ArtID.EQ(Int(int64(artId)))).
+		ORDER_BY(Auctions.ID.DESC()).
+		LIMIT(1)
+
+	var dest []types.Auction
+	if err := HandleQueryCtx(stmt, ctx, r.db, &dest, "auction"); err != nil {
+		return nil, err
+	}
+	if len(dest) == 0 {
+		return nil, nil
+	}
+	return &dest[0], nil
+}
+
+func findOneAuctionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	cond BoolExpression,
+) (types.Auction, error) {
+	var dest types.Auction
+	err := HandleQueryCtxWithErr(auctionsQuery(cond), ctx, db, &dest, ErrAuctionNotFound)
+	return dest, err
+}
+
+// auctionsQuery finds the auctions with their leading bid, which is the
+// winning bid once the auction is sold.
+func auctionsQuery(cond BoolExpression) SelectStatement {
+	leadingBid := AuctionBids.AS("LeadingBid")
+	bidder := Users.AS("Bidder")
+	bids := SELECT(COUNT(AuctionBids.ID)).
+		FROM(AuctionBids).
+		WHERE(AuctionBids.AuctionID.EQ(Auctions.ID))
+
+	return SELECT(
+		Auctions.AllColumns,
+		ArtLicenses.AllColumns,
+		leadingBid.AllColumns,
+		bidder.ID,
+		bidder.Username,
+		bidder.AvatarURL,
+		Arts.CreatorID.AS("auction.creator_id"),
+		IntExp(bids).AS("auction.bids"),
+	).
+		FROM(
+			Auctions.
+				INNER_JOIN(Arts, Arts.ID.EQ(Auctions.ArtID)).
+				INNER_JOIN(ArtLicenses, ArtLicenses.ID.EQ(Auctions.LicenseID)).
+				LEFT_JOIN(
+					leadingBid,
+					leadingBid.AuctionID.EQ(Auctions.ID).
+						AND(leadingBid.Status.IN(String(types.BidStatusLeading), String(types.BidStatusWon))),
+				).
+				LEFT_JOIN(bidder, bidder.ID.EQ(leadingBid.UserID)),
+		).
+		WHERE(cond)
+}
+
+// PlaceBid bids on the open auction of the art. It reserves the coins of the
+// bid from the wallet of the bidder and gives the coins of the bid it outbids
+// back to their bidder.
+func (r *AuctionsRepo) PlaceBid(req types.BidReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	auction, err := findOneAuctionWithDB(
+		ctx,
+		tx,
+		Auctions.ArtID.EQ(Int(int64(req.ArtId))).
+			AND(Auctions.Status.EQ(String(types.AuctionStatusOpen))),
+	)
+	if errors.Is(err, ErrAuctionNotFound) {
+		return ErrAuctionClosed
+	}
+	if err != nil {
+		return err
+	}
+	if !req.Now.Before(auction.EndsAt) {
+		return ErrAuctionClosed
+	}
+
+	art, err := findAuctionArtWithDB(ctx, tx, int(auction.ArtID))
+	if err != nil {
+		return err
+	}
+	if int(art.CreatorID) == req.UserId {
+		return ErrBidOnOwnAuction
+	}
+	if req.Amount < auction.MinBid() {
+		return ErrBidTooLow(auction.MinBid())
+	}
+
+	if auction.LeadingBid != nil {
+		previous := auction.LeadingBid
+		err := releaseBidWithDB(ctx, tx, *previous, art, types.BidStatusOutbid)
+		if err != nil {
+			return err
+		}
+
+		if int(previous.UserID) != req.UserId {
+			err = notifyUserWithDB(
+				ctx,
+				tx,
+				int(previous.UserID),
+				fmt.Sprintf("You were outbid on %s, your %d Coin are back", art.Name, previous.Amount),
+				fmt.Sprint("/arts/", *art.ID),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	artId := int(*art.ID)
+	reservationId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:        types.CoinTxAuctionBid,
+		Description: "Bid on " + art.Name,
+		ArtId:       &artId,
+		Legs: []types.CoinLeg{
+			types.WalletLeg(req.UserId, types.Debit, req.Amount),
+			types.AccountLeg(types.AccountEscrow, types.Credit, req.Amount),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	stmt1 := AuctionBids.
+		INSERT(AuctionBids.AuctionID, AuctionBids.UserID, AuctionBids.Amount, AuctionBids.ReservationID).
+		VALUES(*auction.ID, req.UserId, req.Amount, reservationId)
+	if err := HandleExecCtx(stmt1, ctx, tx, "auction_bids"); err != nil {
+		return err
+	}
+
+	if endsAt := auction.ExtendedEnd(req.Now); !endsAt.Equal(auction.EndsAt) {
+		stmt2 := Auctions.UPDATE(Auctions.EndsAt).
+			SET(DATETIME(endsAt)).
+			WHERE(Auctions.ID.EQ(Int(int64(*auction.ID))))
+		if err := HandleExecCtx(stmt2, ctx, tx, "auctions"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func findAuctionArtWithDB(ctx context.Context, db qrm.DB, artId int) (model.Arts, error) {
+	stmt := SELECT(Arts.ID, Arts.Name, Arts.CreatorID, Arts.Status, Arts.DeletedAt).
+		FROM(Arts).
+		WHERE(Arts.ID.EQ(Int(int64(artId))))
+
+	var dest model.Arts
+	err := HandleQueryCtx(stmt, ctx, db, &dest, "art")
+	return dest, err
+}
+
+// releaseBidWithDB gives the reserved coins of the leading bid back to its
+// bidder and moves the bid to the status.
+func releaseBidWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	bid model.AuctionBids,
+	art model.Arts,
+	status string,
+) error {
+	artId := int(*art.ID)
+	releaseId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+		Type:        types.CoinTxAuctionRelease,
+		Description: "Released bid on " + art.Name,
+		ArtId:       &artId,
+		Legs: []types.CoinLeg{
+			types.AccountLeg(types.AccountEscrow, types.Debit, int(bid.Amount)),
+			types.WalletLeg(int(bid.UserID), types.Credit, int(bid.Amount)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	stmt := AuctionBids.UPDATE(AuctionBids.Status, AuctionBids.ReleaseID).
+		SET(String(status), Int(int64(releaseId))).
+		WHERE(
+			AuctionBids.ID.EQ(Int(int64(*bid.ID))).
+				AND(AuctionBids.Status.EQ(String(types.BidStatusLeading))),
+		)
+	return HandleExecCtxWithErr(stmt, ctx, db, ErrAuctionClosed)
+}
+
+func setAuctionStatusWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	auctionId int,
+	status string,
+	winnerId *int,
+) error {
+	stmt := Auctions.UPDATE(Auctions.Status, Auctions.WinnerID).
+		SET(status, winnerId).
+		WHERE(
+			Auctions.ID.EQ(Int(int64(auctionId))).
+				AND(Auctions.Status.EQ(String(types.AuctionStatusOpen))),
+		)
+	return HandleExecCtxWithErr(stmt, ctx, db, ErrAuctionClosed)
+}
+
+// FindManyDueAuctions finds the open auctions whose end has passed.
+func (r *AuctionsRepo) FindManyDueAuctions(now time.Time) ([]model.Auctions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(Auctions.AllColumns).
+		FROM(Auctions).
+		WHERE(
+			Auctions.Status.EQ(String(types.AuctionStatusOpen)).
+				AND(DATETIME(Auctions.EndsAt).LT_EQ(DATETIME(now))),
+		).
+		ORDER_BY(Auctions.EndsAt.ASC(), Auctions.ID.ASC())
+
+	dest := []model.Auctions{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "auction")
+	return dest, err
+}
+
+// CloseAuction settles the auction once it ended. When the leading bid meets
+// the reserve, the bidder gets the art under the license of the auction and
+// the reserved coins go to the creator, less the platform fee, like a
+// purchase. Otherwise the coins go back to the bidder and the art is not sold.
+func (r *AuctionsRepo) CloseAuction(auctionId, feePercent int, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	auction, err := findOneAuctionWithDB(
+		ctx,
+		tx,
+		Auctions.ID.EQ(Int(int64(auctionId))).
+			AND(Auctions.Status.EQ(String(types.AuctionStatusOpen))).
+			AND(DATETIME(Auctions.EndsAt).LT_EQ(DATETIME(now))),
+	)
+	if err != nil {
+		return err
+	}
+
+	art, err := findAuctionArtWithDB(ctx, tx, int(auction.ArtID))
+	if err != nil {
+		return err
+	}
+	forSale := art.DeletedAt == nil &&
+		(art.Status == types.ArtStatusPublished || art.Status == types.ArtStatusUnlisted)
+
+	if !auction.ReserveMet() || !forSale {
+		if err := r.closeUnsoldWithDB(ctx, tx, auction, art); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	bid := auction.LeadingBid
+	winnerId, artId, licenseId := int(bid.UserID), int(*art.ID), int(auction.LicenseID)
+	amount := int(bid.Amount)
+
+	if err := insertBoughtArtWithDB(ctx, tx, winnerId, artId, licenseId); err != nil {
+		return err
+	}
+
+	order, err := insertOrderWithDB(ctx, tx, types.OrderReq{
+		UserId:      winnerId,
+		Kind:        types.OrderKindArt,
+		Description: "Won at auction",
+		FeePercent:  feePercent,
+		Items: []types.OrderItemReq{{
+			ArtId:       &artId,
+			LicenseId:   &licenseId,
+			CreatorId:   int(art.CreatorID),
+			Name:        art.Name,
+			LicenseName: auction.License.Name,
+			ListPrice:   amount,
+			Price:       amount,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	fee := types.PlatformFee(amount, feePercent)
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:        types.CoinTxPurchase,
+		Description: "Won at auction",
+		ArtId:       &artId,
+		Legs: []types.CoinLeg{
+			types.AccountLeg(types.AccountEscrow, types.Debit, amount),
+			types.EarningsLeg(int(art.CreatorID), types.Credit, amount-fee),
+			types.AccountLeg(types.AccountFees, types.Credit, fee),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := setOrderTransactionWithDB(ctx, tx, int(*order.ID), transactionId); err != nil {
+		return err
+	}
+
+	stmt := AuctionBids.UPDATE(AuctionBids.Status).
+		SET(String(types.BidStatusWon)).
+		WHERE(AuctionBids.ID.EQ(Int(int64(*bid.ID))))
+	if err := HandleExecCtx(stmt, ctx, tx, "auction_bids"); err != nil {
+		return err
+	}
+	if err := setAuctionStatusWithDB(ctx, tx, auctionId, types.AuctionStatusSold, &winnerId); err != nil {
+		return err
+	}
+
+	link := fmt.Sprint("/arts/", artId)
+	err = notifyUserWithDB(ctx, tx, winnerId, fmt.Sprintf("You won the auction of %s", art.Name), link)
+	if err != nil {
+		return err
+	}
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		int(art.CreatorID),
+		fmt.Sprintf("%s sold at auction for %d Coin", art.Name, amount),
+		link,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *AuctionsRepo) closeUnsoldWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	auction types.Auction,
+	art model.Arts,
+) error {
+	link := fmt.Sprint("/arts/", *art.ID)
+
+	if bid := auction.LeadingBid; bid != nil {
+		if err := releaseBidWithDB(ctx, db, *bid, art, types.BidStatusReleased); err != nil {
+			return err
+		}
+
+		err := notifyUserWithDB(
+			ctx,
+			db,
+			int(bid.UserID),
+			fmt.Sprintf("The auction of %s ended without a sale, your %d Coin are back", art.Name, bid.Amount),
+			link,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := setAuctionStatusWithDB(ctx, db, int(*auction.ID), types.AuctionStatusUnsold, nil); err != nil {
+		return err
+	}
+
+	return notifyUserWithDB(
+		ctx,
+		db,
+		int(art.CreatorID),
+		fmt.Sprintf("The auction of %s ended without a sale", art.Name),
+		link,
+	)
+}
@@ -0,0 +1,117 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+// setupAuction auctions the first art of the creator for an hour, starting
+// at the price of the art.
+func setupAuction(t *testing.T, now time.Time) (*repositories.ArtsRepo, *repositories.LedgerRepo, *repositories.AuctionsRepo) {
+	t.Helper()
+
+	artsRepo, ledgerRepo := setupPurchase(t)
+	auctionsRepo := repositories.NewAuctionsRepo(testDB, 5*time.Second)
+
+	err := auctionsRepo.CreateAuction(types.AuctionReq{
+		ArtId:            1,
+		CreatorId:        creatorId,
+		LicenseId:        1,
+		StartPrice:       artPrice,
+		MinIncrement:     5,
+		EndsAt:           now.Add(time.Hour),
+		ExtensionSeconds: 300,
+	})
+	asserts.EqualError(t, err, nil)
+
+	return artsRepo, ledgerRepo, auctionsRepo
+}
+
+func Test_AuctionsRepo_PlaceBid(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	artsRepo, ledgerRepo, auctionsRepo := setupAuction(t, now)
+
+	forSale, err := artsRepo.IsArtForSale(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "art for sale during the auction", forSale, false)
+
+	bid := func(userId, amount int, at time.Time) error {
+		return auctionsRepo.PlaceBid(types.BidReq{ArtId: 1, UserId: userId, Amount: amount, Now: at})
+	}
+
+	asserts.EqualError(t, bid(creatorId, artPrice, now), repositories.ErrBidOnOwnAuction)
+	asserts.EqualError(t, bid(buyerId, artPrice-1, now), repositories.ErrBidTooLow(artPrice))
+	asserts.EqualError(t, bid(buyerId, artPrice, now), nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin reserved by the bid", coin, buyerCoin-artPrice)
+
+	asserts.EqualError(t, bid(buyerId, artPrice+4, now), repositories.ErrBidTooLow(artPrice+5))
+
+	// a bid in the last minutes pushes the end back
+	late := now.Add(58 * time.Minute)
+	asserts.EqualError(t, bid(buyerId, artPrice+5, late), nil)
+
+	coin, err = artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after outbidding themselves", coin, buyerCoin-artPrice-5)
+
+	auction, err := auctionsRepo.FindLatestAuction(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "bids", auction.Bids, 2)
+	asserts.Equal(t, "leading bid", auction.LeadingBid.Amount, int32(artPrice+5))
+	asserts.Equal(t, "extended end", auction.EndsAt, late.Add(5*time.Minute))
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+
+	err = auctionsRepo.CancelAuction(creatorId, 1)
+	asserts.EqualError(t, err, repositories.ErrAuctionHasBids)
+	asserts.EqualError(t, bid(buyerId, buyerCoin, late.Add(6*time.Minute)), repositories.ErrAuctionClosed)
+}
+
+func Test_AuctionsRepo_CloseAuction(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	artsRepo, ledgerRepo, auctionsRepo := setupAuction(t, now)
+
+	err := auctionsRepo.PlaceBid(types.BidReq{ArtId: 1, UserId: buyerId, Amount: 60, Now: now})
+	asserts.EqualError(t, err, nil)
+
+	due, err := auctionsRepo.FindManyDueAuctions(now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due auctions before the end", len(due), 0)
+
+	end := now.Add(time.Hour)
+	due, err = auctionsRepo.FindManyDueAuctions(end)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due auctions", len(due), 1)
+
+	asserts.EqualError(t, auctionsRepo.CloseAuction(int(*due[0].ID), 10, end), nil)
+
+	isBought, err := artsRepo.HasUsersBoughtArts(buyerId, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "winner owns the art", isBought, true)
+
+	earnings, err := ledgerRepo.FindCreatorEarnings(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "creator earnings", earnings.Balance, 60-types.PlatformFee(60, 10))
+
+	auction, err := auctionsRepo.FindLatestAuction(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "status", auction.Status, types.AuctionStatusSold)
+
+	art, err := artsRepo.FindOneArt(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "one-of-a-kind art sold out", art.Edition.IsSoldOut(), true)
+	asserts.Equal(t, "art on auction", art.OnAuction, false)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
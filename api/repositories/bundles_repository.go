@@ -0,0 +1,468 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+// number of bundles shown on top of the search results
+const searchBundlesLimit = 4
+
+var (
+	ErrBundleNotFound            = ErrNotFound("bundle")
+	ErrBundlesArtsNoRowsAffected = ErrNoRowsAffected("bundles_arts")
+	ErrBoughtArtsNoRowsAffected  = ErrNoRowsAffected("users_bought_arts")
+	ErrBundleAlreadyOwned        = httperror.New(
+		"you already own every art of this bundle",
+		http.StatusBadRequest,
+	)
+	ErrBundleAlreadyBought = httperror.New("user already bought this bundle", http.StatusBadRequest)
+	ErrBundleNotForSale    = httperror.New("this bundle is not for sale", http.StatusBadRequest)
+)
+
+type BundlesRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewBundlesRepo(db *sql.DB, timeout time.Duration) *BundlesRepo {
+	return &BundlesRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *BundlesRepo) FindOneBundle(id int) (types.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.findOneBundleWithDB(ctx, r.db, id)
+}
+
+func (r *BundlesRepo) findOneBundleWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	id int,
+) (types.Bundle, error) {
+	stmt := r.bundlesStmt(Bundles.ID.EQ(Int(int64(id))))
+
+	var dest types.Bundle
+	err := HandleQueryCtxWithErr(stmt, ctx, db, &dest, ErrBundleNotFound)
+	return dest, err
+}
+
+func (r *BundlesRepo) FindManyCreatedBundles(creatorId int) ([]types.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := r.bundlesStmt(Bundles.CreatorID.EQ(Int(int64(creatorId))))
+
+	var dest []types.Bundle
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "bundle")
+	return dest, err
+}
+
+// FindManyBundles returns the bundles for sale that match the search and
+// the price filter of the request.
+func (r *BundlesRepo) FindManyBundles(req types.ManyArtsReq) ([]types.Bundle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	creator := Users.AS("Creator")
+
+	var cond BoolExpression = r.forSaleCond()
+	if req.Search != "" {
+		cond = cond.AND(
+			Bundles.Name.LIKE(String("%" + req.Search + "%")).
+				OR(Bundles.Description.LIKE(String("%" + req.Search + "%"))).
+				OR(creator.Username.LIKE(String("%" + req.Search + "%"))),
+		)
+	}
+	if req.CreatorId != 0 {
+		cond = cond.AND(Bundles.CreatorID.EQ(Int(int64(req.CreatorId))))
+	}
+	if req.Filter.MinPrice != -1 {
+		cond = cond.AND(Bundles.Price.GT_EQ(Int(int64(req.Filter.MinPrice))))
+	}
+	if req.Filter.MaxPrice != -1 {
+		cond = cond.AND(Bundles.Price.LT_EQ(Int(int64(req.Filter.MaxPrice))))
+	}
+
+	bundlesIds := SELECT(Bundles.ID).
+		FROM(Bundles.LEFT_JOIN(creator, creator.ID.EQ(Bundles.CreatorID))).
+		WHERE(cond).
+		ORDER_BY(Bundles.CreatedAt.DESC()).
+		LIMIT(searchBundlesLimit)
+
+	stmt := r.bundlesStmt(Bundles.ID.IN(bundlesIds))
+
+	var dest []types.Bundle
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "bundle")
+	return dest, err
+}
+
+func (r *BundlesRepo) bundlesStmt(cond BoolExpression) SelectStatement {
+	creator := Users.AS("Creator")
+
+	return SELECT(
+		Bundles.AllColumns,
+		creator.AllColumns.Except(creator.Password),
+		Arts.AllColumns,
+	).FROM(
+		Bundles.
+			LEFT_JOIN(creator, creator.ID.EQ(Bundles.CreatorID)).
+			LEFT_JOIN(BundlesArts, BundlesArts.BundleID.EQ(Bundles.ID)).
+			LEFT_JOIN(Arts, Arts.ID.EQ(BundlesArts.ArtID)),
+	).WHERE(cond).ORDER_BY(Bundles.CreatedAt.DESC(), Bundles.ID.DESC(), Arts.ID.ASC())
+}
+
+// forSaleCond matches the bundles whose arts are all for sale.
+func (r *BundlesRepo) forSaleCond() BoolExpression {
+	notForSale := SELECT(Int(1)).
+		FROM(BundlesArts.INNER_JOIN(Arts, Arts.ID.EQ(BundlesArts.ArtID))).
+		WHERE(
+			BundlesArts.BundleID.EQ(Bundles.ID).
+				AND(
+					Arts.DeletedAt.IS_NOT_NULL().
+						OR(Arts.Status.NOT_IN(String(types.ArtStatusPublished), String(types.ArtStatusUnlisted))).
+						OR(Arts.MembersTierID.IS_NOT_NULL()).
+						OR(EXISTS(openAuctionExp())),
+				),
+		)
+
+	return NOT(EXISTS(notForSale))
+}
+
+func (r *BundlesRepo) IsBundleForSale(bundleId int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.isBundleForSaleWithDB(ctx, r.db, bundleId)
+}
+
+func (r *BundlesRepo) isBundleForSaleWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	bundleId int,
+) (bool, error) {
+	stmt := SELECT(Int(1)).
+		FROM(Bundles).
+		WHERE(Bundles.ID.EQ(Int(int64(bundleId))).AND(r.forSaleCond()))
+
+	var tmp struct{ int }
+	return HandleHasCtx(stmt, ctx, db, &tmp)
+}
+
+// FindManyBundleableArts returns the arts of the creator that can be put in
+// a bundle.
+func (r *BundlesRepo) FindManyBundleableArts(creatorId int) ([]model.Arts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(Arts.AllColumns).
+		FROM(Arts).
+		WHERE(
+			Arts.CreatorID.EQ(Int(int64(creatorId))).
+				AND(Arts.DeletedAt.IS_NULL()),
+		).
+		ORDER_BY(Arts.ID.DESC())
+
+	var dest []model.Arts
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art")
+	return dest, err
+}
+
+// CountCreatedArts returns how many of the arts belong to the creator and the
+// total price of those arts.
+func (r *BundlesRepo) CountCreatedArts(creatorId int, artsId []int) (count, total int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	ids := utils.Map(artsId, func(id int) Expression { return Int(int64(id)) })
+	stmt := SELECT(
+		COUNT(Arts.ID).AS("count"),
+		COALESCE(SUM(Arts.Price), Int(0)).AS("total"),
+	).
+		FROM(Arts).
+		WHERE(
+			Arts.ID.IN(ids...).
+				AND(Arts.CreatorID.EQ(Int(int64(creatorId)))).
+				AND(Arts.DeletedAt.IS_NULL()),
+		)
+
+	var dest struct {
+		Count int `alias:"count"`
+		Total int `alias:"total"`
+	}
+	err = HandleQueryCtx(stmt, ctx, r.db, &dest, "art")
+	return dest.Count, dest.Total, err
+}
+
+// FindOwnedArtsID returns the ids of the bundle's arts that the user owns.
+func (r *BundlesRepo) FindOwnedArtsID(userId, bundleId int) (map[int]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.findOwnedArtsIDWithDB(ctx, r.db, userId, bundleId)
+}
+
+func (r *BundlesRepo) findOwnedArtsIDWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId, bundleId int,
+) (map[int]bool, error) {
+	stmt := SELECT(UsersBoughtArts.ArtID).
+		FROM(
+			UsersBoughtArts.
+				INNER_JOIN(BundlesArts, BundlesArts.ArtID.EQ(UsersBoughtArts.ArtID)),
+		).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(BundlesArts.BundleID.EQ(Int(int64(bundleId)))),
+		)
+
+	var dest []model.UsersBoughtArts
+	if err := HandleQueryCtx(stmt, ctx, db, &dest, "art"); err != nil {
+		return nil, err
+	}
+
+	owned := make(map[int]bool, len(dest))
+	for _, bought := range dest {
+		owned[int(bought.ArtID)] = true
+	}
+	return owned, nil
+}
+
+func hasUsersBoughtBundlesWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId, bundleId int,
+) (bool, error) {
+	stmt := SELECT(Int(1)).
+		FROM(UsersBoughtBundles).
+		WHERE(
+			UsersBoughtBundles.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtBundles.BundleID.EQ(Int(int64(bundleId)))),
+		)
+
+	var tmp struct{ int }
+	return HandleHasCtx(stmt, ctx, db, &tmp)
+}
+
+func (r *BundlesRepo) CreateBundle(req types.BundleReq) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := Bundles.
+		INSERT(Bundles.CreatorID, Bundles.Name, Bundles.Description, Bundles.Price).
+		VALUES(req.CreatorId, req.Name, req.Description, req.Price).
+		RETURNING(Bundles.ID)
+
+	var bundle model.Bundles
+	if err := HandleQueryCtx(stmt, ctx, tx, &bundle, "bundle"); err != nil {
+		return 0, err
+	}
+
+	if err := r.insertBundlesArtsWithDB(ctx, tx, int(*bundle.ID), req.ArtsID); err != nil {
+		return 0, err
+	}
+
+	return int(*bundle.ID), tx.Commit()
+}
+
+func (r *BundlesRepo) UpdateBundle(req types.BundleReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := Bundles.
+		UPDATE(Bundles.Name, Bundles.Description, Bundles.Price).
+		SET(req.Name, req.Description, req.Price).
+		WHERE(
+			Bundles.ID.EQ(Int(int64(req.BundleId))).
+				AND(Bundles.CreatorID.EQ(Int(int64(req.CreatorId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt1, ctx, tx, ErrBundleNotFound); err != nil {
+		return err
+	}
+
+	stmt2 := BundlesArts.DELETE().WHERE(BundlesArts.BundleID.EQ(Int(int64(req.BundleId))))
+	if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrBundlesArtsNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrBundlesArtsNoRowsAffected) {
+		return err
+	}
+
+	if err := r.insertBundlesArtsWithDB(ctx, tx, req.BundleId, req.ArtsID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *BundlesRepo) insertBundlesArtsWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	bundleId int,
+	artsId []int,
+) error {
+	stmt := BundlesArts.INSERT(BundlesArts.BundleID, BundlesArts.ArtID)
+	for _, artId := range artsId {
+		stmt = stmt.VALUES(bundleId, artId)
+	}
+
+	return HandleExecCtx(stmt, ctx, db, "bundles_arts")
+}
+
+func (r *BundlesRepo) DeleteBundle(creatorId, bundleId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Bundles.DELETE().
+		WHERE(
+			Bundles.ID.EQ(Int(int64(bundleId))).
+				AND(Bundles.CreatorID.EQ(Int(int64(creatorId)))),
+		)
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrBundleNotFound)
+}
+
+// BuyBundle grants every art of the bundle that the user does not own yet
+// under its cheapest license. The price is what the user expects to pay and
+// it is checked against the pro-rated price of the bundle. Whether the bundle
+// is for sale is checked in the same transaction, so an art that goes on
+// auction meanwhile is not sold with the bundle.
+func (r *BundlesRepo) BuyBundle(userId, bundleId, price, feePercent int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	forSale, err := r.isBundleForSaleWithDB(ctx, tx, bundleId)
+	if err != nil {
+		return err
+	}
+	if !forSale {
+		return ErrBundleNotForSale
+	}
+	bought, err := hasUsersBoughtBundlesWithDB(ctx, tx, userId, bundleId)
+	if err != nil {
+		return err
+	}
+	if bought {
+		return ErrBundleAlreadyBought
+	}
+
+	bundle, err := r.findOneBundleWithDB(ctx, tx, bundleId)
+	if err != nil {
+		return err
+	}
+	owned, err := r.findOwnedArtsIDWithDB(ctx, tx, userId, bundleId)
+	if err != nil {
+		return err
+	}
+	if bundle.OwnsAll(owned) {
+		return ErrBundleAlreadyOwned
+	}
+	if bundle.PriceFor(owned) != price {
+		return ErrInvalidPrice
+	}
+
+	cheapestLicense := SELECT(ArtLicenses.ID).
+		FROM(ArtLicenses).
+		WHERE(ArtLicenses.ArtID.EQ(BundlesArts.ArtID)).
+		ORDER_BY(ArtLicenses.Price.ASC(), ArtLicenses.ID.ASC()).
+		LIMIT(1)
+	alreadyOwned := SELECT(Int(1)).
+		FROM(UsersBoughtArts).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtArts.ArtID.EQ(BundlesArts.ArtID)),
+		)
+
+	stmt1 := SELECT(BundlesArts.ArtID, IntExp(cheapestLicense).AS("license_id")).
+		FROM(BundlesArts).
+		WHERE(
+			BundlesArts.BundleID.EQ(Int(int64(bundleId))).
+				AND(NOT(EXISTS(alreadyOwned))),
+		)
+	var toBuy []struct {
+		ArtId     int `alias:"bundles_arts.art_id"`
+		LicenseId int `alias:"license_id"`
+	}
+	if err := HandleQueryCtx(stmt1, ctx, tx, &toBuy, "bundles_arts"); err != nil {
+		return err
+	}
+	if len(toBuy) == 0 {
+		return ErrBoughtArtsNoRowsAffected
+	}
+	// every art goes through insertBoughtArtWithDB for its edition number, so
+	// a sold out limited-edition art fails the whole bundle
+	for _, art := range toBuy {
+		if err := insertBoughtArtWithDB(ctx, tx, userId, art.ArtId, art.LicenseId); err != nil {
+			return err
+		}
+	}
+
+	stmt2 := UsersBoughtBundles.
+		INSERT(UsersBoughtBundles.UserID, UsersBoughtBundles.BundleID, UsersBoughtBundles.Price).
+		VALUES(userId, bundleId, price)
+	if err := HandleExecCtx(stmt2, ctx, tx, "users_bought_bundles"); err != nil {
+		return err
+	}
+
+	order, err := insertOrderWithDB(ctx, tx, types.OrderReq{
+		UserId:     userId,
+		Kind:       types.OrderKindBundle,
+		FeePercent: feePercent,
+		Items: []types.OrderItemReq{{
+			BundleId:  &bundleId,
+			CreatorId: int(bundle.CreatorID),
+			Name:      bundle.Name,
+			ListPrice: int(bundle.Price),
+			Price:     price,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:     types.CoinTxPurchase,
+		BundleId: &bundleId,
+		Legs:     types.SaleLegs(userId, int(bundle.CreatorID), price, feePercent),
+	})
+	if err != nil {
+		return err
+	}
+	if err := setOrderTransactionWithDB(ctx, tx, int(*order.ID), transactionId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,51 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_BundlesRepo_BuyBundle(t *testing.T) {
+	const bundlePrice = artPrice
+
+	artsRepo, _ := setupPurchase(t)
+	bundlesRepo := repositories.NewBundlesRepo(testDB, 5*time.Second)
+	auctionsRepo := repositories.NewAuctionsRepo(testDB, 5*time.Second)
+
+	bundleId, err := bundlesRepo.CreateBundle(types.BundleReq{
+		CreatorId: creatorId,
+		Name:      "bundle",
+		Price:     bundlePrice,
+		ArtsID:    []int{1, 2},
+	})
+	asserts.EqualError(t, err, nil)
+
+	// the art goes on auction after the bundle is shown for sale
+	err = auctionsRepo.CreateAuction(types.AuctionReq{
+		ArtId:        1,
+		CreatorId:    creatorId,
+		LicenseId:    1,
+		StartPrice:   artPrice,
+		MinIncrement: 5,
+		EndsAt:       time.Now().UTC().Add(time.Hour),
+	})
+	asserts.EqualError(t, err, nil)
+
+	err = bundlesRepo.BuyBundle(buyerId, bundleId, bundlePrice, 0)
+	asserts.EqualError(t, err, repositories.ErrBundleNotForSale)
+
+	bought, err := artsRepo.HasUsersBoughtArts(buyerId, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "auctioned art bought with the bundle", bought, false)
+
+	asserts.EqualError(t, auctionsRepo.CancelAuction(creatorId, 1), nil)
+
+	err = bundlesRepo.BuyBundle(buyerId, bundleId, bundlePrice, 0)
+	asserts.EqualError(t, err, nil)
+	err = bundlesRepo.BuyBundle(buyerId, bundleId, bundlePrice, 0)
+	asserts.EqualError(t, err, repositories.ErrBundleAlreadyBought)
+}
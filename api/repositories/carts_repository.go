@@ -0,0 +1,306 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrCartItemNotFound   = ErrNotFound("cart item")
+	ErrCartArtOwned       = httperror.New("user already owns this art", http.StatusBadRequest)
+	ErrCartEmpty          = httperror.New("there is nothing to buy in the cart", http.StatusBadRequest)
+	ErrCartPayWhatYouWant = httperror.New(
+		"choose the price of pay-what-you-want arts on their page",
+		http.StatusBadRequest,
+	)
+
+	ErrCartItemNotForSale = func(name string) error {
+		return httperror.New(name+" is no longer for sale, remove it from the cart", http.StatusBadRequest)
+	}
+	ErrCartItemSoldOut = func(name string) error {
+		return httperror.New(name+" is sold out, remove it from the cart", http.StatusBadRequest)
+	}
+)
+
+type CartsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewCartsRepo(db *sql.DB, timeout time.Duration) *CartsRepo {
+	return &CartsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// AddCartItem puts the license of the art in the user's cart, or changes the
+// license when the art is already there. A licenseId of 0 picks the cheapest
+// license of the art.
+func (r *CartsRepo) AddCartItem(userId, artId, licenseId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(Arts.ID, Arts.PayWhatYouWant).
+		FROM(Arts).
+		WHERE(
+			Arts.ID.EQ(Int(int64(artId))).
+				AND(Arts.Status.IN(String(types.ArtStatusPublished), String(types.ArtStatusUnlisted))).
+				AND(Arts.DeletedAt.IS_NULL()).
+				AND(Arts.MembersTierID.IS_NULL()).
+				AND(NOT(EXISTS(openAuctionExp()))),
+		)
+	var art model.Arts
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &art, ErrArtNotForSale); err != nil {
+		return err
+	}
+	if art.PayWhatYouWant {
+		return ErrCartPayWhatYouWant
+	}
+
+	stmt2 := SELECT(UsersBoughtArts.ArtID).
+		FROM(UsersBoughtArts).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
+		)
+	var bought model.UsersBoughtArts
+	owned, err := HandleHasCtx(stmt2, ctx, tx, &bought)
+	if err != nil {
+		return err
+	}
+	if owned {
+		return ErrCartArtOwned
+	}
+
+	cond := ArtLicenses.ArtID.EQ(Int(int64(artId)))
+	if licenseId != 0 {
+		cond = cond.AND(ArtLicenses.ID.EQ(Int(int64(licenseId))))
+	}
+	stmt3 := SELECT(ArtLicenses.ID).
+		FROM(ArtLicenses).
+		WHERE(cond).
+		ORDER_BY(ArtLicenses.Price.ASC(), ArtLicenses.ID.ASC()).
+		LIMIT(1)
+	var license model.ArtLicenses
+	if err := HandleQueryCtxWithErr(stmt3, ctx, tx, &license, ErrArtLicenseNotFound); err != nil {
+		return err
+	}
+
+	stmt4 := CartItems.
+		INSERT(CartItems.UserID, CartItems.ArtID, CartItems.LicenseID).
+		VALUES(userId, artId, *license.ID).
+		ON_CONFLICT(CartItems.UserID, CartItems.ArtID).
+		DO_UPDATE(SET(CartItems.LicenseID.SET(CartItems.EXCLUDED.LicenseID)))
+	if err := HandleExecCtx(stmt4, ctx, tx, "cart_items"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CartsRepo) RemoveCartItem(userId, artId int) error {
+	stmt := CartItems.DELETE().
+		WHERE(
+			CartItems.UserID.EQ(Int(int64(userId))).
+				AND(CartItems.ArtID.EQ(Int(int64(artId)))),
+		)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrCartItemNotFound)
+}
+
+func (r *CartsRepo) FindCart(userId int) (types.Cart, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	items, err := findCartItemsWithDB(ctx, r.db, userId)
+	return types.Cart{Items: items}, err
+}
+
+func (r *CartsRepo) CountCartItems(userId int) (int, error) {
+	stmt := SELECT(COUNT(CartItems.ArtID).AS("count")).
+		FROM(CartItems).
+		WHERE(CartItems.UserID.EQ(Int(int64(userId))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest struct {
+		Count int `alias:"count"`
+	}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "cart item")
+	return dest.Count, err
+}
+
+// findCartItemsWithDB finds the items of the cart, oldest first, with their
+// price after the running promotion.
+func findCartItemsWithDB(ctx context.Context, db qrm.DB, userId int) ([]types.CartItem, error) {
+	creator := Users.AS("Creator")
+	bought := SELECT(Int(1)).
+		FROM(UsersBoughtArts).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(CartItems.UserID).
+				AND(UsersBoughtArts.ArtID.EQ(CartItems.ArtID)),
+		)
+
+	stmt := SELECT(
+		CartItems.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Arts.CoverURL,
+		Arts.Status,
+		Arts.DeletedAt,
+		Arts.CreatorID,
+		Arts.PayWhatYouWant,
+		Arts.MembersTierID,
+		ArtLicenses.AllColumns,
+		creator.ID,
+		creator.Username,
+		promotionPercentExp().AS("cart_item.promotion_percent"),
+		EXISTS(bought).AS("cart_item.is_bought"),
+		EXISTS(openAuctionExp()).AS("cart_item.on_auction"),
+	).
+		FROM(
+			CartItems.
+				INNER_JOIN(Arts, Arts.ID.EQ(CartItems.ArtID)).
+				INNER_JOIN(ArtLicenses, ArtLicenses.ID.EQ(CartItems.LicenseID)).
+				INNER_JOIN(creator, creator.ID.EQ(Arts.CreatorID)),
+		).
+		WHERE(CartItems.UserID.EQ(Int(int64(userId)))).
+		ORDER_BY(CartItems.CreatedAt.ASC(), CartItems.ArtID.ASC())
+
+	items := []types.CartItem{}
+	err := HandleQueryCtx(stmt, ctx, db, &items, "cart item")
+	return items, err
+}
+
+// Checkout buys every item of the cart that the user does not own yet and
+// empties the cart. The buyer is debited once for the total and each creator
+// is credited with their items minus the platform fee. It returns the id of
+// the order.
+func (r *CartsRepo) Checkout(req types.CheckoutReq) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	items, err := findCartItemsWithDB(ctx, tx, req.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	toBuy := []types.CartItem{}
+	for _, item := range items {
+		if item.IsBought {
+			continue
+		}
+		if item.Art.PayWhatYouWant {
+			return 0, ErrCartPayWhatYouWant
+		}
+		if !item.IsForSale() {
+			return 0, ErrCartItemNotForSale(item.Art.Name)
+		}
+		toBuy = append(toBuy, item)
+	}
+	if len(toBuy) == 0 {
+		return 0, ErrCartEmpty
+	}
+
+	cart := types.Cart{Items: toBuy}
+	if cart.Total() != req.Total {
+		return 0, ErrInvalidPrice
+	}
+
+	orderReq := types.OrderReq{
+		UserId:     req.UserId,
+		Kind:       types.OrderKindCart,
+		FeePercent: req.FeePercent,
+	}
+
+	// every creator gets a single leg however many of their arts are bought
+	legs := []types.CoinLeg{types.WalletLeg(req.UserId, types.Debit, req.Total)}
+	creatorLegs := map[int]int{}
+	fees := 0
+
+	for _, item := range toBuy {
+		price := item.Price()
+		fee := types.PlatformFee(price, req.FeePercent)
+		creatorId := int(item.Art.CreatorID)
+
+		if i, ok := creatorLegs[creatorId]; ok {
+			legs[i].Amount += price - fee
+		} else {
+			creatorLegs[creatorId] = len(legs)
+			legs = append(legs, types.EarningsLeg(creatorId, types.Credit, price-fee))
+		}
+		fees += fee
+
+		artId, licenseId := int(item.ArtID), int(item.LicenseID)
+		err := insertBoughtArtWithDB(ctx, tx, req.UserId, artId, licenseId)
+		if errors.Is(err, ErrArtSoldOut) {
+			return 0, ErrCartItemSoldOut(item.Art.Name)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		orderReq.Items = append(orderReq.Items, types.OrderItemReq{
+			ArtId:       &artId,
+			LicenseId:   &licenseId,
+			CreatorId:   creatorId,
+			Name:        item.Art.Name,
+			LicenseName: item.License.Name,
+			ListPrice:   int(item.License.Price),
+			Price:       price,
+		})
+	}
+	legs = append(legs, types.AccountLeg(types.AccountFees, types.Credit, fees))
+
+	order, err := insertOrderWithDB(ctx, tx, orderReq)
+	if err != nil {
+		return 0, err
+	}
+	orderId := int(*order.ID)
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:        types.CoinTxPurchase,
+		Description: "Order " + *order.ReceiptNumber,
+		Legs:        legs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := setOrderTransactionWithDB(ctx, tx, orderId, transactionId); err != nil {
+		return 0, err
+	}
+
+	stmt := CartItems.DELETE().WHERE(CartItems.UserID.EQ(Int(int64(req.UserId))))
+	if _, err := stmt.ExecContext(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	return orderId, tx.Commit()
+}
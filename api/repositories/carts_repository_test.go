@@ -0,0 +1,75 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_CartsRepo_Checkout(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+	cartsRepo := repositories.NewCartsRepo(testDB, 5*time.Second)
+
+	for artId := 1; artId <= 2; artId++ {
+		asserts.EqualError(t, cartsRepo.AddCartItem(buyerId, artId, 0), nil)
+	}
+
+	// the second art is bought before checking out, so it is skipped
+	err := artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 2, LicenseId: 2, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+	asserts.EqualError(t, cartsRepo.AddCartItem(buyerId, 2, 0), repositories.ErrCartArtOwned)
+	asserts.EqualError(t, cartsRepo.AddCartItem(buyerId, 3, 0), nil)
+
+	cart, err := cartsRepo.FindCart(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "items", len(cart.Items), 3)
+	asserts.Equal(t, "total", cart.Total(), 2*artPrice)
+
+	_, err = cartsRepo.Checkout(types.CheckoutReq{UserId: buyerId, Total: 3 * artPrice})
+	asserts.EqualError(t, err, repositories.ErrInvalidPrice)
+
+	_, err = cartsRepo.Checkout(types.CheckoutReq{UserId: buyerId, Total: 2 * artPrice, FeePercent: 10})
+	asserts.EqualError(t, err, repositories.ErrNotEnoughCoin)
+
+	asserts.EqualError(t, cartsRepo.RemoveCartItem(buyerId, 3), nil)
+	orderId, err := cartsRepo.Checkout(types.CheckoutReq{UserId: buyerId, Total: artPrice, FeePercent: 10})
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin", coin, buyerCoin-2*artPrice)
+	asserts.Equal(t, "bought arts", countBoughtArts(t), 2)
+
+	count, err := cartsRepo.CountCartItems(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "cart items", count, 0)
+
+	// the first order is the purchase of the second art
+	ordersRepo := repositories.NewOrdersRepo(testDB, 5*time.Second)
+	orders, err := ordersRepo.FindManyOrders(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "orders", len(orders), 2)
+	asserts.Equal(t, "order id", int(*orders[0].ID), orderId)
+	asserts.Equal(t, "order kind", orders[0].Kind, types.OrderKindCart)
+	asserts.Equal(t, "order items", len(orders[0].Items), 1)
+	asserts.Equal(t, "order total", int(orders[0].Total), artPrice)
+
+	earnings, err := ledgerRepo.FindCreatorEarnings(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "order of the latest sale", int(*earnings.Sales[0].Order.ID), orderId)
+	asserts.Equal(t, "fee of the latest sale", earnings.Sales[0].Fee, types.PlatformFee(artPrice, 10))
+
+	refundsRepo := repositories.NewRefundsRepo(testDB, 5*time.Second)
+	err = refundsRepo.CreateRefundRequest(buyerId, 1, "test", time.Now().UTC().Add(-time.Hour))
+	asserts.EqualError(t, err, repositories.ErrRefundOrderedArt)
+
+	_, err = cartsRepo.Checkout(types.CheckoutReq{UserId: buyerId, Total: 0})
+	asserts.EqualError(t, err, repositories.ErrCartEmpty)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
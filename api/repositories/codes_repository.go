@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
@@ -10,10 +11,22 @@ import (
 	. "github.com/DeepAung/deep-art/.gen/table"
 	"github.com/DeepAung/deep-art/api/types"
 	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/go-jet/jet/v2/qrm"
 	. "github.com/go-jet/jet/v2/sqlite"
 )
 
-var ErrUniqueCodeName = httperror.New("Code name should be unique", http.StatusBadRequest)
+var (
+	ErrUniqueCodeName = httperror.New("Code name should be unique", http.StatusBadRequest)
+	ErrCodeDisabled   = httperror.New("code is disabled", http.StatusBadRequest)
+	ErrCodeNotStarted = httperror.New("code is not available yet", http.StatusBadRequest)
+	ErrCodeExpired    = httperror.New("code has already expired", http.StatusBadRequest)
+	ErrCodeUsed       = httperror.New("code has already used", http.StatusBadRequest)
+	ErrCodeUsedUp     = httperror.New("code has been fully redeemed", http.StatusBadRequest)
+	ErrCodeNotAllowed = httperror.New("code is not available for your account", http.StatusForbidden)
+
+	ErrCodesUsersNoRowsAffected = ErrNoRowsAffected("codes_users")
+)
 
 type CodesRepo struct {
 	db      *sql.DB
@@ -27,24 +40,37 @@ func NewCodesRepo(db *sql.DB, timeout time.Duration) *CodesRepo {
 	}
 }
 
-func (r *CodesRepo) FindAllCodes() ([]model.Codes, error) {
-	stmt := SELECT(Codes.AllColumns).FROM(Codes)
+func (r *CodesRepo) FindAllCodes() ([]types.Code, error) {
+	stmt := codesStmt(Bool(true))
 
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	var dest []model.Codes
+	dest := []types.Code{}
 	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "code")
 	return dest, err
 }
 
-func (r *CodesRepo) FindOneCodeById(id int) (model.Codes, error) {
-	stmt := SELECT(Codes.AllColumns).FROM(Codes).WHERE(Codes.ID.EQ(Int(int64(id)))).LIMIT(1)
+// FindManyCodes returns the codes of the batch, or the single codes when the
+// batch is empty.
+func (r *CodesRepo) FindManyCodes(batch string) ([]types.Code, error) {
+	stmt := codesStmt(Codes.Batch.EQ(String(batch)))
 
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	var dest model.Codes
+	dest := []types.Code{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "code")
+	return dest, err
+}
+
+func (r *CodesRepo) FindOneCodeById(id int) (types.Code, error) {
+	stmt := codesStmt(Codes.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest types.Code
 	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "code")
 	return dest, err
 }
@@ -60,14 +86,55 @@ func (r *CodesRepo) FindOneCodeByName(name string) (model.Codes, error) {
 	return dest, err
 }
 
-func (r *CodesRepo) HasUsedCode(userId, codeId int) (bool, error) {
-	stmt := SELECT(Int(1)).
+func codesStmt(cond BoolExpression) SelectStatement {
+	uses := SELECT(COUNT(UsersUsedCodes.ID)).
 		FROM(UsersUsedCodes).
-		WHERE(
-			UsersUsedCodes.UserID.EQ(Int(int64(userId))).
-				AND(UsersUsedCodes.CodeID.EQ(Int(int64(codeId)))),
-		).
-		LIMIT(1)
+		WHERE(UsersUsedCodes.CodeID.EQ(Codes.ID))
+	redeemers := SELECT(COUNT(DISTINCT(UsersUsedCodes.UserID))).
+		FROM(UsersUsedCodes).
+		WHERE(UsersUsedCodes.CodeID.EQ(Codes.ID))
+	coins := SELECT(COALESCE(SUM(UsersUsedCodes.Value), Int(0))).
+		FROM(UsersUsedCodes).
+		WHERE(UsersUsedCodes.CodeID.EQ(Codes.ID))
+
+	return SELECT(
+		Codes.AllColumns,
+		IntExp(uses).AS("code.uses"),
+		IntExp(redeemers).AS("code.redeemers"),
+		IntExp(coins).AS("code.coins"),
+		CodesUsers.AllColumns,
+	).FROM(
+		Codes.LEFT_JOIN(CodesUsers, CodesUsers.CodeID.EQ(Codes.ID)),
+	).WHERE(cond).ORDER_BY(Codes.ID.ASC(), CodesUsers.UserID.ASC())
+}
+
+// FindManyCodeBatches returns the redemption statistics of every batch, the
+// latest batch first.
+func (r *CodesRepo) FindManyCodeBatches() ([]types.CodeBatch, error) {
+	stmt := SELECT(
+		Codes.Batch.AS("code_batch.batch"),
+		MAX(Codes.Value).AS("code_batch.value"),
+		MAX(Codes.ExpTime).AS("code_batch.exp_time"),
+		COUNT(DISTINCT(Codes.ID)).AS("code_batch.codes"),
+		COUNT(DISTINCT(UsersUsedCodes.CodeID)).AS("code_batch.redeemed_codes"),
+		COUNT(UsersUsedCodes.ID).AS("code_batch.uses"),
+		COALESCE(SUM(UsersUsedCodes.Value), Int(0)).AS("code_batch.coins"),
+	).
+		FROM(Codes.LEFT_JOIN(UsersUsedCodes, UsersUsedCodes.CodeID.EQ(Codes.ID))).
+		WHERE(Codes.Batch.NOT_EQ(String(""))).
+		GROUP_BY(Codes.Batch).
+		ORDER_BY(MAX(Codes.ID).DESC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.CodeBatch{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "code batch")
+	return dest, err
+}
+
+func (r *CodesRepo) HasCodeBatch(batch string) (bool, error) {
+	stmt := SELECT(Int(1)).FROM(Codes).WHERE(Codes.Batch.EQ(String(batch))).LIMIT(1)
 
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
@@ -76,7 +143,27 @@ func (r *CodesRepo) HasUsedCode(userId, codeId int) (bool, error) {
 	return HandleHasCtx(stmt, ctx, r.db, &tmp)
 }
 
-func (r *CodesRepo) UseCode(userId, codeId int) error {
+// CountUsers returns how many of the users exist.
+func (r *CodesRepo) CountUsers(usersId []int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	ids := utils.Map(usersId, func(id int) Expression { return Int(int64(id)) })
+	stmt := SELECT(COUNT(Users.ID).AS("count")).
+		FROM(Users).
+		WHERE(Users.ID.IN(ids...))
+
+	var dest struct {
+		Count int `alias:"count"`
+	}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "user")
+	return dest.Count, err
+}
+
+// UseCode redeems the code for the user. The code is checked in the same
+// transaction as the redemption, so concurrent redemptions cannot go over its
+// limits.
+func (r *CodesRepo) UseCode(req types.UseCodeReq) error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
@@ -84,60 +171,271 @@ func (r *CodesRepo) UseCode(userId, codeId int) error {
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	stmt := UsersUsedCodes.
-		INSERT(UsersUsedCodes.UserID, UsersUsedCodes.CodeID).
-		VALUES(userId, codeId)
-	if err := HandleExecCtx(stmt, ctx, tx, "users_used_codes"); err != nil {
+	stmt1 := SELECT(Codes.AllColumns).FROM(Codes).WHERE(Codes.ID.EQ(Int(int64(req.CodeId))))
+	var code model.Codes
+	if err := HandleQueryCtx(stmt1, ctx, tx, &code, "code"); err != nil {
 		return err
 	}
 
-	stmt2 := SELECT(Codes.Value).FROM(Codes).WHERE(Codes.ID.EQ(Int(int64(codeId))))
-	var dest2 model.Codes
-	if err := HandleQueryCtx(stmt2, ctx, tx, &dest2, "code"); err != nil {
+	if err := r.checkCodeWithDB(ctx, tx, code, req); err != nil {
 		return err
 	}
 
-	stmt3 := Users.
-		UPDATE(Users.Coin).
-		SET(Users.Coin.ADD(Int(int64(dest2.Value)))).
-		WHERE(Users.ID.EQ(Int(int64(userId))))
-	if err := HandleExecCtx(stmt3, ctx, tx, "users"); err != nil {
+	stmt2 := UsersUsedCodes.
+		INSERT(UsersUsedCodes.UserID, UsersUsedCodes.CodeID, UsersUsedCodes.Value).
+		VALUES(req.UserId, req.CodeId, code.Value)
+	if err := HandleExecCtx(stmt2, ctx, tx, "users_used_codes"); err != nil {
+		return err
+	}
+
+	err = postCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:   types.CoinTxCodeRedemption,
+		CodeId: &req.CodeId,
+		Legs: []types.CoinLeg{
+			types.AccountLeg(types.AccountCodes, types.Debit, int(code.Value)),
+			types.WalletLeg(req.UserId, types.Credit, int(code.Value)),
+		},
+	})
+	if err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
-func (r *CodesRepo) CreateCode(req types.CodeReq) (model.Codes, error) {
-	stmt := Codes.INSERT(Codes.Name, Codes.Value, Codes.ExpTime).
-		VALUES(req.Name, req.Value, req.ExpTime.Time).
-		RETURNING(Codes.AllColumns)
+func (r *CodesRepo) checkCodeWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	code model.Codes,
+	req types.UseCodeReq,
+) error {
+	if code.Disabled {
+		return ErrCodeDisabled
+	}
+	if code.StartsAt != nil && req.Now.Before(*code.StartsAt) {
+		return ErrCodeNotStarted
+	}
+	if code.ExpTime.Before(req.Now) {
+		return ErrCodeExpired
+	}
+
+	switch code.Segment {
+	case types.CodeSegmentNewUsers:
+		stmt := SELECT(Users.CreatedAt).FROM(Users).WHERE(Users.ID.EQ(Int(int64(req.UserId))))
+		var user model.Users
+		if err := HandleQueryCtx(stmt, ctx, db, &user, "user"); err != nil {
+			return err
+		}
+		if user.CreatedAt == nil || user.CreatedAt.Before(req.NewUserSince) {
+			return ErrCodeNotAllowed
+		}
+	case types.CodeSegmentUsers:
+		stmt := SELECT(Int(1)).
+			FROM(CodesUsers).
+			WHERE(
+				CodesUsers.CodeID.EQ(Int(int64(req.CodeId))).
+					AND(CodesUsers.UserID.EQ(Int(int64(req.UserId)))),
+			)
+		var tmp struct{ int }
+		allowed, err := HandleHasCtx(stmt, ctx, db, &tmp)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return ErrCodeNotAllowed
+		}
+	}
 
+	stmt := SELECT(
+		COUNT(UsersUsedCodes.ID).AS("total"),
+		SUM(
+			CASE().
+				WHEN(UsersUsedCodes.UserID.EQ(Int(int64(req.UserId)))).
+				THEN(Int(1)).
+				ELSE(Int(0)),
+		).AS("user"),
+	).
+		FROM(UsersUsedCodes).
+		WHERE(UsersUsedCodes.CodeID.EQ(Int(int64(req.CodeId))))
+
+	var uses struct {
+		Total int  `alias:"total"`
+		User  *int `alias:"user"`
+	}
+	if err := HandleQueryCtx(stmt, ctx, db, &uses, "code"); err != nil {
+		return err
+	}
+
+	if uses.User != nil && *uses.User >= int(code.PerUserLimit) {
+		return ErrCodeUsed
+	}
+	if code.MaxUses != nil && uses.Total >= int(*code.MaxUses) {
+		return ErrCodeUsedUp
+	}
+
+	return nil
+}
+
+func (r *CodesRepo) CreateCode(req types.CodeReq) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	var dest model.Codes
-	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "code")
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	ids, err := r.insertCodesWithDB(ctx, tx, []string{req.Name}, "", req.CodeRules)
+	if err != nil {
+		return 0, err
+	}
+
+	return ids[0], tx.Commit()
+}
+
+// CreateCodeBatch creates every code of the batch with the same rules, or
+// none of them.
+func (r *CodesRepo) CreateCodeBatch(req types.CodeBatchReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := r.insertCodesWithDB(ctx, tx, req.Names, req.Batch, req.CodeRules); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CodesRepo) insertCodesWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	names []string,
+	batch string,
+	rules types.CodeRules,
+) ([]int, error) {
+	stmt := Codes.INSERT(
+		Codes.Name,
+		Codes.Value,
+		Codes.ExpTime,
+		Codes.StartsAt,
+		Codes.MaxUses,
+		Codes.PerUserLimit,
+		Codes.Disabled,
+		Codes.Segment,
+		Codes.Batch,
+	)
+	for _, name := range names {
+		stmt = stmt.VALUES(
+			name,
+			rules.Value,
+			rules.ExpTime,
+			rules.StartsAt,
+			rules.MaxUses,
+			rules.PerUserLimit,
+			rules.Disabled,
+			rules.Segment,
+			batch,
+		)
+	}
+
+	var codes []model.Codes
+	err := HandleQueryCtx(stmt.RETURNING(Codes.ID), ctx, db, &codes, "code")
 	if err != nil && err.Error() == "jet: UNIQUE constraint failed: codes.name" {
-		return model.Codes{}, ErrUniqueCodeName
+		return nil, ErrUniqueCodeName
 	}
-	return dest, err
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(codes))
+	for i, code := range codes {
+		ids[i] = int(*code.ID)
+		if err := r.insertCodesUsersWithDB(ctx, db, ids[i], rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
 }
 
-func (r *CodesRepo) UpdateCode(id int, req types.CodeReq) error {
-	stmt := Codes.UPDATE(Codes.Name, Codes.Value, Codes.ExpTime).
-		SET(req.Name, req.Value, req.ExpTime.Time).
-		WHERE(Codes.ID.EQ(Int(int64(id))))
+func (r *CodesRepo) insertCodesUsersWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	codeId int,
+	rules types.CodeRules,
+) error {
+	if rules.Segment != types.CodeSegmentUsers {
+		return nil
+	}
+
+	stmt := CodesUsers.INSERT(CodesUsers.CodeID, CodesUsers.UserID)
+	for _, userId := range rules.UsersID {
+		stmt = stmt.VALUES(codeId, userId)
+	}
+
+	return HandleExecCtx(stmt, ctx, db, "codes_users")
+}
 
+func (r *CodesRepo) UpdateCode(id int, req types.CodeReq) error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
-	err := HandleExecCtx(stmt, ctx, r.db, "codes")
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := Codes.UPDATE(
+		Codes.Name,
+		Codes.Value,
+		Codes.ExpTime,
+		Codes.StartsAt,
+		Codes.MaxUses,
+		Codes.PerUserLimit,
+		Codes.Disabled,
+		Codes.Segment,
+	).
+		SET(
+			req.Name,
+			req.Value,
+			req.ExpTime,
+			req.StartsAt,
+			req.MaxUses,
+			req.PerUserLimit,
+			req.Disabled,
+			req.Segment,
+		).
+		WHERE(Codes.ID.EQ(Int(int64(id))))
+
+	err = HandleExecCtx(stmt1, ctx, tx, "codes")
 	if err != nil && err.Error() == "UNIQUE constraint failed: codes.name" {
 		return ErrUniqueCodeName
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	stmt2 := CodesUsers.DELETE().WHERE(CodesUsers.CodeID.EQ(Int(int64(id))))
+	if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrCodesUsersNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrCodesUsersNoRowsAffected) {
+		return err
+	}
+
+	if err := r.insertCodesUsersWithDB(ctx, tx, id, req.CodeRules); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *CodesRepo) DeleteCode(id int) error {
@@ -0,0 +1,113 @@
+package repositories_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func setupCodes(t *testing.T) *repositories.CodesRepo {
+	t.Helper()
+
+	repositories.ResetDB(migrateDB)
+	t.Cleanup(func() { repositories.ResetDB(migrateDB) })
+
+	return repositories.NewCodesRepo(testDB, 5*time.Second)
+}
+
+func createCode(t *testing.T, codesRepo *repositories.CodesRepo, name string, rules types.CodeRules) int {
+	t.Helper()
+
+	if rules.ExpTime.IsZero() {
+		rules.ExpTime = time.Now().UTC().Add(time.Hour)
+	}
+	if rules.PerUserLimit == 0 {
+		rules.PerUserLimit = 1
+	}
+	if rules.Segment == "" {
+		rules.Segment = types.CodeSegmentAll
+	}
+
+	id, err := codesRepo.CreateCode(types.CodeReq{Name: name, CodeRules: rules})
+	asserts.EqualError(t, err, nil)
+	return id
+}
+
+func useCode(codesRepo *repositories.CodesRepo, userId, codeId int) error {
+	now := time.Now().UTC()
+	return codesRepo.UseCode(types.UseCodeReq{
+		UserId:       userId,
+		CodeId:       codeId,
+		Now:          now,
+		NewUserSince: now.Add(-time.Hour),
+	})
+}
+
+func Test_CodesRepo_UseCode_Limits(t *testing.T) {
+	codesRepo := setupCodes(t)
+
+	maxUses := 3
+	codeId := createCode(t, codesRepo, "LIMITED", types.CodeRules{
+		Value:        10,
+		MaxUses:      &maxUses,
+		PerUserLimit: 2,
+	})
+
+	errs := make([]error, goroutines)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = useCode(codesRepo, 1+i%2, codeId)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else if err != repositories.ErrCodeUsed && err != repositories.ErrCodeUsedUp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	asserts.Equal(t, "succeeded", succeeded, maxUses)
+
+	code, err := codesRepo.FindOneCodeById(codeId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "uses", code.Uses, maxUses)
+	asserts.Equal(t, "redeemers", code.Redeemers, 2)
+	asserts.Equal(t, "coins", code.Coins, 10*maxUses)
+}
+
+func Test_CodesRepo_UseCode_Rules(t *testing.T) {
+	codesRepo := setupCodes(t)
+	later := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		rules types.CodeRules
+		err   error
+	}{
+		{"disabled", types.CodeRules{Disabled: true}, repositories.ErrCodeDisabled},
+		{"not started", types.CodeRules{StartsAt: &later}, repositories.ErrCodeNotStarted},
+		{"expired", types.CodeRules{ExpTime: time.Now().UTC().Add(-time.Hour)}, repositories.ErrCodeExpired},
+		{"other users", types.CodeRules{Segment: types.CodeSegmentUsers, UsersID: []int{1}}, repositories.ErrCodeNotAllowed},
+		{"allowed users", types.CodeRules{Segment: types.CodeSegmentUsers, UsersID: []int{1, buyerId}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codeId := createCode(t, codesRepo, tt.name, tt.rules)
+			asserts.EqualError(t, useCode(codesRepo, buyerId, codeId), tt.err)
+		})
+	}
+}
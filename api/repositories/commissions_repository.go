@@ -0,0 +1,386 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrCommissionNotFound = ErrNotFound("commission")
+	ErrCommissionToSelf   = httperror.New(
+		"you cannot request a commission from yourself",
+		http.StatusBadRequest,
+	)
+	ErrCommissionStatus = httperror.New(
+		"commission cannot move to this status",
+		http.StatusBadRequest,
+	)
+	ErrCommissionQuoteChanged = httperror.New(
+		"the quote has changed, please review the new quote",
+		http.StatusConflict,
+	)
+)
+
+type CommissionsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewCommissionsRepo(db *sql.DB, timeout time.Duration) *CommissionsRepo {
+	return &CommissionsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *CommissionsRepo) BeginTx() (context.Context, context.CancelFunc, *sql.Tx, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	return ctx, cancel, tx, err
+}
+
+func (r *CommissionsRepo) CreateCommission(req types.CommissionReq) error {
+	if req.BuyerId == req.CreatorId {
+		return ErrCommissionToSelf
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := Commissions.
+		INSERT(
+			Commissions.BuyerID,
+			Commissions.CreatorID,
+			Commissions.Brief,
+			Commissions.Budget,
+		).
+		VALUES(req.BuyerId, req.CreatorId, req.Brief, req.Budget).
+		RETURNING(Commissions.ID)
+	var commission model.Commissions
+	if err := HandleQueryCtx(stmt, ctx, tx, &commission, "commissions"); err != nil {
+		return err
+	}
+
+	err = insertCommissionEventWithDB(
+		ctx,
+		tx,
+		int(*commission.ID),
+		&req.BuyerId,
+		nil,
+		types.CommissionStatusRequested,
+		fmt.Sprintf("Budget of %d Coin", req.Budget),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		req.CreatorId,
+		fmt.Sprintf("You have a new commission request for %d Coin", req.Budget),
+		"/commissions",
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CommissionsRepo) MoveCommission(req types.CommissionMoveReq) error {
+	ctx, cancel, tx, err := r.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.MoveCommissionWithDB(ctx, tx, req); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MoveCommissionWithDB moves the commission to the status when the role of
+// the actor is allowed to. The quote is held in escrow when the buyer accepts
+// it, only if it is still the quote the buyer saw, until the due date of the delivery, and leaves the escrow when the
+// commission is completed or refunded.
+// Every move is logged and told to the other party.
+func (r *CommissionsRepo) MoveCommissionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.CommissionMoveReq,
+) error {
+	commission, err := findOneCommissionWithDB(ctx, db, req.Id)
+	if err != nil {
+		return err
+	}
+	if req.Role != types.CommissionRoleAdmin && commission.RoleOf(req.ActorId) != req.Role {
+		return ErrCommissionNotFound
+	}
+	if !commission.CanMoveTo(req.Status, req.Role) {
+		return ErrCommissionStatus
+	}
+
+	quote := commission.Quote
+	escrowId, settlementId := commission.EscrowID, commission.SettlementID
+	note := req.Note
+	switch req.Status {
+	case types.CommissionStatusQuoted:
+		newQuote := int32(req.Quote)
+		quote = &newQuote
+		if note == "" {
+			note = fmt.Sprintf("Quoted %d Coin", req.Quote)
+		}
+	case types.CommissionStatusAccepted:
+		if quote == nil || int(*quote) != req.Quote {
+			return ErrCommissionQuoteChanged
+		}
+		transactionId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+			Type:        types.CoinTxCommissionEscrow,
+			Description: fmt.Sprintf("Commission #%d from %s", *commission.ID, commission.Creator.Username),
+			Legs: []types.CoinLeg{
+				types.WalletLeg(int(commission.BuyerID), types.Debit, int(*quote)),
+				types.AccountLeg(types.AccountEscrow, types.Credit, int(*quote)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		id := int32(transactionId)
+		escrowId = &id
+
+		stmt := Commissions.UPDATE(Commissions.DueAt).
+			SET(TimestampExp(DATETIME(req.DueAt))).
+			WHERE(Commissions.ID.EQ(Int(int64(req.Id))))
+		if err := HandleExecCtx(stmt, ctx, db, "commission"); err != nil {
+			return err
+		}
+	case types.CommissionStatusCompleted:
+		amount := int(*quote)
+		fee := types.PlatformFee(amount, req.FeePercent)
+		transactionId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+			Type:        types.CoinTxCommissionRelease,
+			Description: fmt.Sprintf("Commission #%d for %s", *commission.ID, commission.Buyer.Username),
+			Legs: []types.CoinLeg{
+				types.AccountLeg(types.AccountEscrow, types.Debit, amount),
+				types.EarningsLeg(int(commission.CreatorID), types.Credit, amount-fee),
+				types.AccountLeg(types.AccountFees, types.Credit, fee),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		id := int32(transactionId)
+		settlementId = &id
+	case types.CommissionStatusRefunded:
+		transactionId, err := insertCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+			Type:        types.CoinTxCommissionRefund,
+			Description: fmt.Sprintf("Refunded commission #%d from %s", *commission.ID, commission.Creator.Username),
+			Legs: []types.CoinLeg{
+				types.AccountLeg(types.AccountEscrow, types.Debit, int(*quote)),
+				types.WalletLeg(int(commission.BuyerID), types.Credit, int(*quote)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		id := int32(transactionId)
+		settlementId = &id
+	}
+
+	stmt1 := Commissions.
+		UPDATE(
+			Commissions.Status,
+			Commissions.Quote,
+			Commissions.EscrowID,
+			Commissions.SettlementID,
+		).
+		SET(req.Status, quote, escrowId, settlementId).
+		WHERE(
+			Commissions.ID.EQ(Int(int64(req.Id))).
+				AND(Commissions.Status.EQ(String(commission.Status))),
+		)
+	if err := HandleExecCtxWithErr(stmt1, ctx, db, ErrCommissionStatus); err != nil {
+		return err
+	}
+
+	for _, file := range req.Files {
+		stmt2 := CommissionFiles.
+			INSERT(CommissionFiles.CommissionID, CommissionFiles.Filename, CommissionFiles.URL).
+			VALUES(req.Id, file.Filename, file.URL)
+		if err := HandleExecCtx(stmt2, ctx, db, "commission_files"); err != nil {
+			return err
+		}
+	}
+
+	err = insertCommissionEventWithDB(
+		ctx,
+		db,
+		req.Id,
+		&req.ActorId,
+		&commission.Status,
+		req.Status,
+		note,
+	)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Commission #%d is %s", *commission.ID, req.Status)
+	notified := []int{}
+	switch req.Role {
+	case types.CommissionRoleBuyer:
+		notified = append(notified, int(commission.CreatorID))
+	case types.CommissionRoleCreator:
+		notified = append(notified, int(commission.BuyerID))
+	case types.CommissionRoleAdmin:
+		notified = append(notified, int(commission.BuyerID), int(commission.CreatorID))
+	}
+	for _, userId := range notified {
+		if err := notifyUserWithDB(ctx, db, userId, message, "/commissions"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertCommissionEventWithDB logs a move of the commission. fromStatus is
+// nil for the request itself.
+func insertCommissionEventWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	commissionId int,
+	actorId *int,
+	fromStatus *string,
+	toStatus string,
+	note string,
+) error {
+	stmt := CommissionEvents.
+		INSERT(
+			CommissionEvents.CommissionID,
+			CommissionEvents.ActorID,
+			CommissionEvents.FromStatus,
+			CommissionEvents.ToStatus,
+			CommissionEvents.Note,
+		).
+		VALUES(commissionId, actorId, fromStatus, toStatus, note)
+	return HandleExecCtx(stmt, ctx, db, "commission_events")
+}
+
+func findOneCommissionWithDB(ctx context.Context, db qrm.DB, id int) (types.Commission, error) {
+	stmt := commissionsQuery(Commissions.ID.EQ(Int(int64(id))))
+
+	var dest types.Commission
+	err := HandleQueryCtxWithErr(stmt, ctx, db, &dest, ErrCommissionNotFound)
+	return dest, err
+}
+
+// FindOneCommission finds the commission of the user with its files and
+// events. Admins can find every commission.
+func (r *CommissionsRepo) FindOneCommission(
+	id, userId int,
+	isAdmin bool,
+) (types.CommissionDetail, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	commission, err := findOneCommissionWithDB(ctx, r.db, id)
+	if err != nil {
+		return types.CommissionDetail{}, err
+	}
+	if !isAdmin && commission.RoleOf(userId) == "" {
+		return types.CommissionDetail{}, ErrCommissionNotFound
+	}
+
+	stmt1 := SELECT(CommissionFiles.AllColumns).
+		FROM(CommissionFiles).
+		WHERE(CommissionFiles.CommissionID.EQ(Int(int64(id)))).
+		ORDER_BY(CommissionFiles.ID.ASC())
+	files := []model.CommissionFiles{}
+	if err := HandleQueryCtx(stmt1, ctx, r.db, &files, "commission file"); err != nil {
+		return types.CommissionDetail{}, err
+	}
+
+	actor := Users.AS("Actor")
+	stmt2 := SELECT(CommissionEvents.AllColumns, actor.ID, actor.Username).
+		FROM(CommissionEvents.LEFT_JOIN(actor, actor.ID.EQ(CommissionEvents.ActorID))).
+		WHERE(CommissionEvents.CommissionID.EQ(Int(int64(id)))).
+		ORDER_BY(CommissionEvents.ID.ASC())
+	events := []types.CommissionEvent{}
+	if err := HandleQueryCtx(stmt2, ctx, r.db, &events, "commission event"); err != nil {
+		return types.CommissionDetail{}, err
+	}
+
+	return types.CommissionDetail{
+		Commission: commission,
+		Files:      files,
+		Events:     events,
+	}, nil
+}
+
+// FindManyCommissions finds the commissions the user requested or was
+// requested for, the newest first.
+func (r *CommissionsRepo) FindManyCommissions(userId int) ([]types.Commission, error) {
+	id := Int(int64(userId))
+	return r.findManyCommissions(Commissions.BuyerID.EQ(id).OR(Commissions.CreatorID.EQ(id)))
+}
+
+// FindAllDisputedCommissions finds the commissions waiting for an admin.
+func (r *CommissionsRepo) FindAllDisputedCommissions() ([]types.Commission, error) {
+	return r.findManyCommissions(Commissions.Status.EQ(String(types.CommissionStatusDisputed)))
+}
+
+func (r *CommissionsRepo) findManyCommissions(cond BoolExpression) ([]types.Commission, error) {
+	stmt := commissionsQuery(cond).
+		ORDER_BY(Commissions.CreatedAt.DESC(), Commissions.ID.DESC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.Commission{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "commission")
+	return dest, err
+}
+
+func commissionsQuery(cond BoolExpression) SelectStatement {
+	buyer := Users.AS("Buyer")
+	creator := Users.AS("Creator")
+
+	return SELECT(
+		Commissions.AllColumns,
+		buyer.ID,
+		buyer.Username,
+		buyer.AvatarURL,
+		creator.ID,
+		creator.Username,
+		creator.AvatarURL,
+	).
+		FROM(
+			Commissions.
+				INNER_JOIN(buyer, buyer.ID.EQ(Commissions.BuyerID)).
+				INNER_JOIN(creator, creator.ID.EQ(Commissions.CreatorID)),
+		).
+		WHERE(cond)
+}
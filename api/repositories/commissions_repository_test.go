@@ -0,0 +1,205 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+const commissionQuote = 60
+
+// setupCommission requests a commission from the creator and quotes it.
+func setupCommission(t *testing.T) (*repositories.ArtsRepo, *repositories.LedgerRepo, *repositories.CommissionsRepo, int) {
+	t.Helper()
+
+	artsRepo, ledgerRepo := setupPurchase(t)
+	commissionsRepo := repositories.NewCommissionsRepo(testDB, 5*time.Second)
+
+	err := commissionsRepo.CreateCommission(types.CommissionReq{
+		BuyerId:   buyerId,
+		CreatorId: creatorId,
+		Brief:     "a cat",
+		Budget:    50,
+	})
+	asserts.EqualError(t, err, nil)
+
+	commissions, err := commissionsRepo.FindManyCommissions(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "commissions", len(commissions), 1)
+	id := int(*commissions[0].ID)
+
+	err = commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: creatorId,
+		Role:    types.CommissionRoleCreator,
+		Status:  types.CommissionStatusQuoted,
+		Quote:   commissionQuote,
+	})
+	asserts.EqualError(t, err, nil)
+
+	return artsRepo, ledgerRepo, commissionsRepo, id
+}
+
+func Test_CommissionsRepo_MoveCommission(t *testing.T) {
+	artsRepo, ledgerRepo, commissionsRepo, id := setupCommission(t)
+
+	move := func(actorId int, role, status string) error {
+		return commissionsRepo.MoveCommission(types.CommissionMoveReq{
+			Id:         id,
+			ActorId:    actorId,
+			Role:       role,
+			Status:     status,
+			Quote:      commissionQuote,
+			FeePercent: 10,
+		})
+	}
+
+	err := move(creatorId, types.CommissionRoleCreator, types.CommissionStatusAccepted)
+	asserts.EqualError(t, err, repositories.ErrCommissionStatus)
+	err = move(creatorId, types.CommissionRoleBuyer, types.CommissionStatusAccepted)
+	asserts.EqualError(t, err, repositories.ErrCommissionNotFound)
+	err = move(buyerId, types.CommissionRoleBuyer, types.CommissionStatusCompleted)
+	asserts.EqualError(t, err, repositories.ErrCommissionStatus)
+
+	asserts.EqualError(t, move(buyerId, types.CommissionRoleBuyer, types.CommissionStatusAccepted), nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin held in escrow", coin, buyerCoin-commissionQuote)
+
+	err = move(buyerId, types.CommissionRoleBuyer, types.CommissionStatusCancelled)
+	asserts.EqualError(t, err, repositories.ErrCommissionStatus)
+
+	err = commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: creatorId,
+		Role:    types.CommissionRoleCreator,
+		Status:  types.CommissionStatusDelivered,
+		Files:   []types.CommissionFileReq{{Filename: "cat.png", URL: "/commissions/1/cat.png"}},
+	})
+	asserts.EqualError(t, err, nil)
+
+	asserts.EqualError(t, move(buyerId, types.CommissionRoleBuyer, types.CommissionStatusDisputed), nil)
+	err = move(buyerId, types.CommissionRoleBuyer, types.CommissionStatusCompleted)
+	asserts.EqualError(t, err, repositories.ErrCommissionStatus)
+	asserts.EqualError(t, move(2, types.CommissionRoleAdmin, types.CommissionStatusCompleted), nil)
+
+	earnings, err := ledgerRepo.FindCreatorEarnings(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "released earnings", earnings.Balance, commissionQuote-types.PlatformFee(commissionQuote, 10))
+
+	commission, err := commissionsRepo.FindOneCommission(id, buyerId, false)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "status", commission.Status, types.CommissionStatusCompleted)
+	asserts.Equal(t, "files", len(commission.Files), 1)
+	asserts.Equal(t, "events", len(commission.Events), 6)
+
+	_, err = commissionsRepo.FindOneCommission(id, 2, false)
+	asserts.EqualError(t, err, repositories.ErrCommissionNotFound)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
+
+func Test_CommissionsRepo_RefundCommission(t *testing.T) {
+	artsRepo, _, commissionsRepo, id := setupCommission(t)
+
+	for _, req := range []types.CommissionMoveReq{
+		{ActorId: buyerId, Role: types.CommissionRoleBuyer, Status: types.CommissionStatusAccepted, Quote: commissionQuote},
+		{ActorId: creatorId, Role: types.CommissionRoleCreator, Status: types.CommissionStatusDelivered},
+		{ActorId: buyerId, Role: types.CommissionRoleBuyer, Status: types.CommissionStatusDisputed},
+		{ActorId: 2, Role: types.CommissionRoleAdmin, Status: types.CommissionStatusRefunded},
+	} {
+		req.Id = id
+		asserts.EqualError(t, commissionsRepo.MoveCommission(req), nil)
+	}
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after the refund", coin, buyerCoin)
+
+	err = commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: 2,
+		Role:    types.CommissionRoleAdmin,
+		Status:  types.CommissionStatusCompleted,
+	})
+	asserts.EqualError(t, err, repositories.ErrCommissionStatus)
+}
+
+func Test_CommissionsRepo_DisputeOverdueCommission(t *testing.T) {
+	artsRepo, _, commissionsRepo, id := setupCommission(t)
+
+	err := commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: buyerId,
+		Role:    types.CommissionRoleBuyer,
+		Status:  types.CommissionStatusAccepted,
+		Quote:   commissionQuote,
+		DueAt:   time.Now().Add(time.Hour),
+	})
+	asserts.EqualError(t, err, nil)
+
+	dispute := types.CommissionMoveReq{
+		Id:      id,
+		ActorId: buyerId,
+		Role:    types.CommissionRoleBuyer,
+		Status:  types.CommissionStatusDisputed,
+	}
+	asserts.EqualError(t, commissionsRepo.MoveCommission(dispute), repositories.ErrCommissionStatus)
+
+	_, err = testDB.Exec(`UPDATE "commissions" SET "due_at" = DATETIME('now', '-1 hour') WHERE "id" = ?`, id)
+	asserts.EqualError(t, err, nil)
+
+	asserts.EqualError(t, commissionsRepo.MoveCommission(dispute), nil)
+	err = commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: 2,
+		Role:    types.CommissionRoleAdmin,
+		Status:  types.CommissionStatusRefunded,
+	})
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after the refund", coin, buyerCoin)
+}
+
+func Test_CommissionsRepo_AcceptChangedQuote(t *testing.T) {
+	artsRepo, _, commissionsRepo, id := setupCommission(t)
+
+	// the creator quotes again after the buyer saw the first quote
+	err := commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: creatorId,
+		Role:    types.CommissionRoleCreator,
+		Status:  types.CommissionStatusQuoted,
+		Quote:   commissionQuote + 20,
+	})
+	asserts.EqualError(t, err, nil)
+
+	accept := types.CommissionMoveReq{
+		Id:      id,
+		ActorId: buyerId,
+		Role:    types.CommissionRoleBuyer,
+		Status:  types.CommissionStatusAccepted,
+		Quote:   commissionQuote,
+		DueAt:   time.Now().Add(time.Hour),
+	}
+	asserts.EqualError(t, commissionsRepo.MoveCommission(accept), repositories.ErrCommissionQuoteChanged)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after the changed quote", coin, buyerCoin)
+
+	accept.Quote = commissionQuote + 20
+	asserts.EqualError(t, commissionsRepo.MoveCommission(accept), nil)
+
+	coin, err = artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin held in escrow", coin, buyerCoin-commissionQuote-20)
+}
@@ -0,0 +1,325 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrCouponNotFound            = ErrNotFound("coupon")
+	ErrCouponsArtsNoRowsAffected = ErrNoRowsAffected("coupons_arts")
+	ErrCouponsTagsNoRowsAffected = ErrNoRowsAffected("coupons_tags")
+	ErrCouponCodeExists          = httperror.New(
+		"you already have a coupon with this code",
+		http.StatusBadRequest,
+	)
+	ErrCouponExpired       = httperror.New("this coupon has expired", http.StatusBadRequest)
+	ErrCouponUsedUp        = httperror.New("this coupon is used up", http.StatusBadRequest)
+	ErrCouponNotApplicable = httperror.New(
+		"this coupon cannot be used for this art",
+		http.StatusBadRequest,
+	)
+)
+
+type CouponsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewCouponsRepo(db *sql.DB, timeout time.Duration) *CouponsRepo {
+	return &CouponsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *CouponsRepo) FindManyCreatedCoupons(creatorId int) ([]types.Coupon, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := couponsStmt(Coupons.CreatorID.EQ(Int(int64(creatorId))))
+
+	dest := []types.Coupon{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "coupon")
+	return dest, err
+}
+
+// FindApplicableCoupon finds the coupon of the art's creator and checks that
+// it can be used for the art at the given time.
+func (r *CouponsRepo) FindApplicableCoupon(
+	artId int,
+	code string,
+	now time.Time,
+) (types.Coupon, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return findApplicableCouponWithDB(ctx, r.db, artId, code, now)
+}
+
+func findApplicableCouponWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	artId int,
+	code string,
+	now time.Time,
+) (types.Coupon, error) {
+	creatorId := SELECT(Arts.CreatorID).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(artId))))
+	stmt := couponsStmt(
+		Coupons.CreatorID.EQ(IntExp(creatorId)).
+			AND(Coupons.Code.EQ(String(types.NormalizeCouponCode(code)))),
+	)
+
+	var coupon types.Coupon
+	if err := HandleQueryCtxWithErr(stmt, ctx, db, &coupon, ErrCouponNotFound); err != nil {
+		return types.Coupon{}, err
+	}
+
+	if coupon.ExpTime != nil && !coupon.ExpTime.After(now) {
+		return types.Coupon{}, ErrCouponExpired
+	}
+	if coupon.IsUsedUp() {
+		return types.Coupon{}, ErrCouponUsedUp
+	}
+
+	switch coupon.Scope {
+	case types.CouponScopeArts:
+		if !coupon.HasArt(int32(artId)) {
+			return types.Coupon{}, ErrCouponNotApplicable
+		}
+	case types.CouponScopeTags:
+		stmt := SELECT(ArtsTags.TagID).
+			FROM(ArtsTags).
+			WHERE(ArtsTags.ArtID.EQ(Int(int64(artId))))
+		var tags []model.ArtsTags
+		if err := HandleQueryCtx(stmt, ctx, db, &tags, "tag"); err != nil {
+			return types.Coupon{}, err
+		}
+
+		tagged := false
+		for _, tag := range tags {
+			tagged = tagged || coupon.HasTag(tag.TagID)
+		}
+		if !tagged {
+			return types.Coupon{}, ErrCouponNotApplicable
+		}
+	}
+
+	return coupon, nil
+}
+
+// redeemCouponWithDB records that the user paid price for the art after the
+// discount of the coupon.
+func redeemCouponWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	couponId, userId, artId, price, discount int,
+) error {
+	stmt := CouponRedemptions.
+		INSERT(
+			CouponRedemptions.CouponID,
+			CouponRedemptions.UserID,
+			CouponRedemptions.ArtID,
+			CouponRedemptions.Price,
+			CouponRedemptions.Discount,
+		).
+		VALUES(couponId, userId, artId, price, discount)
+
+	return HandleExecCtx(stmt, ctx, db, "coupon_redemptions")
+}
+
+func couponsStmt(cond BoolExpression) SelectStatement {
+	uses := SELECT(COUNT(CouponRedemptions.ID)).
+		FROM(CouponRedemptions).
+		WHERE(CouponRedemptions.CouponID.EQ(Coupons.ID))
+	totalDiscount := SELECT(COALESCE(SUM(CouponRedemptions.Discount), Int(0))).
+		FROM(CouponRedemptions).
+		WHERE(CouponRedemptions.CouponID.EQ(Coupons.ID))
+	revenue := SELECT(COALESCE(SUM(CouponRedemptions.Price), Int(0))).
+		FROM(CouponRedemptions).
+		WHERE(CouponRedemptions.CouponID.EQ(Coupons.ID))
+
+	return SELECT(
+		Coupons.AllColumns,
+		IntExp(uses).AS("coupon.uses"),
+		IntExp(totalDiscount).AS("coupon.total_discount"),
+		IntExp(revenue).AS("coupon.revenue"),
+		Arts.ID,
+		Arts.Name,
+		Tags.AllColumns,
+	).FROM(
+		Coupons.
+			LEFT_JOIN(CouponsArts, CouponsArts.CouponID.EQ(Coupons.ID)).
+			LEFT_JOIN(Arts, Arts.ID.EQ(CouponsArts.ArtID)).
+			LEFT_JOIN(CouponsTags, CouponsTags.CouponID.EQ(Coupons.ID)).
+			LEFT_JOIN(Tags, Tags.ID.EQ(CouponsTags.TagID)),
+	).WHERE(cond).ORDER_BY(Coupons.CreatedAt.DESC(), Coupons.ID.DESC(), Arts.ID.ASC(), Tags.ID.ASC())
+}
+
+// CountCreatedArts returns how many of the arts belong to the creator.
+func (r *CouponsRepo) CountCreatedArts(creatorId int, artsId []int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	ids := utils.Map(artsId, func(id int) Expression { return Int(int64(id)) })
+	stmt := SELECT(COUNT(Arts.ID).AS("count")).
+		FROM(Arts).
+		WHERE(
+			Arts.ID.IN(ids...).
+				AND(Arts.CreatorID.EQ(Int(int64(creatorId)))).
+				AND(Arts.DeletedAt.IS_NULL()),
+		)
+
+	var dest struct {
+		Count int `alias:"count"`
+	}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "art")
+	return dest.Count, err
+}
+
+func (r *CouponsRepo) CreateCoupon(req types.CouponReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := Coupons.
+		INSERT(
+			Coupons.CreatorID,
+			Coupons.Code,
+			Coupons.Kind,
+			Coupons.Value,
+			Coupons.Scope,
+			Coupons.ExpTime,
+			Coupons.MaxUses,
+		).
+		VALUES(
+			req.CreatorId,
+			req.Code,
+			req.Kind,
+			req.Value,
+			req.Scope,
+			req.ExpTime,
+			req.MaxUses,
+		).
+		RETURNING(Coupons.ID)
+
+	var coupon model.Coupons
+	if err := HandleQueryCtx(stmt, ctx, tx, &coupon, "coupon"); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: coupons.creator_id, coupons.code") {
+			return ErrCouponCodeExists
+		}
+		return err
+	}
+
+	if err := r.insertCouponScopeWithDB(ctx, tx, int(*coupon.ID), req); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CouponsRepo) UpdateCoupon(req types.CouponReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := Coupons.
+		UPDATE(
+			Coupons.Code,
+			Coupons.Kind,
+			Coupons.Value,
+			Coupons.Scope,
+			Coupons.ExpTime,
+			Coupons.MaxUses,
+		).
+		SET(req.Code, req.Kind, req.Value, req.Scope, req.ExpTime, req.MaxUses).
+		WHERE(
+			Coupons.ID.EQ(Int(int64(req.CouponId))).
+				AND(Coupons.CreatorID.EQ(Int(int64(req.CreatorId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt1, ctx, tx, ErrCouponNotFound); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: coupons.creator_id, coupons.code") {
+			return ErrCouponCodeExists
+		}
+		return err
+	}
+
+	stmt2 := CouponsArts.DELETE().WHERE(CouponsArts.CouponID.EQ(Int(int64(req.CouponId))))
+	if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrCouponsArtsNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrCouponsArtsNoRowsAffected) {
+		return err
+	}
+
+	stmt3 := CouponsTags.DELETE().WHERE(CouponsTags.CouponID.EQ(Int(int64(req.CouponId))))
+	if err := HandleExecCtxWithErr(stmt3, ctx, tx, ErrCouponsTagsNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrCouponsTagsNoRowsAffected) {
+		return err
+	}
+
+	if err := r.insertCouponScopeWithDB(ctx, tx, req.CouponId, req); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertCouponScopeWithDB saves the arts or the tags that the coupon is
+// scoped to.
+func (r *CouponsRepo) insertCouponScopeWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	couponId int,
+	req types.CouponReq,
+) error {
+	switch req.Scope {
+	case types.CouponScopeArts:
+		stmt := CouponsArts.INSERT(CouponsArts.CouponID, CouponsArts.ArtID)
+		for _, artId := range req.ArtsID {
+			stmt = stmt.VALUES(couponId, artId)
+		}
+		return HandleExecCtx(stmt, ctx, db, "coupons_arts")
+	case types.CouponScopeTags:
+		stmt := CouponsTags.INSERT(CouponsTags.CouponID, CouponsTags.TagID)
+		for _, tagId := range req.TagsID {
+			stmt = stmt.VALUES(couponId, tagId)
+		}
+		return HandleExecCtx(stmt, ctx, db, "coupons_tags")
+	}
+
+	return nil
+}
+
+func (r *CouponsRepo) DeleteCoupon(creatorId, couponId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Coupons.DELETE().
+		WHERE(
+			Coupons.ID.EQ(Int(int64(couponId))).
+				AND(Coupons.CreatorID.EQ(Int(int64(creatorId)))),
+		)
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrCouponNotFound)
+}
@@ -0,0 +1,352 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+// number of entries shown in the wallet history and the sales of a creator
+const walletHistoryLimit = 50
+
+var (
+	ErrUnbalancedTransaction = httperror.New(
+		"coin transaction is not balanced",
+		http.StatusInternalServerError,
+	)
+	ErrNotEnoughCoin = httperror.New("not enough coin", http.StatusBadRequest)
+)
+
+// signedLegAmount is the change of the account balance made by a leg.
+var signedLegAmount = IntExp(
+	CASE(CoinTransactionLegs.Direction).
+		WHEN(String(types.Credit)).THEN(CoinTransactionLegs.Amount).
+		ELSE(Int(0).SUB(CoinTransactionLegs.Amount)),
+)
+
+type LedgerRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewLedgerRepo(db *sql.DB, timeout time.Duration) *LedgerRepo {
+	return &LedgerRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// postCoinTransactionWithDB records the transaction with its legs and applies
+// the wallet and earnings legs to the cached "users"."coin" and
+// "users"."earnings" balances. Every coin balance change must go through
+// here. Transactions of zero coins are not recorded.
+func postCoinTransactionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.CoinTransactionReq,
+) error {
+	_, err := insertCoinTransactionWithDB(ctx, db, req)
+	return err
+}
+
+// insertCoinTransactionWithDB posts the transaction like
+// postCoinTransactionWithDB and returns its id, or 0 when nothing is recorded.
+func insertCoinTransactionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	req types.CoinTransactionReq,
+) (int, error) {
+	total := 0
+	for _, leg := range req.Legs {
+		if leg.Amount < 0 || types.IsUserAccount(leg.Account) != (leg.UserId != nil) {
+			return 0, ErrUnbalancedTransaction
+		}
+		total += leg.Amount
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	if len(req.Legs) < 2 || !req.IsBalanced() {
+		return 0, ErrUnbalancedTransaction
+	}
+
+	stmt1 := CoinTransactions.
+		INSERT(
+			CoinTransactions.Type,
+			CoinTransactions.Description,
+			CoinTransactions.CodeID,
+			CoinTransactions.ArtID,
+			CoinTransactions.BundleID,
+		).
+		VALUES(req.Type, req.Description, req.CodeId, req.ArtId, req.BundleId).
+		RETURNING(CoinTransactions.ID)
+	var transaction struct {
+		ID int `alias:"coin_transactions.id"`
+	}
+	if err := HandleQueryCtx(stmt1, ctx, db, &transaction, "coin transaction"); err != nil {
+		return 0, err
+	}
+
+	for _, leg := range req.Legs {
+		if leg.Amount == 0 {
+			continue
+		}
+
+		stmt2 := CoinTransactionLegs.
+			INSERT(
+				CoinTransactionLegs.TransactionID,
+				CoinTransactionLegs.Account,
+				CoinTransactionLegs.UserID,
+				CoinTransactionLegs.Direction,
+				CoinTransactionLegs.Amount,
+			).
+			VALUES(transaction.ID, leg.Account, leg.UserId, leg.Direction, leg.Amount)
+		if err := HandleExecCtx(stmt2, ctx, db, "coin_transaction_legs"); err != nil {
+			return 0, err
+		}
+
+		var balance ColumnInteger
+		switch leg.Account {
+		case types.AccountWallet:
+			balance = Users.Coin
+		case types.AccountEarnings:
+			balance = Users.Earnings
+		default:
+			continue
+		}
+
+		// the balance is guarded here so that it can never go negative, even
+		// when it changed after the caller checked it
+		amount := Int(int64(leg.Amount))
+		cond := Users.ID.EQ(Int(int64(*leg.UserId)))
+		change, noRowsErr := balance.ADD(amount), ErrUserNotFound
+		if leg.Direction == types.Debit {
+			cond = cond.AND(balance.GT_EQ(amount))
+			change, noRowsErr = balance.SUB(amount), ErrNotEnoughCoin
+		}
+		stmt3 := Users.UPDATE(balance).SET(change).WHERE(cond)
+		if err := HandleExecCtxWithErr(stmt3, ctx, db, noRowsErr); err != nil {
+			return 0, err
+		}
+	}
+
+	return transaction.ID, nil
+}
+
+func (r *LedgerRepo) FindManyWalletEntries(userId int) ([]types.WalletEntry, error) {
+	stmt := SELECT(
+		CoinTransactionLegs.AllColumns,
+		CoinTransactions.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Codes.ID,
+		Codes.Name,
+		Bundles.ID,
+		Bundles.Name,
+	).
+		FROM(
+			CoinTransactionLegs.
+				INNER_JOIN(CoinTransactions, CoinTransactions.ID.EQ(CoinTransactionLegs.TransactionID)).
+				LEFT_JOIN(Arts, Arts.ID.EQ(CoinTransactions.ArtID)).
+				LEFT_JOIN(Codes, Codes.ID.EQ(CoinTransactions.CodeID)).
+				LEFT_JOIN(Bundles, Bundles.ID.EQ(CoinTransactions.BundleID)),
+		).
+		WHERE(
+			CoinTransactionLegs.UserID.EQ(Int(int64(userId))).
+				AND(CoinTransactionLegs.Account.EQ(String(types.AccountWallet))),
+		).
+		ORDER_BY(CoinTransactions.CreatedAt.DESC(), CoinTransactions.ID.DESC()).
+		LIMIT(walletHistoryLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.WalletEntry{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "wallet entry")
+	return dest, err
+}
+
+// FindLedgerReport checks the cached balances of the users against their
+// accounts in the ledger, and every transaction for balanced legs.
+func (r *LedgerRepo) FindLedgerReport() (types.LedgerReport, error) {
+	balance := accountBalance(types.AccountWallet)
+	earningsBalance := accountBalance(types.AccountEarnings)
+
+	stmt1 := SELECT(
+		Users.ID,
+		Users.Username,
+		Users.Coin,
+		Users.Earnings,
+		balance.AS("wallet_mismatch.balance"),
+		earningsBalance.AS("wallet_mismatch.earnings_balance"),
+	).
+		FROM(
+			Users.LEFT_JOIN(CoinTransactionLegs, CoinTransactionLegs.UserID.EQ(Users.ID)),
+		).
+		GROUP_BY(Users.ID).
+		HAVING(Users.Coin.NOT_EQ(balance).OR(Users.Earnings.NOT_EQ(earningsBalance))).
+		ORDER_BY(Users.ID.ASC())
+
+	stmt2 := SELECT(CoinTransactionLegs.TransactionID.AS("id")).
+		FROM(CoinTransactionLegs).
+		GROUP_BY(CoinTransactionLegs.TransactionID).
+		HAVING(SUMi(signedLegAmount).NOT_EQ(Int(0))).
+		ORDER_BY(CoinTransactionLegs.TransactionID.ASC())
+
+	stmt3 := SELECT(accountBalance(types.AccountFees).AS("fees")).
+		FROM(CoinTransactionLegs).
+		WHERE(CoinTransactionLegs.Account.EQ(String(types.AccountFees)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	report := types.LedgerReport{
+		MismatchedWallets:      []types.WalletMismatch{},
+		UnbalancedTransactions: []int{},
+	}
+	if err := HandleQueryCtx(stmt1, ctx, r.db, &report.MismatchedWallets, "user"); err != nil {
+		return types.LedgerReport{}, err
+	}
+
+	var transactions []struct {
+		ID int `alias:"id"`
+	}
+	if err := HandleQueryCtx(stmt2, ctx, r.db, &transactions, "coin transaction"); err != nil {
+		return types.LedgerReport{}, err
+	}
+	for _, transaction := range transactions {
+		report.UnbalancedTransactions = append(report.UnbalancedTransactions, transaction.ID)
+	}
+
+	var fees struct {
+		Fees int `alias:"fees"`
+	}
+	if err := HandleQueryCtx(stmt3, ctx, r.db, &fees, "coin transaction"); err != nil {
+		return types.LedgerReport{}, err
+	}
+	report.CollectedFees = fees.Fees
+
+	return report, nil
+}
+
+// accountBalance sums the legs of the account in an aggregate query.
+func accountBalance(account string) IntegerExpression {
+	return IntExp(COALESCE(
+		SUMi(IntExp(
+			CASE().
+				WHEN(CoinTransactionLegs.Account.EQ(String(account))).THEN(signedLegAmount).
+				ELSE(Int(0)),
+		)),
+		Int(0),
+	))
+}
+
+// FindCreatorEarnings finds the earnings balance of the creator with the
+// latest sales. An order pays a single fee for the arts of every creator, so
+// the fee of the creator's part of the order is taken from its items.
+func (r *LedgerRepo) FindCreatorEarnings(creatorId int) (types.CreatorEarnings, error) {
+	feeLegs := CoinTransactionLegs.AS("fee_legs")
+	orderFees := SELECT(OrderItems.OrderID, SUM(OrderItems.Fee).AS("fee")).
+		FROM(OrderItems).
+		WHERE(OrderItems.CreatorID.EQ(Int(int64(creatorId)))).
+		GROUP_BY(OrderItems.OrderID).
+		AsTable("order_fees")
+	orderFee := IntegerColumn("fee").From(orderFees)
+
+	stmt1 := SELECT(Users.Earnings).FROM(Users).WHERE(Users.ID.EQ(Int(int64(creatorId))))
+
+	stmt2 := SELECT(
+		CoinTransactions.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Bundles.ID,
+		Bundles.Name,
+		Orders.ID,
+		Orders.ReceiptNumber,
+		CoinTransactionLegs.Amount.AS("sale.earned"),
+		COALESCE(orderFee, feeLegs.Amount, Int(0)).AS("sale.fee"),
+	).
+		FROM(
+			CoinTransactionLegs.
+				INNER_JOIN(CoinTransactions, CoinTransactions.ID.EQ(CoinTransactionLegs.TransactionID)).
+				LEFT_JOIN(Arts, Arts.ID.EQ(CoinTransactions.ArtID)).
+				LEFT_JOIN(Bundles, Bundles.ID.EQ(CoinTransactions.BundleID)).
+				LEFT_JOIN(Orders, Orders.TransactionID.EQ(CoinTransactions.ID)).
+				LEFT_JOIN(orderFees, OrderItems.OrderID.From(orderFees).EQ(Orders.ID)).
+				LEFT_JOIN(
+					feeLegs,
+					feeLegs.TransactionID.EQ(CoinTransactions.ID).
+						AND(feeLegs.Account.EQ(String(types.AccountFees))),
+				),
+		).
+		WHERE(
+			CoinTransactionLegs.UserID.EQ(Int(int64(creatorId))).
+				AND(CoinTransactionLegs.Account.EQ(String(types.AccountEarnings))).
+				AND(CoinTransactionLegs.Direction.EQ(String(types.Credit))).
+				AND(CoinTransactions.Type.IN(String(types.CoinTxPurchase), String(types.CoinTxGift))),
+		).
+		ORDER_BY(CoinTransactions.CreatedAt.DESC(), CoinTransactions.ID.DESC()).
+		LIMIT(walletHistoryLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var user model.Users
+	if err := HandleQueryCtxWithErr(stmt1, ctx, r.db, &user, ErrUserNotFound); err != nil {
+		return types.CreatorEarnings{}, err
+	}
+
+	sales := []types.Sale{}
+	if err := HandleQueryCtx(stmt2, ctx, r.db, &sales, "sale"); err != nil {
+		return types.CreatorEarnings{}, err
+	}
+
+	return types.CreatorEarnings{Balance: int(user.Earnings), Sales: sales}, nil
+}
+
+// AdjustCoin credits (or debits, when the amount is negative) the wallet of
+// the user against the adjustments account of the platform.
+func (r *LedgerRepo) AdjustCoin(username string, amount int, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := SELECT(Users.ID).FROM(Users).WHERE(Users.Username.EQ(String(username)))
+	var user model.Users
+	if err := HandleQueryCtxWithErr(stmt, ctx, tx, &user, ErrUserNotFound); err != nil {
+		return err
+	}
+
+	walletDirection, accountDirection := types.Credit, types.Debit
+	if amount < 0 {
+		walletDirection, accountDirection = types.Debit, types.Credit
+		amount = -amount
+	}
+
+	err = postCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:        types.CoinTxAdminAdjustment,
+		Description: reason,
+		Legs: []types.CoinLeg{
+			types.WalletLeg(int(*user.ID), walletDirection, amount),
+			types.AccountLeg(types.AccountAdjustments, accountDirection, amount),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
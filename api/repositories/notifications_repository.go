@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+type NotificationsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewNotificationsRepo(db *sql.DB, timeout time.Duration) *NotificationsRepo {
+	return &NotificationsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *NotificationsRepo) FindManyNotifications(userId int) ([]model.Notifications, error) {
+	stmt := SELECT(Notifications.AllColumns).
+		FROM(Notifications).
+		WHERE(Notifications.UserID.EQ(Int(int64(userId)))).
+		ORDER_BY(Notifications.CreatedAt.DESC(), Notifications.ID.DESC()).
+		LIMIT(50)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest []model.Notifications
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "notification")
+	return dest, err
+}
+
+func (r *NotificationsRepo) CountUnreadNotifications(userId int) (int, error) {
+	stmt := SELECT(COUNT(Notifications.ID).AS("count")).
+		FROM(Notifications).
+		WHERE(
+			Notifications.UserID.EQ(Int(int64(userId))).
+				AND(Notifications.IsRead.IS_FALSE()),
+		)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest struct {
+		Count int `alias:"count"`
+	}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "notification")
+	return dest.Count, err
+}
+
+func (r *NotificationsRepo) ReadAllNotifications(userId int) error {
+	stmt := Notifications.UPDATE(Notifications.IsRead).
+		SET(Bool(true)).
+		WHERE(
+			Notifications.UserID.EQ(Int(int64(userId))).
+				AND(Notifications.IsRead.IS_FALSE()),
+		)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	err := HandleExecCtxWithErr(stmt, ctx, r.db, ErrNotificationsNoRowsAffected)
+	if err != nil && !errors.Is(err, ErrNotificationsNoRowsAffected) {
+		return err
+	}
+	return nil
+}
+
+// notifyUserWithDB sends the notification to a single user.
+func notifyUserWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId int,
+	message string,
+	link string,
+) error {
+	stmt := Notifications.
+		INSERT(Notifications.UserID, Notifications.Message, Notifications.Link).
+		VALUES(userId, message, link)
+	return HandleExecCtx(stmt, ctx, db, "notifications")
+}
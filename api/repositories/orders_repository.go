@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var ErrOrderNotFound = ErrNotFound("order")
+
+// number of sales shown to the creator
+const salesLimit = 100
+
+type OrdersRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewOrdersRepo(db *sql.DB, timeout time.Duration) *OrdersRepo {
+	return &OrdersRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// insertOrderWithDB records the purchase with its items and gives it a
+// receipt number. The fee of every item is taken from its price, the same
+// way as types.SaleLegs.
+func insertOrderWithDB(ctx context.Context, db qrm.DB, req types.OrderReq) (model.Orders, error) {
+	stmt1 := Orders.
+		INSERT(Orders.UserID, Orders.Total, Orders.Kind, Orders.Description).
+		VALUES(req.UserId, req.Total(), req.Kind, req.Description).
+		RETURNING(Orders.AllColumns)
+	var order model.Orders
+	if err := HandleQueryCtx(stmt1, ctx, db, &order, "order"); err != nil {
+		return model.Orders{}, err
+	}
+
+	createdAt := time.Now().UTC()
+	if order.CreatedAt != nil {
+		createdAt = *order.CreatedAt
+	}
+	receiptNumber := types.ReceiptNumber(int(*order.ID), createdAt)
+	order.ReceiptNumber = &receiptNumber
+
+	stmt2 := Orders.UPDATE(Orders.ReceiptNumber).
+		SET(String(receiptNumber)).
+		WHERE(Orders.ID.EQ(Int(int64(*order.ID))))
+	if err := HandleExecCtx(stmt2, ctx, db, "orders"); err != nil {
+		return model.Orders{}, err
+	}
+
+	for _, item := range req.Items {
+		var couponCode *string
+		if item.CouponCode != "" {
+			couponCode = &item.CouponCode
+		}
+
+		stmt3 := OrderItems.
+			INSERT(
+				OrderItems.OrderID,
+				OrderItems.ArtID,
+				OrderItems.LicenseID,
+				OrderItems.BundleID,
+				OrderItems.CreatorID,
+				OrderItems.ArtName,
+				OrderItems.LicenseName,
+				OrderItems.ListPrice,
+				OrderItems.Price,
+				OrderItems.Fee,
+				OrderItems.CouponCode,
+			).
+			VALUES(
+				*order.ID,
+				item.ArtId,
+				item.LicenseId,
+				item.BundleId,
+				item.CreatorId,
+				item.Name,
+				item.LicenseName,
+				item.ListPrice,
+				item.Price,
+				types.PlatformFee(item.Price, req.FeePercent),
+				couponCode,
+			)
+		if err := HandleExecCtx(stmt3, ctx, db, "order_items"); err != nil {
+			return model.Orders{}, err
+		}
+	}
+
+	return order, nil
+}
+
+// setOrderTransactionWithDB links the order to its payment. Free orders have
+// no payment, their transactionId is 0.
+func setOrderTransactionWithDB(ctx context.Context, db qrm.DB, orderId, transactionId int) error {
+	if transactionId == 0 {
+		return nil
+	}
+
+	stmt := Orders.UPDATE(Orders.TransactionID).
+		SET(Int(int64(transactionId))).
+		WHERE(Orders.ID.EQ(Int(int64(orderId))))
+	return HandleExecCtx(stmt, ctx, db, "orders")
+}
+
+// FindManyOrders finds the orders of the user, newest first.
+func (r *OrdersRepo) FindManyOrders(userId int) ([]types.Order, error) {
+	stmt := ordersQuery(Orders.UserID.EQ(Int(int64(userId))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	orders := []types.Order{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &orders, "order")
+	return orders, err
+}
+
+func (r *OrdersRepo) FindOneOrder(id int) (types.Order, error) {
+	stmt := ordersQuery(Orders.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var order types.Order
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &order, ErrOrderNotFound)
+	return order, err
+}
+
+func ordersQuery(cond BoolExpression) SelectStatement {
+	buyer := Users.AS("Buyer")
+
+	return SELECT(
+		Orders.AllColumns,
+		OrderItems.AllColumns,
+		buyer.ID,
+		buyer.Username,
+		buyer.Email,
+		CoinTransactions.ID,
+		CoinTransactions.RefundID,
+	).
+		FROM(
+			Orders.
+				INNER_JOIN(OrderItems, OrderItems.OrderID.EQ(Orders.ID)).
+				INNER_JOIN(buyer, buyer.ID.EQ(Orders.UserID)).
+				LEFT_JOIN(CoinTransactions, CoinTransactions.ID.EQ(Orders.TransactionID)),
+		).
+		WHERE(cond).
+		ORDER_BY(Orders.ID.DESC(), OrderItems.ID.ASC())
+}
+
+// FindManySales finds the latest order items sold by the creator.
+func (r *OrdersRepo) FindManySales(creatorId int) ([]types.OrderSale, error) {
+	buyer := Users.AS("Buyer")
+
+	stmt := SELECT(
+		OrderItems.AllColumns,
+		Orders.AllColumns,
+		buyer.ID,
+		buyer.Username,
+	).
+		FROM(
+			OrderItems.
+				INNER_JOIN(Orders, Orders.ID.EQ(OrderItems.OrderID)).
+				INNER_JOIN(buyer, buyer.ID.EQ(Orders.UserID)),
+		).
+		WHERE(OrderItems.CreatorID.EQ(Int(int64(creatorId)))).
+		ORDER_BY(Orders.ID.DESC(), OrderItems.ID.ASC()).
+		LIMIT(salesLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	sales := []types.OrderSale{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &sales, "sale")
+	return sales, err
+}
@@ -0,0 +1,53 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_OrdersRepo_Purchases(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	ordersRepo := repositories.NewOrdersRepo(testDB, 5*time.Second)
+
+	_, err := testDB.Exec(
+		`INSERT INTO "art_licenses" ("id", "art_id", "name", "price") VALUES (4, 1, 'Commercial', ?)`,
+		artPrice+20,
+	)
+	asserts.EqualError(t, err, nil)
+
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice, FeePercent: 10})
+	asserts.EqualError(t, err, nil)
+	err = artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 4, Price: 20, FeePercent: 10})
+	asserts.EqualError(t, err, nil)
+
+	orders, err := ordersRepo.FindManyOrders(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "orders", len(orders), 2)
+
+	upgrade, purchase := orders[0], orders[1]
+	asserts.Equal(t, "purchase kind", purchase.Kind, types.OrderKindArt)
+	asserts.Equal(t, "upgrade kind", upgrade.Kind, types.OrderKindUpgrade)
+	asserts.Equal(t, "upgrade license", upgrade.Items[0].LicenseName, "Commercial")
+	asserts.Equal(t, "upgrade total", int(upgrade.Total), 20)
+	asserts.Equal(t, "purchase fee", int(purchase.Items[0].Fee), types.PlatformFee(artPrice, 10))
+	asserts.Equal(t, "purchase paid", purchase.Transaction != nil, true)
+	asserts.Equal(
+		t,
+		"receipt number",
+		purchase.Receipt(),
+		types.ReceiptNumber(int(*purchase.ID), *purchase.CreatedAt),
+	)
+
+	order, err := ordersRepo.FindOneOrder(int(*purchase.ID))
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "buyer", order.Buyer.Username, buyerName)
+
+	sales, err := ordersRepo.FindManySales(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "sales", len(sales), 2)
+	asserts.Equal(t, "earned", sales[1].Earned(), artPrice-types.PlatformFee(artPrice, 10))
+}
@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/payment"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+// number of payments shown on the admin page
+const paymentsLimit = 50
+
+var (
+	ErrCoinPackageNotFound = ErrNotFound("coin package")
+	ErrPaymentNotFound     = ErrNotFound("payment")
+)
+
+type PaymentsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewPaymentsRepo(db *sql.DB, timeout time.Duration) *PaymentsRepo {
+	return &PaymentsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *PaymentsRepo) FindManyCoinPackages(onlyActive bool) ([]model.CoinPackages, error) {
+	cond := Bool(true)
+	if onlyActive {
+		cond = CoinPackages.IsActive.IS_TRUE()
+	}
+
+	stmt := SELECT(CoinPackages.AllColumns).
+		FROM(CoinPackages).
+		WHERE(cond).
+		ORDER_BY(CoinPackages.Price.ASC(), CoinPackages.ID.ASC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []model.CoinPackages{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "coin package")
+	return dest, err
+}
+
+func (r *PaymentsRepo) FindOneCoinPackage(id int) (model.CoinPackages, error) {
+	stmt := SELECT(CoinPackages.AllColumns).
+		FROM(CoinPackages).
+		WHERE(CoinPackages.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest model.CoinPackages
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrCoinPackageNotFound)
+	return dest, err
+}
+
+func (r *PaymentsRepo) CreateCoinPackage(dto types.CoinPackageDTO) error {
+	stmt := CoinPackages.
+		INSERT(CoinPackages.Name, CoinPackages.Coins, CoinPackages.Price, CoinPackages.IsActive).
+		VALUES(dto.Name, dto.Coins, dto.Price, dto.IsActive)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtx(stmt, ctx, r.db, "coin_packages")
+}
+
+func (r *PaymentsRepo) UpdateCoinPackage(id int, dto types.CoinPackageDTO) error {
+	stmt := CoinPackages.
+		UPDATE(CoinPackages.Name, CoinPackages.Coins, CoinPackages.Price, CoinPackages.IsActive).
+		SET(dto.Name, dto.Coins, dto.Price, dto.IsActive).
+		WHERE(CoinPackages.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrCoinPackageNotFound)
+}
+
+func (r *PaymentsRepo) DeleteCoinPackage(id int) error {
+	stmt := CoinPackages.DELETE().WHERE(CoinPackages.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrCoinPackageNotFound)
+}
+
+// FindManyPayments returns the latest payments with the users who made them.
+func (r *PaymentsRepo) FindManyPayments() ([]types.Payment, error) {
+	stmt := SELECT(Payments.AllColumns, Users.ID, Users.Username).
+		FROM(Payments.INNER_JOIN(Users, Users.ID.EQ(Payments.UserID))).
+		ORDER_BY(Payments.CreatedAt.DESC(), Payments.ID.DESC()).
+		LIMIT(paymentsLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.Payment{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "payment")
+	return dest, err
+}
+
+// FindManyPendingPayments returns the payments that have been pending since
+// before the given time.
+func (r *PaymentsRepo) FindManyPendingPayments(before time.Time) ([]model.Payments, error) {
+	stmt := SELECT(Payments.AllColumns).
+		FROM(Payments).
+		WHERE(
+			Payments.Status.EQ(String(payment.StatusPending)).
+				AND(DATETIME(Payments.CreatedAt).LT(DATETIME(before))),
+		).
+		ORDER_BY(Payments.ID.ASC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []model.Payments{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "payment")
+	return dest, err
+}
+
+func (r *PaymentsRepo) FindOnePayment(id int) (model.Payments, error) {
+	stmt := SELECT(Payments.AllColumns).FROM(Payments).WHERE(Payments.ID.EQ(Int(int64(id))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest model.Payments
+	err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrPaymentNotFound)
+	return dest, err
+}
+
+// CreatePayment records a pending payment before the checkout is created at
+// the provider, so that every checkout has a payment to settle.
+func (r *PaymentsRepo) CreatePayment(req types.CreatePaymentReq) (int, error) {
+	stmt := Payments.
+		INSERT(
+			Payments.UserID,
+			Payments.PackageID,
+			Payments.Coins,
+			Payments.Price,
+			Payments.Currency,
+			Payments.Provider,
+		).
+		VALUES(req.UserId, req.PackageId, req.Coins, req.Price, req.Currency, req.Provider).
+		RETURNING(Payments.ID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var dest model.Payments
+	if err := HandleQueryCtx(stmt, ctx, r.db, &dest, "payment"); err != nil {
+		return 0, err
+	}
+	return int(*dest.ID), nil
+}
+
+func (r *PaymentsRepo) SetPaymentProviderId(paymentId int, providerId string) error {
+	stmt := Payments.
+		UPDATE(Payments.ProviderID).
+		SET(String(providerId)).
+		WHERE(Payments.ID.EQ(Int(int64(paymentId))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrPaymentNotFound)
+}
+
+// FailPayment fails the payment that is still pending, like a payment whose
+// checkout could not be created.
+func (r *PaymentsRepo) FailPayment(paymentId int) error {
+	stmt := Payments.
+		UPDATE(Payments.Status).
+		SET(String(payment.StatusFailed)).
+		WHERE(
+			Payments.ID.EQ(Int(int64(paymentId))).
+				AND(Payments.Status.EQ(String(payment.StatusPending))),
+		)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return HandleExecCtx(stmt, ctx, r.db, "payments")
+}
+
+// SettlePayment applies the status reported by the provider. The coins are
+// credited only once, however many times the status is reported, and a
+// succeeded payment never goes back to failed.
+func (r *PaymentsRepo) SettlePayment(event payment.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(Payments.AllColumns).
+		FROM(Payments).
+		WHERE(Payments.ProviderID.EQ(String(event.ProviderId)))
+	var dest model.Payments
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &dest, ErrPaymentNotFound); err != nil {
+		return err
+	}
+
+	if dest.Status == payment.StatusSucceeded || dest.Status == event.Status {
+		return nil
+	}
+
+	switch event.Status {
+	case payment.StatusFailed:
+		stmt2 := Payments.
+			UPDATE(Payments.Status).
+			SET(String(payment.StatusFailed)).
+			WHERE(Payments.ID.EQ(Int(int64(*dest.ID))))
+		if err := HandleExecCtx(stmt2, ctx, tx, "payments"); err != nil {
+			return err
+		}
+	case payment.StatusSucceeded:
+		userId, coins := int(dest.UserID), int(dest.Coins)
+		transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+			Type:        types.CoinTxTopUp,
+			Description: fmt.Sprintf("Paid %s", types.FormatPrice(int(dest.Price), dest.Currency)),
+			Legs: []types.CoinLeg{
+				types.AccountLeg(types.AccountTopUps, types.Debit, coins),
+				types.WalletLeg(userId, types.Credit, coins),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		stmt2 := Payments.
+			UPDATE(Payments.Status, Payments.TransactionID).
+			SET(String(payment.StatusSucceeded), Int(int64(transactionId))).
+			WHERE(Payments.ID.EQ(Int(int64(*dest.ID))))
+		if err := HandleExecCtx(stmt2, ctx, tx, "payments"); err != nil {
+			return err
+		}
+
+		err = notifyUserWithDB(ctx, tx, userId, fmt.Sprintf("You received %d coins from your payment", coins), "/me")
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+	"github.com/DeepAung/deep-art/pkg/payment"
+)
+
+func createPayment(t *testing.T, paymentsRepo *repositories.PaymentsRepo, providerId string, coins int) int {
+	t.Helper()
+
+	id, err := paymentsRepo.CreatePayment(types.CreatePaymentReq{
+		UserId:    buyerId,
+		PackageId: 1,
+		Coins:     coins,
+		Price:     499,
+		Currency:  "USD",
+		Provider:  "fake",
+	})
+	asserts.EqualError(t, err, nil)
+	asserts.EqualError(t, paymentsRepo.SetPaymentProviderId(id, providerId), nil)
+	return id
+}
+
+func Test_PaymentsRepo_SettlePayment(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	paymentsRepo := repositories.NewPaymentsRepo(testDB, 5*time.Second)
+
+	const coins = 50
+	asserts.EqualError(t, paymentsRepo.CreateCoinPackage(types.CoinPackageDTO{
+		Name:     "Small",
+		Coins:    coins,
+		Price:    499,
+		IsActive: true,
+	}), nil)
+
+	t.Run("duplicate webhooks credit once", func(t *testing.T) {
+		id := createPayment(t, paymentsRepo, "paid", coins)
+		event := payment.Event{ProviderId: "paid", Status: payment.StatusSucceeded}
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		errs := make([]error, goroutines)
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				errs[i] = paymentsRepo.SettlePayment(event)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		for _, err := range errs {
+			asserts.EqualError(t, err, nil)
+		}
+
+		failed := payment.Event{ProviderId: "paid", Status: payment.StatusFailed}
+		asserts.EqualError(t, paymentsRepo.SettlePayment(failed), nil)
+
+		p, err := paymentsRepo.FindOnePayment(id)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "status", p.Status, payment.StatusSucceeded)
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "coin", coin, buyerCoin+coins)
+	})
+
+	t.Run("failed payment credits nothing", func(t *testing.T) {
+		id := createPayment(t, paymentsRepo, "failed", coins)
+		event := payment.Event{ProviderId: "failed", Status: payment.StatusFailed}
+		asserts.EqualError(t, paymentsRepo.SettlePayment(event), nil)
+
+		p, err := paymentsRepo.FindOnePayment(id)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "status", p.Status, payment.StatusFailed)
+
+		coin, err := artsRepo.FindUserCoin(buyerId)
+		asserts.EqualError(t, err, nil)
+		asserts.Equal(t, "coin", coin, buyerCoin+coins)
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		event := payment.Event{ProviderId: "unknown", Status: payment.StatusSucceeded}
+		asserts.EqualError(t, paymentsRepo.SettlePayment(event), repositories.ErrPaymentNotFound)
+	})
+}
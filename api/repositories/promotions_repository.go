@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrPromotionNotFound            = ErrNotFound("promotion")
+	ErrPromotionsArtsNoRowsAffected = ErrNoRowsAffected("promotions_arts")
+)
+
+type PromotionsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewPromotionsRepo(db *sql.DB, timeout time.Duration) *PromotionsRepo {
+	return &PromotionsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// promotionPercentExp is the percentage off of the best promotion running for
+// the art of the outer query, or 0 when the art is not on sale. Buyers choose
+// the price of pay-what-you-want arts, so they are never on sale.
+func promotionPercentExp() IntegerExpression {
+	pickedArt := SELECT(Int(1)).
+		FROM(PromotionsArts).
+		WHERE(
+			PromotionsArts.PromotionID.EQ(Promotions.ID).
+				AND(PromotionsArts.ArtID.EQ(Arts.ID)),
+		)
+
+	return IntExp(
+		SELECT(COALESCE(MAX(Promotions.Percent), Int(0))).
+			FROM(Promotions).
+			WHERE(
+				Arts.PayWhatYouWant.IS_FALSE().
+					AND(Promotions.CreatorID.EQ(Arts.CreatorID)).
+					AND(DATETIME(Promotions.StartsAt).LT_EQ(CURRENT_TIMESTAMP())).
+					AND(DATETIME(Promotions.EndsAt).GT(CURRENT_TIMESTAMP())).
+					AND(
+						Promotions.Scope.EQ(String(types.PromotionScopeAll)).
+							OR(EXISTS(pickedArt)),
+					),
+			),
+	)
+}
+
+// effectivePriceExp is the price of the art of the outer query after its
+// promotion. It must round like types.PromotionPrice. Pay-what-you-want arts
+// are never promoted, so it is their minimum.
+func effectivePriceExp() IntegerExpression {
+	return Arts.Price.SUB(Arts.Price.MUL(promotionPercentExp()).DIV(Int(100)))
+}
+
+func (r *PromotionsRepo) FindManyCreatedPromotions(creatorId int) ([]types.Promotion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(
+		Promotions.AllColumns,
+		Arts.ID,
+		Arts.Name,
+	).FROM(
+		Promotions.
+			LEFT_JOIN(PromotionsArts, PromotionsArts.PromotionID.EQ(Promotions.ID)).
+			LEFT_JOIN(Arts, Arts.ID.EQ(PromotionsArts.ArtID)),
+	).
+		WHERE(Promotions.CreatorID.EQ(Int(int64(creatorId)))).
+		ORDER_BY(Promotions.StartsAt.DESC(), Promotions.ID.DESC(), Arts.ID.ASC())
+
+	dest := []types.Promotion{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "promotion")
+	return dest, err
+}
+
+func (r *PromotionsRepo) CreatePromotion(req types.PromotionReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := Promotions.
+		INSERT(
+			Promotions.CreatorID,
+			Promotions.Name,
+			Promotions.Percent,
+			Promotions.Scope,
+			Promotions.StartsAt,
+			Promotions.EndsAt,
+		).
+		VALUES(
+			req.CreatorId,
+			req.Name,
+			req.Percent,
+			req.Scope,
+			TimestampExp(DATETIME(req.StartsAt)),
+			TimestampExp(DATETIME(req.EndsAt)),
+		).
+		RETURNING(Promotions.ID)
+
+	var promotion model.Promotions
+	if err := HandleQueryCtx(stmt, ctx, tx, &promotion, "promotion"); err != nil {
+		return err
+	}
+
+	if err := r.insertPromotionsArtsWithDB(ctx, tx, int(*promotion.ID), req); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PromotionsRepo) UpdatePromotion(req types.PromotionReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := Promotions.
+		UPDATE(
+			Promotions.Name,
+			Promotions.Percent,
+			Promotions.Scope,
+			Promotions.StartsAt,
+			Promotions.EndsAt,
+		).
+		SET(
+			req.Name,
+			req.Percent,
+			req.Scope,
+			TimestampExp(DATETIME(req.StartsAt)),
+			TimestampExp(DATETIME(req.EndsAt)),
+		).
+		WHERE(
+			Promotions.ID.EQ(Int(int64(req.PromotionId))).
+				AND(Promotions.CreatorID.EQ(Int(int64(req.CreatorId)))),
+		)
+	if err := HandleExecCtxWithErr(stmt1, ctx, tx, ErrPromotionNotFound); err != nil {
+		return err
+	}
+
+	stmt2 := PromotionsArts.DELETE().
+		WHERE(PromotionsArts.PromotionID.EQ(Int(int64(req.PromotionId))))
+	if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrPromotionsArtsNoRowsAffected); err != nil &&
+		!errors.Is(err, ErrPromotionsArtsNoRowsAffected) {
+		return err
+	}
+
+	if err := r.insertPromotionsArtsWithDB(ctx, tx, req.PromotionId, req); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PromotionsRepo) insertPromotionsArtsWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	promotionId int,
+	req types.PromotionReq,
+) error {
+	if req.Scope != types.PromotionScopeArts {
+		return nil
+	}
+
+	stmt := PromotionsArts.INSERT(PromotionsArts.PromotionID, PromotionsArts.ArtID)
+	for _, artId := range req.ArtsID {
+		stmt = stmt.VALUES(promotionId, artId)
+	}
+
+	return HandleExecCtx(stmt, ctx, db, "promotions_arts")
+}
+
+func (r *PromotionsRepo) DeletePromotion(creatorId, promotionId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Promotions.DELETE().
+		WHERE(
+			Promotions.ID.EQ(Int(int64(promotionId))).
+				AND(Promotions.CreatorID.EQ(Int(int64(creatorId)))),
+		)
+
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrPromotionNotFound)
+}
@@ -0,0 +1,455 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrRefundRequestNotFound  = ErrNotFound("refund request")
+	ErrRefundAlreadyRequested = httperror.New(
+		"a refund of this art is already requested",
+		http.StatusBadRequest,
+	)
+	ErrRefundAlreadyDecided = httperror.New(
+		"refund request is already decided",
+		http.StatusBadRequest,
+	)
+	ErrRefundArtNotBought  = httperror.New("user did not buy this art", http.StatusBadRequest)
+	ErrRefundArtDownloaded = httperror.New(
+		"downloaded arts cannot be refunded",
+		http.StatusBadRequest,
+	)
+	ErrRefundBundledArt = httperror.New(
+		"arts bought in a bundle cannot be refunded",
+		http.StatusBadRequest,
+	)
+	ErrRefundOrderedArt = httperror.New(
+		"arts bought in a cart order cannot be refunded",
+		http.StatusBadRequest,
+	)
+	ErrRefundGiftedArt = httperror.New(
+		"gifted arts cannot be refunded",
+		http.StatusBadRequest,
+	)
+	ErrRefundNotPaid = httperror.New(
+		"there is no payment of this art to refund",
+		http.StatusBadRequest,
+	)
+	ErrRefundWindowPassed = httperror.New(
+		"the refund window of this purchase has passed",
+		http.StatusBadRequest,
+	)
+)
+
+type RefundsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewRefundsRepo(db *sql.DB, timeout time.Duration) *RefundsRepo {
+	return &RefundsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// purchaseLeg is a leg of a purchase that is not refunded yet.
+type purchaseLeg struct {
+	model.CoinTransactionLegs
+
+	Transaction model.CoinTransactions
+}
+
+// CreateRefundRequest asks the creator to refund the purchase of the art.
+// Only purchases made after since and never downloaded can be refunded.
+func (r *RefundsRepo) CreateRefundRequest(
+	userId int,
+	artId int,
+	reason string,
+	since time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(RefundRequests.ID).
+		FROM(RefundRequests).
+		WHERE(
+			RefundRequests.UserID.EQ(Int(int64(userId))).
+				AND(RefundRequests.ArtID.EQ(Int(int64(artId)))).
+				AND(RefundRequests.Status.EQ(String(types.RefundStatusPending))),
+		)
+	var pending model.RefundRequests
+	found, err := HandleHasCtx(stmt1, ctx, tx, &pending)
+	if err != nil {
+		return err
+	}
+	if found {
+		return ErrRefundAlreadyRequested
+	}
+
+	legs, err := r.findRefundableLegsWithDB(ctx, tx, userId, artId)
+	if err != nil {
+		return err
+	}
+	for _, leg := range legs {
+		if leg.Transaction.CreatedAt == nil || leg.Transaction.CreatedAt.Before(since) {
+			return ErrRefundWindowPassed
+		}
+	}
+
+	paid := 0
+	for _, leg := range legs {
+		if leg.Account == types.AccountWallet {
+			paid += int(leg.Amount)
+		}
+	}
+
+	stmt2 := RefundRequests.
+		INSERT(
+			RefundRequests.UserID,
+			RefundRequests.ArtID,
+			RefundRequests.Reason,
+			RefundRequests.Amount,
+		).
+		VALUES(userId, artId, reason, paid)
+	if err := HandleExecCtx(stmt2, ctx, tx, "refund_requests"); err != nil {
+		return err
+	}
+
+	stmt3 := SELECT(Arts.CreatorID, Arts.Name).FROM(Arts).WHERE(Arts.ID.EQ(Int(int64(artId))))
+	var art model.Arts
+	if err := HandleQueryCtx(stmt3, ctx, tx, &art, "art"); err != nil {
+		return err
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		int(art.CreatorID),
+		fmt.Sprintf("A refund of %q is requested", art.Name),
+		"/creator",
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// findRefundableLegsWithDB checks that the user can still refund the art and
+// finds the legs of the purchases to reverse: the first purchase and the
+// license upgrades after it.
+func (r *RefundsRepo) findRefundableLegsWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId int,
+	artId int,
+) ([]purchaseLeg, error) {
+	stmt1 := SELECT(UsersBoughtArts.AllColumns).
+		FROM(UsersBoughtArts).
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
+		)
+	var bought model.UsersBoughtArts
+	if err := HandleQueryCtxWithErr(stmt1, ctx, db, &bought, ErrRefundArtNotBought); err != nil {
+		return nil, err
+	}
+	if bought.DownloadCount > 0 {
+		return nil, ErrRefundArtDownloaded
+	}
+
+	stmt2 := SELECT(UsersBoughtBundles.BundleID).
+		FROM(
+			UsersBoughtBundles.
+				INNER_JOIN(BundlesArts, BundlesArts.BundleID.EQ(UsersBoughtBundles.BundleID)),
+		).
+		WHERE(
+			UsersBoughtBundles.UserID.EQ(Int(int64(userId))).
+				AND(BundlesArts.ArtID.EQ(Int(int64(artId)))),
+		)
+	var bundle model.UsersBoughtBundles
+	bundled, err := HandleHasCtx(stmt2, ctx, db, &bundle)
+	if err != nil {
+		return nil, err
+	}
+	if bundled {
+		return nil, ErrRefundBundledArt
+	}
+
+	stmt3 := SELECT(OrderItems.ID).
+		FROM(OrderItems.INNER_JOIN(Orders, Orders.ID.EQ(OrderItems.OrderID))).
+		WHERE(
+			Orders.UserID.EQ(Int(int64(userId))).
+				AND(Orders.Kind.EQ(String(types.OrderKindCart))).
+				AND(OrderItems.ArtID.EQ(Int(int64(artId)))),
+		)
+	var orderItem model.OrderItems
+	ordered, err := HandleHasCtx(stmt3, ctx, db, &orderItem)
+	if err != nil {
+		return nil, err
+	}
+	if ordered {
+		return nil, ErrRefundOrderedArt
+	}
+
+	stmt4 := SELECT(Gifts.ID).
+		FROM(Gifts).
+		WHERE(
+			Gifts.RecipientID.EQ(Int(int64(userId))).
+				AND(Gifts.ArtID.EQ(Int(int64(artId)))),
+		)
+	var gift model.Gifts
+	gifted, err := HandleHasCtx(stmt4, ctx, db, &gift)
+	if err != nil {
+		return nil, err
+	}
+	if gifted {
+		return nil, ErrRefundGiftedArt
+	}
+
+	buyerLegs := CoinTransactionLegs.AS("buyer_legs")
+	paidByUser := SELECT(buyerLegs.ID).
+		FROM(buyerLegs).
+		WHERE(
+			buyerLegs.TransactionID.EQ(CoinTransactions.ID).
+				AND(buyerLegs.Account.EQ(String(types.AccountWallet))).
+				AND(buyerLegs.UserID.EQ(Int(int64(userId)))),
+		)
+
+	stmt5 := SELECT(CoinTransactionLegs.AllColumns, CoinTransactions.AllColumns).
+		FROM(
+			CoinTransactionLegs.
+				INNER_JOIN(CoinTransactions, CoinTransactions.ID.EQ(CoinTransactionLegs.TransactionID)),
+		).
+		WHERE(
+			CoinTransactions.Type.EQ(String(types.CoinTxPurchase)).
+				AND(CoinTransactions.ArtID.EQ(Int(int64(artId)))).
+				AND(CoinTransactions.RefundID.IS_NULL()).
+				AND(EXISTS(paidByUser)),
+		).
+		ORDER_BY(CoinTransactionLegs.ID.ASC())
+
+	legs := []purchaseLeg{}
+	if err := HandleQueryCtx(stmt5, ctx, db, &legs, "coin transaction leg"); err != nil {
+		return nil, err
+	}
+	if len(legs) == 0 {
+		return nil, ErrRefundNotPaid
+	}
+
+	return legs, nil
+}
+
+// ApproveRefundRequest reverses the payment of the art and removes the art
+// from the buyer, all in one transaction.
+func (r *RefundsRepo) ApproveRefundRequest(id, deciderId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	refund, err := r.findOneRefundRequestWithDB(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if !refund.IsPending() {
+		return ErrRefundAlreadyDecided
+	}
+
+	userId, artId := int(refund.UserID), int(refund.ArtID)
+	legs, err := r.findRefundableLegsWithDB(ctx, tx, userId, artId)
+	if err != nil {
+		return err
+	}
+
+	paid := make([]model.CoinTransactionLegs, len(legs))
+	purchases := []Expression{}
+	seen := map[int32]bool{}
+	for i, leg := range legs {
+		paid[i] = leg.CoinTransactionLegs
+		if !seen[leg.TransactionID] {
+			seen[leg.TransactionID] = true
+			purchases = append(purchases, Int(int64(leg.TransactionID)))
+		}
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:  types.CoinTxRefund,
+		ArtId: &artId,
+		Legs:  types.ReverseLegs(paid),
+	})
+	if err != nil {
+		return err
+	}
+
+	stmt1 := CoinTransactions.UPDATE(CoinTransactions.RefundID).
+		SET(Int(int64(transactionId))).
+		WHERE(CoinTransactions.ID.IN(purchases...))
+	if err := HandleExecCtx(stmt1, ctx, tx, "coin_transactions"); err != nil {
+		return err
+	}
+
+	stmt2 := UsersBoughtArts.DELETE().
+		WHERE(
+			UsersBoughtArts.UserID.EQ(Int(int64(userId))).
+				AND(UsersBoughtArts.ArtID.EQ(Int(int64(artId)))),
+		)
+	if err := HandleExecCtx(stmt2, ctx, tx, "users_bought_arts"); err != nil {
+		return err
+	}
+
+	err = r.decideRefundRequestWithDB(ctx, tx, refund, types.RefundStatusApproved, deciderId, &transactionId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RejectRefundRequest keeps the purchase as it is.
+func (r *RefundsRepo) RejectRefundRequest(id, deciderId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	refund, err := r.findOneRefundRequestWithDB(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if !refund.IsPending() {
+		return ErrRefundAlreadyDecided
+	}
+
+	err = r.decideRefundRequestWithDB(ctx, tx, refund, types.RefundStatusRejected, deciderId, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// decideRefundRequestWithDB records the decision and tells the buyer.
+func (r *RefundsRepo) decideRefundRequestWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	refund types.RefundRequest,
+	status string,
+	deciderId int,
+	transactionId *int,
+) error {
+	stmt := RefundRequests.
+		UPDATE(RefundRequests.Status, RefundRequests.DecidedBy, RefundRequests.TransactionID).
+		SET(status, deciderId, transactionId).
+		WHERE(
+			RefundRequests.ID.EQ(Int(int64(*refund.ID))).
+				AND(RefundRequests.Status.EQ(String(types.RefundStatusPending))),
+		)
+	if err := HandleExecCtxWithErr(stmt, ctx, db, ErrRefundAlreadyDecided); err != nil {
+		return err
+	}
+
+	return notifyUserWithDB(
+		ctx,
+		db,
+		int(refund.UserID),
+		fmt.Sprintf("Your refund of %q is %s", refund.Art.Name, status),
+		"/me",
+	)
+}
+
+func (r *RefundsRepo) FindOneRefundRequest(id int) (types.RefundRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return r.findOneRefundRequestWithDB(ctx, r.db, id)
+}
+
+func (r *RefundsRepo) findOneRefundRequestWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	id int,
+) (types.RefundRequest, error) {
+	stmt := refundRequestsQuery(RefundRequests.ID.EQ(Int(int64(id))))
+
+	var dest types.RefundRequest
+	err := HandleQueryCtxWithErr(stmt, ctx, db, &dest, ErrRefundRequestNotFound)
+	return dest, err
+}
+
+// FindManyRefundRequestsByBuyer finds the refunds requested by the user.
+func (r *RefundsRepo) FindManyRefundRequestsByBuyer(userId int) ([]types.RefundRequest, error) {
+	return r.findManyRefundRequests(RefundRequests.UserID.EQ(Int(int64(userId))))
+}
+
+// FindManyRefundRequestsByCreator finds the refunds of the creator's arts.
+func (r *RefundsRepo) FindManyRefundRequestsByCreator(
+	creatorId int,
+) ([]types.RefundRequest, error) {
+	return r.findManyRefundRequests(Arts.CreatorID.EQ(Int(int64(creatorId))))
+}
+
+func (r *RefundsRepo) FindAllRefundRequests() ([]types.RefundRequest, error) {
+	return r.findManyRefundRequests(Bool(true))
+}
+
+func (r *RefundsRepo) findManyRefundRequests(
+	cond BoolExpression,
+) ([]types.RefundRequest, error) {
+	stmt := refundRequestsQuery(cond).
+		ORDER_BY(RefundRequests.CreatedAt.DESC(), RefundRequests.ID.DESC()).
+		LIMIT(50)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.RefundRequest{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "refund request")
+	return dest, err
+}
+
+func refundRequestsQuery(cond BoolExpression) SelectStatement {
+	buyer := Users.AS("Buyer")
+
+	return SELECT(
+		RefundRequests.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Arts.CreatorID,
+		buyer.ID,
+		buyer.Username,
+		buyer.AvatarURL,
+	).
+		FROM(
+			RefundRequests.
+				INNER_JOIN(Arts, Arts.ID.EQ(RefundRequests.ArtID)).
+				INNER_JOIN(buyer, buyer.ID.EQ(RefundRequests.UserID)),
+		).
+		WHERE(cond)
+}
@@ -0,0 +1,44 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_RefundsRepo_GiftThenBuy(t *testing.T) {
+	const recipientId = 4
+
+	artsRepo, ledgerRepo := setupPurchase(t)
+	refundsRepo := repositories.NewRefundsRepo(testDB, 5*time.Second)
+
+	buy := types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice}
+	err := artsRepo.GiftArt(types.GiftArtReq{BuyArtReq: buy, RecipientName: "user2"})
+	asserts.EqualError(t, err, nil)
+	asserts.EqualError(t, artsRepo.BuyArt(buy), nil)
+
+	since := time.Now().UTC().Add(-time.Hour)
+	asserts.EqualError(t, refundsRepo.CreateRefundRequest(buyerId, 1, "changed my mind", since), nil)
+
+	requests, err := refundsRepo.FindManyRefundRequestsByBuyer(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "refund requests", len(requests), 1)
+	asserts.Equal(t, "refund amount", int(requests[0].Amount), artPrice)
+
+	asserts.EqualError(t, refundsRepo.ApproveRefundRequest(int(*requests[0].ID), 2), nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin", coin, buyerCoin-artPrice)
+
+	gifted, err := artsRepo.HasUsersBoughtArts(recipientId, 1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "recipient keeps the gift", gifted, true)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var ErrRiskFlagReviewed = httperror.New(
+	"this risk flag is not waiting for a review",
+	http.StatusBadRequest,
+)
+
+type RiskRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewRiskRepo(db *sql.DB, timeout time.Duration) *RiskRepo {
+	return &RiskRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// FindRiskSignals counts what the fraud rules score for the attempt, over the
+// attempts and the refunds since the given time. The user is new when they
+// signed up after newUserSince.
+func (r *RiskRepo) FindRiskSignals(
+	req types.RiskEventReq,
+	since, newUserSince time.Time,
+) (types.RiskSignals, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	inWindow := func(column ColumnTimestamp) BoolExpression {
+		return DATETIME(column).GT_EQ(DATETIME(since))
+	}
+
+	ipAccounts := SELECT(COUNT(DISTINCT(RiskEvents.UserID))).
+		FROM(RiskEvents).
+		WHERE(
+			RiskEvents.Action.EQ(String(req.Action)).
+				AND(RiskEvents.SubjectID.EQ(Int(int64(req.SubjectId)))).
+				AND(RiskEvents.IP.EQ(String(req.IP))).
+				AND(RiskEvents.UserID.NOT_EQ(Users.ID)).
+				AND(inWindow(RiskEvents.CreatedAt)),
+		)
+	refunds := SELECT(COUNT(RefundRequests.ID)).
+		FROM(RefundRequests).
+		WHERE(
+			RefundRequests.UserID.EQ(Users.ID).
+				AND(RefundRequests.Status.NOT_EQ(String(types.RefundStatusRejected))).
+				AND(inWindow(RefundRequests.CreatedAt)),
+		)
+	purchases := SELECT(COUNT(RiskEvents.ID)).
+		FROM(RiskEvents).
+		WHERE(
+			RiskEvents.UserID.EQ(Users.ID).
+				AND(RiskEvents.Action.EQ(String(types.RiskActionBuyArt))).
+				AND(inWindow(RiskEvents.CreatedAt)),
+		)
+	cleared := SELECT(Int(1)).
+		FROM(RiskFlags).
+		WHERE(
+			RiskFlags.UserID.EQ(Users.ID).
+				AND(RiskFlags.Action.EQ(String(req.Action))).
+				AND(RiskFlags.SubjectID.EQ(Int(int64(req.SubjectId)))).
+				AND(RiskFlags.Decision.IN(String(types.RiskDecisionHold), String(types.RiskDecisionBlock))).
+				AND(RiskFlags.Status.EQ(String(types.RiskFlagStatusCleared))),
+		)
+
+	stmt := SELECT(
+		IntExp(ipAccounts).ADD(Int(1)).AS("signals.ip_accounts"),
+		IntExp(refunds).AS("signals.refunds"),
+		IntExp(purchases).AS("signals.purchases"),
+		DATETIME(Users.CreatedAt).GT_EQ(DATETIME(newUserSince)).AS("signals.is_new_user"),
+		EXISTS(cleared).AS("signals.is_cleared"),
+	).
+		FROM(Users).
+		WHERE(Users.ID.EQ(Int(int64(req.UserId))))
+
+	var dest struct {
+		IPAccounts int  `alias:"signals.ip_accounts"`
+		Refunds    int  `alias:"signals.refunds"`
+		Purchases  int  `alias:"signals.purchases"`
+		IsNewUser  bool `alias:"signals.is_new_user"`
+		IsCleared  bool `alias:"signals.is_cleared"`
+	}
+	if err := HandleQueryCtxWithErr(stmt, ctx, r.db, &dest, ErrUserNotFound); err != nil {
+		return types.RiskSignals{}, err
+	}
+
+	return types.RiskSignals(dest), nil
+}
+
+// RecordRiskEvent keeps the attempt for the next assessments, and puts it in
+// the review queue when it was not simply allowed.
+func (r *RiskRepo) RecordRiskEvent(req types.RiskEventReq, assessment types.RiskAssessment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := RiskEvents.
+		INSERT(
+			RiskEvents.UserID,
+			RiskEvents.Action,
+			RiskEvents.SubjectID,
+			RiskEvents.IP,
+			RiskEvents.CreatedAt,
+		).
+		VALUES(req.UserId, req.Action, req.SubjectId, req.IP, DATETIME(req.Now)).
+		RETURNING(RiskEvents.ID)
+
+	var event struct {
+		ID int `alias:"risk_events.id"`
+	}
+	if err := HandleQueryCtx(stmt1, ctx, tx, &event, "risk event"); err != nil {
+		return err
+	}
+
+	if assessment.Decision != types.RiskDecisionAllow {
+		stmt2 := RiskFlags.
+			INSERT(
+				RiskFlags.EventID,
+				RiskFlags.UserID,
+				RiskFlags.Action,
+				RiskFlags.SubjectID,
+				RiskFlags.Rules,
+				RiskFlags.Score,
+				RiskFlags.Decision,
+			).
+			VALUES(
+				event.ID,
+				req.UserId,
+				req.Action,
+				req.SubjectId,
+				strings.Join(assessment.Rules, ","),
+				assessment.Score,
+				assessment.Decision,
+			)
+		if err := HandleExecCtx(stmt2, ctx, tx, "risk_flags"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindManyOpenRiskFlags is the review queue of the admins, oldest first.
+func (r *RiskRepo) FindManyOpenRiskFlags() ([]types.RiskFlag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	user := Users.AS("User")
+	stmt := SELECT(
+		RiskFlags.AllColumns,
+		RiskEvents.IP,
+		user.ID,
+		user.Username,
+		user.AvatarURL,
+	).
+		FROM(
+			RiskFlags.
+				INNER_JOIN(RiskEvents, RiskEvents.ID.EQ(RiskFlags.EventID)).
+				INNER_JOIN(user, user.ID.EQ(RiskFlags.UserID)),
+		).
+		WHERE(RiskFlags.Status.EQ(String(types.RiskFlagStatusOpen))).
+		ORDER_BY(RiskFlags.CreatedAt.ASC(), RiskFlags.ID.ASC())
+
+	dest := []types.RiskFlag{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "risk flag")
+	return dest, err
+}
+
+// ReviewRiskFlag closes the open flag. A cleared hold or block lets the user
+// attempt the action on the same subject again.
+func (r *RiskRepo) ReviewRiskFlag(id, reviewerId int, status string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := RiskFlags.UPDATE(RiskFlags.Status, RiskFlags.ReviewedBy).
+		SET(String(status), Int(int64(reviewerId))).
+		WHERE(
+			RiskFlags.ID.EQ(Int(int64(id))).
+				AND(RiskFlags.Status.EQ(String(types.RiskFlagStatusOpen))),
+		)
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrRiskFlagReviewed)
+}
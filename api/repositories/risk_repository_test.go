@@ -0,0 +1,62 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_RiskRepo_FindRiskSignals(t *testing.T) {
+	setupPurchase(t)
+	riskRepo := repositories.NewRiskRepo(testDB, 5*time.Second)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	since := now.Add(-time.Hour)
+	attempt := func(userId int, ip string, at time.Time) types.RiskEventReq {
+		return types.RiskEventReq{
+			UserId:    userId,
+			Action:    types.RiskActionUseCode,
+			SubjectId: 1,
+			IP:        ip,
+			Now:       at,
+		}
+	}
+	allow := types.RiskAssessment{Decision: types.RiskDecisionAllow}
+
+	asserts.EqualError(t, riskRepo.RecordRiskEvent(attempt(1, "10.0.0.1", now), allow), nil)
+	asserts.EqualError(t, riskRepo.RecordRiskEvent(attempt(2, "10.0.0.1", now), allow), nil)
+	asserts.EqualError(t, riskRepo.RecordRiskEvent(attempt(2, "10.0.0.2", now), allow), nil)
+	// too old to count
+	asserts.EqualError(t, riskRepo.RecordRiskEvent(attempt(3, "10.0.0.1", now.Add(-2*time.Hour)), allow), nil)
+
+	signals, err := riskRepo.FindRiskSignals(attempt(buyerId, "10.0.0.1", now), since, since)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "accounts from the ip", signals.IPAccounts, 3)
+	asserts.Equal(t, "new user", signals.IsNewUser, true)
+	asserts.Equal(t, "cleared", signals.IsCleared, false)
+
+	held := types.RiskAssessment{
+		Score:    70,
+		Rules:    []string{types.RiskRuleSharedIP, types.RiskRuleNewAccount},
+		Decision: types.RiskDecisionHold,
+	}
+	asserts.EqualError(t, riskRepo.RecordRiskEvent(attempt(buyerId, "10.0.0.1", now), held), nil)
+
+	flags, err := riskRepo.FindManyOpenRiskFlags()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "open flags", len(flags), 1)
+	asserts.Equal(t, "flag ip", flags[0].IP, "10.0.0.1")
+	asserts.Equal(t, "flag rules", len(flags[0].RuleList()), 2)
+
+	id := int(*flags[0].ID)
+	asserts.EqualError(t, riskRepo.ReviewRiskFlag(id, 2, types.RiskFlagStatusCleared), nil)
+	err = riskRepo.ReviewRiskFlag(id, 2, types.RiskFlagStatusConfirmed)
+	asserts.EqualError(t, err, repositories.ErrRiskFlagReviewed)
+
+	signals, err = riskRepo.FindRiskSignals(attempt(buyerId, "10.0.0.1", now), since, since)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "cleared", signals.IsCleared, true)
+}
@@ -0,0 +1,476 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrSubscriptionTierNotFound = ErrNotFound("subscription tier")
+	ErrSubscriptionNotFound     = ErrNotFound("subscription")
+	ErrSubscriptionTierExists   = httperror.New(
+		"you already have a tier with this name",
+		http.StatusBadRequest,
+	)
+	ErrSubscriptionTierInUse = httperror.New(
+		"this tier still has subscribers",
+		http.StatusBadRequest,
+	)
+	ErrSubscribeToSelf = httperror.New(
+		"you cannot subscribe to your own tier",
+		http.StatusBadRequest,
+	)
+	ErrAlreadySubscribed = httperror.New(
+		"you are already subscribed to this tier",
+		http.StatusBadRequest,
+	)
+)
+
+type SubscriptionsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewSubscriptionsRepo(db *sql.DB, timeout time.Duration) *SubscriptionsRepo {
+	return &SubscriptionsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// subscriptionAccessCond matches the subscriptions that give access to their
+// tier. An active subscription keeps its access until it lapses, even when
+// its renewal is late.
+func subscriptionAccessCond(now time.Time) BoolExpression {
+	return Subscriptions.Status.EQ(String(types.SubscriptionStatusActive)).
+		OR(
+			Subscriptions.Status.EQ(String(types.SubscriptionStatusCancelled)).
+				AND(DATETIME(Subscriptions.RenewsAt).GT(DATETIME(now))),
+		)
+}
+
+// checkCreatorTierWithDB checks that the tier belongs to the creator.
+func checkCreatorTierWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	tierId int,
+	creatorId IntegerExpression,
+) error {
+	stmt := SELECT(SubscriptionTiers.ID).
+		FROM(SubscriptionTiers).
+		WHERE(
+			SubscriptionTiers.ID.EQ(Int(int64(tierId))).
+				AND(SubscriptionTiers.CreatorID.EQ(creatorId)),
+		)
+	var tier model.SubscriptionTiers
+	return HandleQueryCtxWithErr(stmt, ctx, db, &tier, ErrSubscriptionTierNotFound)
+}
+
+// FindManyTiers finds the tiers of the creator, from the cheapest, with the
+// subscription of the user to each of them.
+func (r *SubscriptionsRepo) FindManyTiers(
+	creatorId, userId int,
+	now time.Time,
+) ([]types.SubscriptionTier, error) {
+	subscribers := SELECT(COUNT(Subscriptions.ID)).
+		FROM(Subscriptions).
+		WHERE(
+			Subscriptions.TierID.EQ(SubscriptionTiers.ID).
+				AND(subscriptionAccessCond(now)),
+		)
+
+	stmt := SELECT(
+		SubscriptionTiers.AllColumns,
+		IntExp(subscribers).AS("tier.subscribers"),
+		Subscriptions.AllColumns,
+	).
+		FROM(
+			SubscriptionTiers.LEFT_JOIN(
+				Subscriptions,
+				Subscriptions.TierID.EQ(SubscriptionTiers.ID).
+					AND(Subscriptions.UserID.EQ(Int(int64(userId)))),
+			),
+		).
+		WHERE(SubscriptionTiers.CreatorID.EQ(Int(int64(creatorId)))).
+		ORDER_BY(SubscriptionTiers.Price.ASC(), SubscriptionTiers.ID.ASC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.SubscriptionTier{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "subscription tier")
+	return dest, err
+}
+
+func (r *SubscriptionsRepo) CreateTier(req types.SubscriptionTierReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SubscriptionTiers.
+		INSERT(
+			SubscriptionTiers.CreatorID,
+			SubscriptionTiers.Name,
+			SubscriptionTiers.Description,
+			SubscriptionTiers.Price,
+		).
+		VALUES(req.CreatorId, req.Name, req.Description, req.Price)
+	return handleTierErr(HandleExecCtx(stmt, ctx, r.db, "subscription_tiers"))
+}
+
+// UpdateTier updates the tier of the creator. A new price is charged from the
+// next renewal.
+func (r *SubscriptionsRepo) UpdateTier(req types.SubscriptionTierReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SubscriptionTiers.
+		UPDATE(SubscriptionTiers.Name, SubscriptionTiers.Description, SubscriptionTiers.Price).
+		SET(req.Name, req.Description, req.Price).
+		WHERE(
+			SubscriptionTiers.ID.EQ(Int(int64(req.TierId))).
+				AND(SubscriptionTiers.CreatorID.EQ(Int(int64(req.CreatorId)))),
+		)
+	return handleTierErr(
+		HandleExecCtxWithErr(stmt, ctx, r.db, ErrSubscriptionTierNotFound),
+	)
+}
+
+func handleTierErr(err error) error {
+	if err != nil &&
+		strings.Contains(err.Error(), "UNIQUE constraint failed: subscription_tiers.creator_id, subscription_tiers.name") {
+		return ErrSubscriptionTierExists
+	}
+	return err
+}
+
+// DeleteTier deletes the tier of the creator when nobody has access to it
+// anymore. Its members-only arts become for sale again.
+func (r *SubscriptionsRepo) DeleteTier(creatorId, tierId int, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkCreatorTierWithDB(ctx, tx, tierId, Int(int64(creatorId))); err != nil {
+		return err
+	}
+
+	stmt1 := SELECT(Subscriptions.ID).
+		FROM(Subscriptions).
+		WHERE(
+			Subscriptions.TierID.EQ(Int(int64(tierId))).
+				AND(subscriptionAccessCond(now)),
+		)
+	var tmp struct{ int }
+	inUse, err := HandleHasCtx(stmt1, ctx, tx, &tmp)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrSubscriptionTierInUse
+	}
+
+	stmt2 := SubscriptionTiers.DELETE().WHERE(SubscriptionTiers.ID.EQ(Int(int64(tierId))))
+	if err := HandleExecCtxWithErr(stmt2, ctx, tx, ErrSubscriptionTierNotFound); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Subscribe charges the first month of the tier and moves it to the earnings
+// of the creator, less the platform fee. A cancelled subscription that is
+// still paid is resumed without being charged again.
+func (r *SubscriptionsRepo) Subscribe(req types.SubscribeReq, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(SubscriptionTiers.AllColumns, Users.ID, Users.Username).
+		FROM(SubscriptionTiers.INNER_JOIN(Users, Users.ID.EQ(SubscriptionTiers.CreatorID))).
+		WHERE(SubscriptionTiers.ID.EQ(Int(int64(req.TierId))))
+	var tier struct {
+		model.SubscriptionTiers
+		Creator model.Users
+	}
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &tier, ErrSubscriptionTierNotFound); err != nil {
+		return err
+	}
+	if int(tier.CreatorID) == req.UserId {
+		return ErrSubscribeToSelf
+	}
+
+	stmt2 := SELECT(Subscriptions.AllColumns).
+		FROM(Subscriptions).
+		WHERE(
+			Subscriptions.UserID.EQ(Int(int64(req.UserId))).
+				AND(Subscriptions.TierID.EQ(Int(int64(req.TierId)))),
+		)
+	var subscriptions []model.Subscriptions
+	if err := HandleQueryCtx(stmt2, ctx, tx, &subscriptions, "subscription"); err != nil {
+		return err
+	}
+
+	if len(subscriptions) > 0 {
+		subscription := subscriptions[0]
+		if subscription.Status == types.SubscriptionStatusActive {
+			return ErrAlreadySubscribed
+		}
+		if subscription.Status == types.SubscriptionStatusCancelled &&
+			subscription.RenewsAt.After(now) {
+			stmt3 := Subscriptions.
+				UPDATE(Subscriptions.Status).
+				SET(String(types.SubscriptionStatusActive)).
+				WHERE(Subscriptions.ID.EQ(Int(int64(*subscription.ID))))
+			if err := HandleExecCtx(stmt3, ctx, tx, "subscriptions"); err != nil {
+				return err
+			}
+			return tx.Commit()
+		}
+	}
+
+	if err := chargeSubscriptionWithDB(
+		ctx,
+		tx,
+		req.UserId,
+		tier.SubscriptionTiers,
+		tier.Creator.Username,
+		req.FeePercent,
+	); err != nil {
+		return err
+	}
+
+	renewsAt := now.AddDate(0, 1, 0)
+	if len(subscriptions) > 0 {
+		stmt4 := Subscriptions.
+			UPDATE(Subscriptions.Status, Subscriptions.RenewsAt).
+			SET(String(types.SubscriptionStatusActive), DATETIME(renewsAt)).
+			WHERE(Subscriptions.ID.EQ(Int(int64(*subscriptions[0].ID))))
+		if err := HandleExecCtx(stmt4, ctx, tx, "subscriptions"); err != nil {
+			return err
+		}
+	} else {
+		stmt4 := Subscriptions.
+			INSERT(Subscriptions.UserID, Subscriptions.TierID, Subscriptions.RenewsAt).
+			VALUES(req.UserId, req.TierId, DATETIME(renewsAt))
+		if err := HandleExecCtx(stmt4, ctx, tx, "subscriptions"); err != nil {
+			return err
+		}
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		int(tier.CreatorID),
+		fmt.Sprintf("You have a new subscriber to %s", tier.Name),
+		"/creator/subscriptions",
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// chargeSubscriptionWithDB charges a month of the tier like a sale of the
+// creator.
+func chargeSubscriptionWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	userId int,
+	tier model.SubscriptionTiers,
+	creatorName string,
+	feePercent int,
+) error {
+	return postCoinTransactionWithDB(ctx, db, types.CoinTransactionReq{
+		Type:        types.CoinTxSubscription,
+		Description: fmt.Sprintf("Subscription to %s of %s", tier.Name, creatorName),
+		Legs:        types.SaleLegs(userId, int(tier.CreatorID), int(tier.Price), feePercent),
+	})
+}
+
+// CancelSubscription stops the renewals of the subscription. The user keeps
+// the access until the end of the paid month.
+func (r *SubscriptionsRepo) CancelSubscription(userId, tierId int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := Subscriptions.
+		UPDATE(Subscriptions.Status).
+		SET(String(types.SubscriptionStatusCancelled)).
+		WHERE(
+			Subscriptions.UserID.EQ(Int(int64(userId))).
+				AND(Subscriptions.TierID.EQ(Int(int64(tierId)))).
+				AND(Subscriptions.Status.EQ(String(types.SubscriptionStatusActive))),
+		)
+	return HandleExecCtxWithErr(stmt, ctx, r.db, ErrSubscriptionNotFound)
+}
+
+// FindManyDueSubscriptions finds the active subscriptions whose renewal time
+// has passed.
+func (r *SubscriptionsRepo) FindManyDueSubscriptions(now time.Time) ([]model.Subscriptions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	stmt := SELECT(Subscriptions.AllColumns).
+		FROM(Subscriptions).
+		WHERE(
+			Subscriptions.Status.EQ(String(types.SubscriptionStatusActive)).
+				AND(DATETIME(Subscriptions.RenewsAt).LT_EQ(DATETIME(now))),
+		).
+		ORDER_BY(Subscriptions.RenewsAt.ASC(), Subscriptions.ID.ASC())
+
+	dest := []model.Subscriptions{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "subscription")
+	return dest, err
+}
+
+// RenewSubscription charges the next month of the subscription at the
+// current price of its tier. When the wallet does not have enough coins, the
+// subscription lapses instead and the user is told so.
+func (r *SubscriptionsRepo) RenewSubscription(
+	subscriptionId, feePercent int,
+	now time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	subscriber := Users.AS("Subscriber")
+	creator := Users.AS("Creator")
+	stmt1 := SELECT(
+		Subscriptions.AllColumns,
+		SubscriptionTiers.AllColumns,
+		subscriber.ID,
+		subscriber.Coin,
+		creator.ID,
+		creator.Username,
+	).
+		FROM(
+			Subscriptions.
+				INNER_JOIN(SubscriptionTiers, SubscriptionTiers.ID.EQ(Subscriptions.TierID)).
+				INNER_JOIN(subscriber, subscriber.ID.EQ(Subscriptions.UserID)).
+				INNER_JOIN(creator, creator.ID.EQ(SubscriptionTiers.CreatorID)),
+		).
+		WHERE(
+			Subscriptions.ID.EQ(Int(int64(subscriptionId))).
+				AND(Subscriptions.Status.EQ(String(types.SubscriptionStatusActive))).
+				AND(DATETIME(Subscriptions.RenewsAt).LT_EQ(DATETIME(now))),
+		)
+	var subscription struct {
+		model.Subscriptions
+		Tier       model.SubscriptionTiers
+		Subscriber model.Users `alias:"Subscriber.*"`
+		Creator    model.Users `alias:"Creator.*"`
+	}
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &subscription, ErrSubscriptionNotFound); err != nil {
+		return err
+	}
+
+	if subscription.Subscriber.Coin < subscription.Tier.Price {
+		stmt2 := Subscriptions.
+			UPDATE(Subscriptions.Status).
+			SET(String(types.SubscriptionStatusLapsed)).
+			WHERE(Subscriptions.ID.EQ(Int(int64(subscriptionId))))
+		if err := HandleExecCtx(stmt2, ctx, tx, "subscriptions"); err != nil {
+			return err
+		}
+
+		err = notifyUserWithDB(
+			ctx,
+			tx,
+			int(subscription.UserID),
+			fmt.Sprintf(
+				"Your subscription to %s of %s lapsed because you did not have enough coins",
+				subscription.Tier.Name,
+				subscription.Creator.Username,
+			),
+			fmt.Sprint("/creators/", subscription.Creator.ID),
+		)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	if err := chargeSubscriptionWithDB(
+		ctx,
+		tx,
+		int(subscription.UserID),
+		subscription.Tier,
+		subscription.Creator.Username,
+		feePercent,
+	); err != nil {
+		return err
+	}
+
+	renewsAt := types.NextRenewal(subscription.RenewsAt, now)
+	stmt3 := Subscriptions.
+		UPDATE(Subscriptions.RenewsAt).
+		SET(DATETIME(renewsAt)).
+		WHERE(Subscriptions.ID.EQ(Int(int64(subscriptionId))))
+	if err := HandleExecCtx(stmt3, ctx, tx, "subscriptions"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindManySubscribers finds the users with access to a tier of the creator.
+func (r *SubscriptionsRepo) FindManySubscribers(
+	creatorId int,
+	now time.Time,
+) ([]types.Subscriber, error) {
+	subscriber := Users.AS("Subscriber")
+
+	stmt := SELECT(
+		Subscriptions.AllColumns,
+		subscriber.ID,
+		subscriber.Username,
+		subscriber.AvatarURL,
+		SubscriptionTiers.AllColumns,
+	).
+		FROM(
+			Subscriptions.
+				INNER_JOIN(SubscriptionTiers, SubscriptionTiers.ID.EQ(Subscriptions.TierID)).
+				INNER_JOIN(subscriber, subscriber.ID.EQ(Subscriptions.UserID)),
+		).
+		WHERE(
+			SubscriptionTiers.CreatorID.EQ(Int(int64(creatorId))).
+				AND(subscriptionAccessCond(now)),
+		).
+		ORDER_BY(Subscriptions.CreatedAt.DESC(), Subscriptions.ID.DESC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.Subscriber{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "subscriber")
+	return dest, err
+}
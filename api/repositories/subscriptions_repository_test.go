@@ -0,0 +1,154 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+// setupSubscription creates a tier of the creator at the price of an art and
+// makes the first art members-only for it.
+func setupSubscription(t *testing.T) (*repositories.ArtsRepo, *repositories.SubscriptionsRepo, int) {
+	t.Helper()
+
+	artsRepo, _ := setupPurchase(t)
+	subscriptionsRepo := repositories.NewSubscriptionsRepo(testDB, 5*time.Second)
+
+	err := subscriptionsRepo.CreateTier(types.SubscriptionTierReq{
+		CreatorId: creatorId,
+		Name:      "Gold",
+		Price:     artPrice,
+	})
+	asserts.EqualError(t, err, nil)
+
+	tiers, err := subscriptionsRepo.FindManyTiers(creatorId, buyerId, time.Now().UTC())
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "tiers", len(tiers), 1)
+	tierId := int(*tiers[0].ID)
+
+	_, err = testDB.Exec(`UPDATE "arts" SET "members_tier_id" = ? WHERE "id" = 1`, tierId)
+	asserts.EqualError(t, err, nil)
+
+	return artsRepo, subscriptionsRepo, tierId
+}
+
+func Test_SubscriptionsRepo_RenewSubscription(t *testing.T) {
+	artsRepo, subscriptionsRepo, tierId := setupSubscription(t)
+	now := time.Now().UTC()
+
+	err := artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, repositories.ErrArtNotForSale)
+
+	access, err := artsRepo.HasMembersAccess(buyerId, 1, now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "access before subscribing", access, false)
+
+	err = subscriptionsRepo.Subscribe(types.SubscribeReq{UserId: creatorId, TierId: tierId}, now)
+	asserts.EqualError(t, err, repositories.ErrSubscribeToSelf)
+
+	err = subscriptionsRepo.Subscribe(types.SubscribeReq{UserId: buyerId, TierId: tierId}, now)
+	asserts.EqualError(t, err, nil)
+	err = subscriptionsRepo.Subscribe(types.SubscribeReq{UserId: buyerId, TierId: tierId}, now)
+	asserts.EqualError(t, err, repositories.ErrAlreadySubscribed)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after subscribing", coin, buyerCoin-artPrice)
+
+	access, err = artsRepo.HasMembersAccess(buyerId, 1, now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "access after subscribing", access, true)
+
+	tiers, err := subscriptionsRepo.FindManyTiers(creatorId, buyerId, now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "subscribed", tiers[0].IsSubscribed(), true)
+	asserts.Equal(t, "tier subscribers", tiers[0].Subscribers, 1)
+
+	subscribers, err := subscriptionsRepo.FindManySubscribers(creatorId, now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "subscribers", len(subscribers), 1)
+	asserts.Equal(t, "subscriber", subscribers[0].Subscriber.Username, buyerName)
+
+	// a month later the wallet still has enough coins for one renewal
+	later := now.AddDate(0, 1, 0).Add(time.Minute)
+	due, err := subscriptionsRepo.FindManyDueSubscriptions(later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due subscriptions", len(due), 1)
+
+	err = subscriptionsRepo.RenewSubscription(int(*due[0].ID), 0, later)
+	asserts.EqualError(t, err, nil)
+
+	coin, err = artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after renewal", coin, buyerCoin-2*artPrice)
+
+	due, err = subscriptionsRepo.FindManyDueSubscriptions(later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due subscriptions after renewal", len(due), 0)
+
+	// but not for another one
+	later = later.AddDate(0, 1, 0)
+	due, err = subscriptionsRepo.FindManyDueSubscriptions(later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due subscriptions next month", len(due), 1)
+
+	err = subscriptionsRepo.RenewSubscription(int(*due[0].ID), 0, later)
+	asserts.EqualError(t, err, nil)
+
+	coin, err = artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after lapse", coin, buyerCoin-2*artPrice)
+
+	access, err = artsRepo.HasMembersAccess(buyerId, 1, later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "access after lapse", access, false)
+
+	// nobody has access anymore, so the tier can go and the art is sold again
+	err = subscriptionsRepo.DeleteTier(creatorId, tierId, later)
+	asserts.EqualError(t, err, nil)
+
+	forSale, err := artsRepo.IsArtForSale(1)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "for sale", forSale, true)
+}
+
+func Test_SubscriptionsRepo_CancelSubscription(t *testing.T) {
+	artsRepo, subscriptionsRepo, tierId := setupSubscription(t)
+	now := time.Now().UTC()
+	later := now.AddDate(0, 1, 0).Add(time.Minute)
+
+	err := subscriptionsRepo.Subscribe(types.SubscribeReq{UserId: buyerId, TierId: tierId}, now)
+	asserts.EqualError(t, err, nil)
+
+	err = subscriptionsRepo.CancelSubscription(buyerId, tierId)
+	asserts.EqualError(t, err, nil)
+	err = subscriptionsRepo.CancelSubscription(buyerId, tierId)
+	asserts.EqualError(t, err, repositories.ErrSubscriptionNotFound)
+
+	// the paid month is kept, but never renewed
+	access, err := artsRepo.HasMembersAccess(buyerId, 1, now)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "access in the paid month", access, true)
+
+	err = subscriptionsRepo.DeleteTier(creatorId, tierId, now)
+	asserts.EqualError(t, err, repositories.ErrSubscriptionTierInUse)
+
+	due, err := subscriptionsRepo.FindManyDueSubscriptions(later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "due subscriptions", len(due), 0)
+
+	access, err = artsRepo.HasMembersAccess(buyerId, 1, later)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "access after the paid month", access, false)
+
+	// resuming in the paid month is not charged again
+	err = subscriptionsRepo.Subscribe(types.SubscribeReq{UserId: buyerId, TierId: tierId}, now)
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after resuming", coin, buyerCoin-artPrice)
+}
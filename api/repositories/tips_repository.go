@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+// number of supporters shown on the creator profile
+const topSupportersLimit = 10
+
+type TipsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewTipsRepo(db *sql.DB, timeout time.Duration) *TipsRepo {
+	return &TipsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+// CreateTip moves the coins from the sender's wallet to the creator's
+// earnings. Tips are not sales, so the platform keeps no fee.
+func (r *TipsRepo) CreateTip(req types.TipReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt1 := SELECT(Users.ID, Users.Username).
+		FROM(Users).
+		WHERE(Users.ID.EQ(Int(int64(req.CreatorId))))
+	var creator model.Users
+	if err := HandleQueryCtxWithErr(stmt1, ctx, tx, &creator, ErrUserNotFound); err != nil {
+		return err
+	}
+
+	if req.ArtId != nil {
+		stmt2 := SELECT(Arts.ID).
+			FROM(Arts).
+			WHERE(
+				Arts.ID.EQ(Int(int64(*req.ArtId))).
+					AND(Arts.CreatorID.EQ(Int(int64(req.CreatorId)))),
+			)
+		var art model.Arts
+		if err := HandleQueryCtx(stmt2, ctx, tx, &art, "art"); err != nil {
+			return err
+		}
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type:        types.CoinTxTip,
+		Description: "Tip for " + creator.Username,
+		ArtId:       req.ArtId,
+		Legs: []types.CoinLeg{
+			types.WalletLeg(req.SenderId, types.Debit, req.Amount),
+			types.EarningsLeg(req.CreatorId, types.Credit, req.Amount),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	stmt3 := Tips.
+		INSERT(
+			Tips.SenderID,
+			Tips.CreatorID,
+			Tips.ArtID,
+			Tips.Amount,
+			Tips.Message,
+			Tips.IsPublic,
+			Tips.TransactionID,
+		).
+		VALUES(
+			req.SenderId,
+			req.CreatorId,
+			req.ArtId,
+			req.Amount,
+			req.Message,
+			req.IsPublic,
+			transactionId,
+		)
+	if err := HandleExecCtx(stmt3, ctx, tx, "tips"); err != nil {
+		return err
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		req.CreatorId,
+		fmt.Sprintf("You received a tip of %d Coin", req.Amount),
+		"/creator",
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindCreatorTips finds the top supporters of the creator with the latest
+// tips. Private tips are only included when withPrivate is true.
+func (r *TipsRepo) FindCreatorTips(creatorId int, withPrivate bool) (types.CreatorTips, error) {
+	sender := Users.AS("Sender")
+
+	stmt1 := SELECT(
+		Tips.SenderID.AS("supporter.user_id"),
+		Users.Username.AS("supporter.username"),
+		Users.AvatarURL.AS("supporter.avatar_url"),
+		SUM(Tips.Amount).AS("supporter.total"),
+		COUNT(Tips.ID).AS("supporter.tips"),
+	).
+		FROM(Tips.INNER_JOIN(Users, Users.ID.EQ(Tips.SenderID))).
+		WHERE(Tips.CreatorID.EQ(Int(int64(creatorId)))).
+		GROUP_BY(Tips.SenderID).
+		ORDER_BY(IntegerColumn("supporter.total").DESC(), Tips.SenderID.ASC()).
+		LIMIT(topSupportersLimit)
+
+	cond := Tips.CreatorID.EQ(Int(int64(creatorId)))
+	if !withPrivate {
+		cond = cond.AND(Tips.IsPublic.IS_TRUE())
+	}
+	stmt2 := SELECT(
+		Tips.AllColumns,
+		sender.ID,
+		sender.Username,
+		sender.AvatarURL,
+		Arts.ID,
+		Arts.Name,
+	).
+		FROM(
+			Tips.
+				INNER_JOIN(sender, sender.ID.EQ(Tips.SenderID)).
+				LEFT_JOIN(Arts, Arts.ID.EQ(Tips.ArtID)),
+		).
+		WHERE(cond).
+		ORDER_BY(Tips.CreatedAt.DESC(), Tips.ID.DESC()).
+		LIMIT(walletHistoryLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tips := types.CreatorTips{Supporters: []types.Supporter{}, Tips: []types.Tip{}}
+	if err := HandleQueryCtx(stmt1, ctx, r.db, &tips.Supporters, "supporter"); err != nil {
+		return types.CreatorTips{}, err
+	}
+	if err := HandleQueryCtx(stmt2, ctx, r.db, &tips.Tips, "tip"); err != nil {
+		return types.CreatorTips{}, err
+	}
+
+	return tips, nil
+}
@@ -0,0 +1,55 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_TipsRepo_CreateTip(t *testing.T) {
+	artsRepo, ledgerRepo := setupPurchase(t)
+	tipsRepo := repositories.NewTipsRepo(testDB, 5*time.Second)
+
+	artId := 1
+	err := tipsRepo.CreateTip(types.TipReq{
+		SenderId:  buyerId,
+		CreatorId: creatorId,
+		ArtId:     &artId,
+		Amount:    30,
+		Message:   "thanks",
+		IsPublic:  true,
+	})
+	asserts.EqualError(t, err, nil)
+
+	coin, err := artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after the tip", coin, buyerCoin-30)
+
+	earnings, err := ledgerRepo.FindCreatorEarnings(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings without a fee", earnings.Balance, 30)
+
+	// more than the wallet holds
+	err = tipsRepo.CreateTip(types.TipReq{SenderId: buyerId, CreatorId: creatorId, Amount: buyerCoin})
+	asserts.EqualError(t, err, repositories.ErrNotEnoughCoin)
+
+	// the art is not one of the creator's
+	err = tipsRepo.CreateTip(types.TipReq{SenderId: buyerId, CreatorId: 2, ArtId: &artId, Amount: 10})
+	asserts.EqualError(t, err, repositories.ErrNotFound("art"))
+
+	coin, err = artsRepo.FindUserCoin(buyerId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "coin after the failed tips", coin, buyerCoin-30)
+
+	tips, err := tipsRepo.FindCreatorTips(creatorId, true)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "tips", len(tips.Tips), 1)
+	asserts.Equal(t, "supporters", len(tips.Supporters), 1)
+
+	report, err := ledgerRepo.FindLedgerReport()
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "ledger is healthy", report.IsHealthy(), true)
+}
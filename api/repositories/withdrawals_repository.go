@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	. "github.com/DeepAung/deep-art/.gen/table"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/go-jet/jet/v2/qrm"
+	. "github.com/go-jet/jet/v2/sqlite"
+)
+
+var (
+	ErrWithdrawalNotFound = ErrNotFound("withdrawal")
+	ErrNotEnoughEarnings  = httperror.New(
+		"not enough earnings to withdraw, earnings of recent sales are still on hold",
+		http.StatusBadRequest,
+	)
+	ErrWithdrawalStatus = httperror.New(
+		"withdrawal cannot move to this status",
+		http.StatusBadRequest,
+	)
+)
+
+type WithdrawalsRepo struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewWithdrawalsRepo(db *sql.DB, timeout time.Duration) *WithdrawalsRepo {
+	return &WithdrawalsRepo{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *WithdrawalsRepo) FindWithdrawalBalance(
+	creatorId int,
+	heldSince time.Time,
+) (types.WithdrawalBalance, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	return findWithdrawalBalanceWithDB(ctx, r.db, creatorId, heldSince)
+}
+
+// findWithdrawalBalanceWithDB holds the earnings credited by every sale made
+// after heldSince, see types.SaleTxTypes. Only the rest of the earnings can be
+// withdrawn.
+func findWithdrawalBalanceWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	creatorId int,
+	heldSince time.Time,
+) (types.WithdrawalBalance, error) {
+	stmt1 := SELECT(Users.Earnings).FROM(Users).WHERE(Users.ID.EQ(Int(int64(creatorId))))
+	var user model.Users
+	if err := HandleQueryCtxWithErr(stmt1, ctx, db, &user, ErrUserNotFound); err != nil {
+		return types.WithdrawalBalance{}, err
+	}
+
+	saleTxTypes := utils.Map(types.SaleTxTypes, func(t string) Expression { return String(t) })
+	stmt2 := SELECT(COALESCE(SUMi(CoinTransactionLegs.Amount), Int(0)).AS("held.amount")).
+		FROM(
+			CoinTransactionLegs.
+				INNER_JOIN(CoinTransactions, CoinTransactions.ID.EQ(CoinTransactionLegs.TransactionID)),
+		).
+		WHERE(
+			CoinTransactionLegs.UserID.EQ(Int(int64(creatorId))).
+				AND(CoinTransactionLegs.Account.EQ(String(types.AccountEarnings))).
+				AND(CoinTransactionLegs.Direction.EQ(String(types.Credit))).
+				AND(CoinTransactions.Type.IN(saleTxTypes...)).
+				AND(DATETIME(CoinTransactions.CreatedAt).GT(DATETIME(heldSince))),
+		)
+	var held struct {
+		Amount int `alias:"held.amount"`
+	}
+	if err := HandleQueryCtx(stmt2, ctx, db, &held, "held earnings"); err != nil {
+		return types.WithdrawalBalance{}, err
+	}
+
+	return types.NewWithdrawalBalance(int(user.Earnings), held.Amount), nil
+}
+
+// RequestWithdrawal moves the amount from the earnings of the creator to the
+// payouts account right away, so the same coins cannot be withdrawn twice.
+func (r *WithdrawalsRepo) RequestWithdrawal(req types.WithdrawalReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	balance, err := findWithdrawalBalanceWithDB(ctx, tx, req.CreatorId, req.HeldSince)
+	if err != nil {
+		return err
+	}
+	if req.Amount > balance.Available {
+		return ErrNotEnoughEarnings
+	}
+
+	transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+		Type: types.CoinTxWithdrawal,
+		Legs: []types.CoinLeg{
+			types.EarningsLeg(req.CreatorId, types.Debit, req.Amount),
+			types.AccountLeg(types.AccountPayouts, types.Credit, req.Amount),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	stmt := Withdrawals.
+		INSERT(Withdrawals.CreatorID, Withdrawals.Amount, Withdrawals.Note, Withdrawals.TransactionID).
+		VALUES(req.CreatorId, req.Amount, req.Note, transactionId)
+	if err := HandleExecCtx(stmt, ctx, tx, "withdrawals"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DecideWithdrawal moves the withdrawal to the status. A rejected withdrawal
+// gives the coins back to the earnings of the creator.
+func (r *WithdrawalsRepo) DecideWithdrawal(req types.WithdrawalDecisionReq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	withdrawal, err := r.findOneWithdrawalWithDB(ctx, tx, req.Id)
+	if err != nil {
+		return err
+	}
+	if !withdrawal.CanMoveTo(req.Status) {
+		return ErrWithdrawalStatus
+	}
+
+	var reversalId *int
+	var paidAt *time.Time
+	switch req.Status {
+	case types.WithdrawalStatusRejected:
+		creatorId, amount := int(withdrawal.CreatorID), int(withdrawal.Amount)
+		transactionId, err := insertCoinTransactionWithDB(ctx, tx, types.CoinTransactionReq{
+			Type: types.CoinTxWithdrawalReversal,
+			Legs: []types.CoinLeg{
+				types.AccountLeg(types.AccountPayouts, types.Debit, amount),
+				types.EarningsLeg(creatorId, types.Credit, amount),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		reversalId = &transactionId
+	case types.WithdrawalStatusPaid:
+		paidAt = &req.Now
+	}
+
+	stmt := Withdrawals.
+		UPDATE(
+			Withdrawals.Status,
+			Withdrawals.AdminNote,
+			Withdrawals.DecidedBy,
+			Withdrawals.ReversalID,
+			Withdrawals.PaidAt,
+		).
+		SET(req.Status, req.AdminNote, req.DeciderId, reversalId, paidAt).
+		WHERE(
+			Withdrawals.ID.EQ(Int(int64(req.Id))).
+				AND(Withdrawals.Status.EQ(String(withdrawal.Status))),
+		)
+	if err := HandleExecCtxWithErr(stmt, ctx, tx, ErrWithdrawalStatus); err != nil {
+		return err
+	}
+
+	err = notifyUserWithDB(
+		ctx,
+		tx,
+		int(withdrawal.CreatorID),
+		fmt.Sprintf("Your withdrawal of %d Coin is %s", withdrawal.Amount, req.Status),
+		"/creator",
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *WithdrawalsRepo) findOneWithdrawalWithDB(
+	ctx context.Context,
+	db qrm.DB,
+	id int,
+) (types.Withdrawal, error) {
+	stmt := withdrawalsQuery(Withdrawals.ID.EQ(Int(int64(id))))
+
+	var dest types.Withdrawal
+	err := HandleQueryCtxWithErr(stmt, ctx, db, &dest, ErrWithdrawalNotFound)
+	return dest, err
+}
+
+func (r *WithdrawalsRepo) FindManyWithdrawalsByCreator(creatorId int) ([]types.Withdrawal, error) {
+	return r.findManyWithdrawals(Withdrawals.CreatorID.EQ(Int(int64(creatorId))))
+}
+
+// FindAllWithdrawals finds the withdrawals of every creator, the ones waiting
+// for an admin first.
+func (r *WithdrawalsRepo) FindAllWithdrawals() ([]types.Withdrawal, error) {
+	return r.findManyWithdrawals(Bool(true))
+}
+
+func (r *WithdrawalsRepo) findManyWithdrawals(cond BoolExpression) ([]types.Withdrawal, error) {
+	waiting := Withdrawals.Status.IN(
+		String(types.WithdrawalStatusPending),
+		String(types.WithdrawalStatusApproved),
+	)
+	stmt := withdrawalsQuery(cond).
+		ORDER_BY(
+			CASE().WHEN(waiting).THEN(Int(0)).ELSE(Int(1)).ASC(),
+			Withdrawals.CreatedAt.DESC(),
+			Withdrawals.ID.DESC(),
+		)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.Withdrawal{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "withdrawal")
+	return dest, err
+}
+
+func withdrawalsQuery(cond BoolExpression) SelectStatement {
+	creator := Users.AS("Creator")
+
+	return SELECT(
+		Withdrawals.AllColumns,
+		creator.ID,
+		creator.Username,
+		creator.Email,
+	).
+		FROM(Withdrawals.INNER_JOIN(creator, creator.ID.EQ(Withdrawals.CreatorID))).
+		WHERE(cond)
+}
+
+// FindManyEarningsEntries finds every earnings entry of the creator, oldest
+// first, for the statement of the creator.
+func (r *WithdrawalsRepo) FindManyEarningsEntries(creatorId int) ([]types.WalletEntry, error) {
+	stmt := SELECT(
+		CoinTransactionLegs.AllColumns,
+		CoinTransactions.AllColumns,
+		Arts.ID,
+		Arts.Name,
+		Bundles.ID,
+		Bundles.Name,
+	).
+		FROM(
+			CoinTransactionLegs.
+				INNER_JOIN(CoinTransactions, CoinTransactions.ID.EQ(CoinTransactionLegs.TransactionID)).
+				LEFT_JOIN(Arts, Arts.ID.EQ(CoinTransactions.ArtID)).
+				LEFT_JOIN(Bundles, Bundles.ID.EQ(CoinTransactions.BundleID)),
+		).
+		WHERE(
+			CoinTransactionLegs.UserID.EQ(Int(int64(creatorId))).
+				AND(CoinTransactionLegs.Account.EQ(String(types.AccountEarnings))),
+		).
+		ORDER_BY(CoinTransactions.CreatedAt.ASC(), CoinTransactions.ID.ASC())
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dest := []types.WalletEntry{}
+	err := HandleQueryCtx(stmt, ctx, r.db, &dest, "earnings entry")
+	return dest, err
+}
@@ -0,0 +1,158 @@
+package repositories_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+const creatorId = 1
+
+func Test_WithdrawalsRepo_RequestWithdrawal(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	withdrawalsRepo := repositories.NewWithdrawalsRepo(testDB, 5*time.Second)
+
+	err := artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+
+	// the sale was just made, so it is on hold unless the hold has already
+	// passed
+	onHold, released := time.Now().UTC().Add(-time.Hour), time.Now().UTC().Add(time.Hour)
+
+	balance, err := withdrawalsRepo.FindWithdrawalBalance(creatorId, onHold)
+	asserts.EqualError(t, err, nil)
+	earned := balance.Earnings
+	asserts.Equal(t, "held", balance.Held, earned)
+	asserts.Equal(t, "available", balance.Available, 0)
+
+	err = withdrawalsRepo.RequestWithdrawal(types.WithdrawalReq{
+		CreatorId: creatorId,
+		Amount:    earned,
+		HeldSince: onHold,
+	})
+	asserts.EqualError(t, err, repositories.ErrNotEnoughEarnings)
+
+	errs := make([]error, goroutines)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = withdrawalsRepo.RequestWithdrawal(types.WithdrawalReq{
+				CreatorId: creatorId,
+				Amount:    earned,
+				HeldSince: released,
+			})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else if err != repositories.ErrNotEnoughEarnings {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	asserts.Equal(t, "succeeded", succeeded, 1)
+
+	balance, err = withdrawalsRepo.FindWithdrawalBalance(creatorId, released)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings", balance.Earnings, 0)
+}
+
+func Test_WithdrawalsRepo_DecideWithdrawal(t *testing.T) {
+	artsRepo, _ := setupPurchase(t)
+	withdrawalsRepo := repositories.NewWithdrawalsRepo(testDB, 5*time.Second)
+
+	err := artsRepo.BuyArt(types.BuyArtReq{UserId: buyerId, ArtId: 1, LicenseId: 1, Price: artPrice})
+	asserts.EqualError(t, err, nil)
+
+	released := time.Now().UTC().Add(time.Hour)
+	balance, err := withdrawalsRepo.FindWithdrawalBalance(creatorId, released)
+	asserts.EqualError(t, err, nil)
+	earned := balance.Earnings
+
+	request := func() int {
+		t.Helper()
+
+		err := withdrawalsRepo.RequestWithdrawal(types.WithdrawalReq{
+			CreatorId: creatorId,
+			Amount:    earned,
+			HeldSince: released,
+		})
+		asserts.EqualError(t, err, nil)
+
+		withdrawals, err := withdrawalsRepo.FindManyWithdrawalsByCreator(creatorId)
+		asserts.EqualError(t, err, nil)
+		return int(*withdrawals[0].ID)
+	}
+	decide := func(id int, status string) error {
+		return withdrawalsRepo.DecideWithdrawal(types.WithdrawalDecisionReq{
+			Id:        id,
+			Status:    status,
+			AdminNote: status,
+			DeciderId: 2,
+			Now:       time.Now().UTC(),
+		})
+	}
+
+	id := request()
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusPaid), repositories.ErrWithdrawalStatus)
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusApproved), nil)
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusRejected), nil)
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusRejected), repositories.ErrWithdrawalStatus)
+
+	balance, err = withdrawalsRepo.FindWithdrawalBalance(creatorId, released)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings after reject", balance.Earnings, earned)
+
+	id = request()
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusApproved), nil)
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusPaid), nil)
+	asserts.EqualError(t, decide(id, types.WithdrawalStatusRejected), repositories.ErrWithdrawalStatus)
+
+	balance, err = withdrawalsRepo.FindWithdrawalBalance(creatorId, released)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings after paid", balance.Earnings, 0)
+
+	entries, err := withdrawalsRepo.FindManyEarningsEntries(creatorId)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "statement entries", len(entries), 4)
+}
+
+func Test_WithdrawalsRepo_HoldCommissionRelease(t *testing.T) {
+	_, _, commissionsRepo, id := setupCommission(t)
+	withdrawalsRepo := repositories.NewWithdrawalsRepo(testDB, 5*time.Second)
+
+	for _, req := range []types.CommissionMoveReq{
+		{ActorId: buyerId, Role: types.CommissionRoleBuyer, Status: types.CommissionStatusAccepted, Quote: commissionQuote},
+		{ActorId: creatorId, Role: types.CommissionRoleCreator, Status: types.CommissionStatusDelivered},
+		{ActorId: buyerId, Role: types.CommissionRoleBuyer, Status: types.CommissionStatusCompleted, FeePercent: 10},
+	} {
+		req.Id = id
+		asserts.EqualError(t, commissionsRepo.MoveCommission(req), nil)
+	}
+
+	onHold := time.Now().UTC().Add(-time.Hour)
+	balance, err := withdrawalsRepo.FindWithdrawalBalance(creatorId, onHold)
+	asserts.EqualError(t, err, nil)
+	asserts.Equal(t, "earnings", balance.Earnings, commissionQuote-types.PlatformFee(commissionQuote, 10))
+	asserts.Equal(t, "held", balance.Held, balance.Earnings)
+	asserts.Equal(t, "available", balance.Available, 0)
+
+	err = withdrawalsRepo.RequestWithdrawal(types.WithdrawalReq{
+		CreatorId: creatorId,
+		Amount:    balance.Earnings,
+		HeldSince: onHold,
+	})
+	asserts.EqualError(t, err, repositories.ErrNotEnoughEarnings)
+}
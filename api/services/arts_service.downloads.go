@@ -0,0 +1,35 @@
+package services
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+)
+
+// the downloads of the last hour count against the rate limit of an art
+const downloadRateWindow = time.Hour
+
+// CheckDownloadRate fails when the user has reached the rate limit of the art.
+func (s *ArtsSvc) CheckDownloadRate(userId, artId int) error {
+	since := time.Now().UTC().Add(-downloadRateWindow)
+	rate, err := s.artsRepo.FindDownloadRate(userId, artId, since)
+	if err != nil {
+		return err
+	}
+	if rate.IsReached() {
+		return repositories.ErrDownloadRateLimited
+	}
+	return nil
+}
+
+// ClaimDownload records the download of the art by the user before it is
+// sent, and fails when the user has reached one of its limits.
+func (s *ArtsSvc) ClaimDownload(userId, artId int) error {
+	since := time.Now().UTC().Add(-downloadRateWindow)
+	return s.artsRepo.ClaimDownload(userId, artId, since)
+}
+
+func (s *ArtsSvc) FindManyDownloads(userId int) ([]types.Download, error) {
+	return s.artsRepo.FindManyDownloads(userId)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/api/types"
+)
+
+// GiftArt buys the license of the art for the user named in the request.
+func (s *ArtsSvc) GiftArt(req types.GiftArtReq) error {
+	req.FeePercent = s.cfg.App.PlatformFee
+	return s.artsRepo.GiftArt(req)
+}
+
+func (s *ArtsSvc) FindManyReceivedGifts(userId int) ([]types.Gift, error) {
+	return s.artsRepo.FindManyReceivedGifts(userId)
+}
+
+// ClaimGift opens the gift and returns the id of the gifted art.
+func (s *ArtsSvc) ClaimGift(id, userId int) (int, error) {
+	return s.artsRepo.ClaimGift(id, userId, time.Now().UTC())
+}
@@ -1,17 +1,35 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/DeepAung/deep-art/.gen/model"
 	"github.com/DeepAung/deep-art/api/repositories"
 	"github.com/DeepAung/deep-art/api/types"
 	"github.com/DeepAung/deep-art/pkg/config"
 	"github.com/DeepAung/deep-art/pkg/httperror"
 	"github.com/DeepAung/deep-art/pkg/storer"
 	"github.com/DeepAung/deep-art/pkg/utils"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+const InitialVersionNote = "Initial version"
+
+// how long a purchase can be retried with the same idempotency key
+const idempotencyKeyRetention = 24 * time.Hour
+
+var (
+	ErrInvalidPublishAt = httperror.New(
+		"publish time of a scheduled art should be in the future",
+		http.StatusBadRequest,
+	)
 )
 
 type ArtsSvc struct {
@@ -36,13 +54,22 @@ func NewArtsSvc(
 // get art id
 // upload cover & files
 // update art coverURL & filesURL
-func (s *ArtsSvc) CreateArt(creatorId int, dto types.FullArtDTO) error {
+func (s *ArtsSvc) CreateArt(
+	creatorId int,
+	dto types.FullArtDTO,
+	publishAt *time.Time,
+) error {
 	ctx, cancel, tx, err := s.artsRepo.BeginTx()
 	defer cancel()
 	if err != nil {
 		return err
 	}
 
+	status, publishAt, err := checkArtStatus(dto.Status, publishAt)
+	if err != nil {
+		return err
+	}
+
 	// create art
 	createReq := types.CreateArtReq{
 		CreatorId:   creatorId,
@@ -50,6 +77,16 @@ func (s *ArtsSvc) CreateArt(creatorId int, dto types.FullArtDTO) error {
 		Description: dto.Description,
 		Price:       dto.Price,
 		TagsID:      dto.TagsID,
+		Status:      status,
+		PublishAt:   publishAt,
+		EditionSize: types.EditionSize(dto.EditionSize),
+
+		PayWhatYouWant: dto.PayWhatYouWant,
+		SuggestedPrice: types.SuggestedPrice(dto.PayWhatYouWant, dto.SuggestedPrice),
+		MembersTierId:  types.MembersTierId(dto.MembersTierId),
+
+		DownloadLimit:     types.DownloadLimit(dto.DownloadLimit),
+		DownloadRateLimit: types.DownloadLimit(dto.DownloadRateLimit),
 	}
 	artId, err := s.artsRepo.CreateArtWithDB(ctx, tx, createReq)
 	if err != nil {
@@ -57,7 +94,7 @@ func (s *ArtsSvc) CreateArt(creatorId int, dto types.FullArtDTO) error {
 	}
 
 	// upload cover
-	coverDir := fmt.Sprint("/arts/cover/", artId)
+	coverDir := fmt.Sprintf("/arts/cover/%d/v1", artId)
 	coverRes, err := uploadFile(s.storer, dto.Cover, coverDir)
 	if err != nil {
 		return err
@@ -65,7 +102,7 @@ func (s *ArtsSvc) CreateArt(creatorId int, dto types.FullArtDTO) error {
 	coverDest := coverRes.Dest()
 
 	// upload files
-	filesDir := fmt.Sprint("/arts/files/", artId)
+	filesDir := fmt.Sprintf("/arts/files/%d/v1", artId)
 	filesRes, err := uploadFiles(s.storer, dto.Files, filesDir)
 	if err != nil {
 		_ = s.storer.DeleteFile(coverDest) // rollback process
@@ -88,77 +125,167 @@ func (s *ArtsSvc) CreateArt(creatorId int, dto types.FullArtDTO) error {
 		return err
 	}
 
+	// create first version
+	if _, err := s.artsRepo.CreateArtVersionWithDB(ctx, tx, artId, InitialVersionNote); err != nil {
+		_ = s.storer.DeleteFiles(append(filesDest, coverDest)) // rollback process
+		return err
+	}
+
 	return tx.Commit()
 }
 
 func (s *ArtsSvc) UpdateArtInfo(req types.UpdateArtInfoReq) error {
+	var err error
+	req.Status, req.PublishAt, err = checkArtStatus(req.Status, req.PublishAt)
+	if err != nil {
+		return err
+	}
+
 	return s.artsRepo.UpdateArtInfo(req)
 }
 
-func (s *ArtsSvc) DeleteArt(artId int) error {
-	ctx, cancel, tx, err := s.artsRepo.BeginTx()
-	defer cancel()
+// checkArtStatus defaults the status to published. The publish time is only
+// kept for scheduled arts and it has to be in the future.
+func checkArtStatus(status string, publishAt *time.Time) (string, *time.Time, error) {
+	if status == "" {
+		status = types.ArtStatusPublished
+	}
+	if status != types.ArtStatusScheduled {
+		return status, nil, nil
+	}
+	if publishAt == nil || !publishAt.After(time.Now()) {
+		return "", nil, ErrInvalidPublishAt
+	}
+
+	return status, publishAt, nil
+}
+
+func (s *ArtsSvc) PublishScheduledArts() error {
+	ids, err := s.artsRepo.PublishScheduledArts(time.Now().UTC())
 	if err != nil {
 		return err
 	}
+	if len(ids) > 0 {
+		slog.Info("published scheduled arts", "ids", ids)
+	}
+
+	return nil
+}
+
+// CanView reports whether the user can open the art's page. Drafts and
+// scheduled arts are only visible to the creator. Archived and deleted arts
+// are only visible to the creator and the buyers.
+func (s *ArtsSvc) CanView(userId int, art types.Art) (bool, error) {
+	if int(art.CreatorID) == userId || art.IsPublic() {
+		return true, nil
+	}
+	if art.Status == types.ArtStatusArchived || art.DeletedAt != nil {
+		return s.IsBought(userId, int(*art.ID))
+	}
+
+	return false, nil
+}
+
+// DeleteArt moves the art to the creator's trash. The art can be restored
+// within the trash retention, after that it is purged if nobody bought it.
+func (s *ArtsSvc) DeleteArt(artId int) error {
+	return s.artsRepo.SoftDeleteArt(artId, time.Now().UTC())
+}
+
+func (s *ArtsSvc) RestoreArt(artId int) error {
+	return s.artsRepo.RestoreArt(artId, time.Now().UTC().Add(-s.cfg.App.TrashRetention))
+}
+
+func (s *ArtsSvc) TrashRetention() time.Duration {
+	return s.cfg.App.TrashRetention
+}
 
-	// if we delete art all files linked to art will be deleted too
-	// so we get all linked files and art's cover before deleting the art
-	files, err := s.artsRepo.FindManyFilesByArtId(artId)
+func (s *ArtsSvc) FindManyDeletedArts(creatorId int) ([]model.Arts, error) {
+	return s.artsRepo.FindManyDeletedArts(creatorId)
+}
+
+// PurgeDeletedArts permanently deletes the arts that stayed in the trash
+// longer than the trash retention. Bought arts are kept for their buyers.
+func (s *ArtsSvc) PurgeDeletedArts() error {
+	ids, err := s.artsRepo.FindManyPurgeableArtIds(
+		time.Now().UTC().Add(-s.cfg.App.TrashRetention),
+	)
 	if err != nil {
 		return err
 	}
-	coverURL, err := s.artsRepo.FindOneCoverURL(artId)
+
+	for _, id := range ids {
+		if err := s.purgeArt(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ArtsSvc) purgeArt(artId int) error {
+	ctx, cancel, tx, err := s.artsRepo.BeginTx()
+	defer cancel()
 	if err != nil {
 		return err
 	}
 
-	// delete art
-	if err := s.artsRepo.DeleteArtWithDB(ctx, tx, artId); err != nil {
+	// if we delete art all files and versions linked to art will be deleted too
+	// so we get every url that was used by any version before deleting the art
+	urls, err := s.artsRepo.FindManyArtVersionsURLs(artId)
+	if err != nil {
 		return err
 	}
 
-	// delete files
-	var filesDest []string
-	for _, file := range files {
-		fileInfo := utils.NewUrlInfoByURL(s.cfg.App.BasePath, file.URL)
-		filesDest = append(filesDest, fileInfo.Dest())
-	}
-	if err := s.storer.DeleteFiles(filesDest); err != nil {
+	// delete art
+	if err := s.artsRepo.DeleteArtWithDB(ctx, tx, artId); err != nil {
 		return err
 	}
 
-	// delete cover
-	coverInfo := utils.NewUrlInfoByURL(s.cfg.App.BasePath, coverURL)
-	if err := s.storer.DeleteFile(coverInfo.Dest()); err != nil {
+	// delete files and covers of every version
+	dests := utils.Map(urls, func(url string) string {
+		return utils.NewUrlInfoByURL(s.cfg.App.BasePath, url).Dest()
+	})
+	if err := s.storer.DeleteFiles(dests); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
-func (s *ArtsSvc) UploadFiles(artId int, files []*multipart.FileHeader) error {
+// Every change of files or cover creates a new version of the art. Blobs of
+// each version live in their own "v{n}" folder, so the older versions stay
+// downloadable after the change.
+func (s *ArtsSvc) UploadFiles(artId int, files []*multipart.FileHeader, note string) error {
 	ctx, cancel, tx, err := s.artsRepo.BeginTx()
 	defer cancel()
 	if err != nil {
 		return err
 	}
 
-	filesDir := fmt.Sprint("/arts/files/", artId)
+	version, err := s.artsRepo.FindNextArtVersionWithDB(ctx, tx, artId)
+	if err != nil {
+		return err
+	}
+
+	filesDir := fmt.Sprintf("/arts/files/%d/v%d", artId, version)
 	filesInfo := utils.Map(files, func(file *multipart.FileHeader) storer.FileRes {
 		return utils.NewUrlInfoByDest(s.cfg.App.BasePath, filesDir+"/"+file.Filename)
 	})
 	filesURL := utils.Map(filesInfo, func(file storer.FileRes) string { return file.Url() })
 	filesName := utils.Map(filesInfo, func(file storer.FileRes) string { return file.Filename() })
 
-	fmt.Println("artId: ", artId)
-	fmt.Println("filesURL: ", filesURL)
-	fmt.Println("filesName: ", filesName)
-
 	if err := s.artsRepo.InsertArtFilesWithDB(ctx, tx, artId, filesURL, filesName); err != nil {
 		return err
 	}
 
+	if note == "" {
+		note = fmt.Sprintf("Added %s", strings.Join(filesName, ", "))
+	}
+	if err := s.createVersion(ctx, tx, artId, note); err != nil {
+		return err
+	}
+
 	if _, err := uploadFiles(s.storer, files, filesDir); err != nil {
 		return err
 	}
@@ -166,54 +293,63 @@ func (s *ArtsSvc) UploadFiles(artId int, files []*multipart.FileHeader) error {
 	return tx.Commit()
 }
 
-func (s *ArtsSvc) DeleteFile(artId, fileId int) error {
+func (s *ArtsSvc) DeleteFile(artId, fileId int, note string) error {
 	ctx, cancel, tx, err := s.artsRepo.BeginTx()
 	defer cancel()
 	if err != nil {
 		return err
 	}
 
-	if err := s.artsRepo.DeleteArtFilesWithDB(ctx, tx, fileId); err != nil {
+	file, err := s.artsRepo.FindOneFile(fileId)
+	if err != nil {
 		return err
 	}
+	if int(file.ArtID) != artId {
+		return repositories.ErrNotFound("file")
+	}
 
-	file, err := s.artsRepo.FindOneFile(fileId)
-	if err != nil {
+	// the blob is kept since the older versions still refer to it
+	if err := s.artsRepo.DeleteArtFilesWithDB(ctx, tx, fileId); err != nil {
 		return err
 	}
 
-	fileInfo := utils.NewUrlInfoByURL(s.cfg.App.BasePath, file.URL)
-	if err := s.storer.DeleteFile(fileInfo.Dest()); err != nil {
-		// there is no image but we want to delete it. so just do nothing
-		return tx.Commit()
+	if note == "" {
+		note = fmt.Sprintf("Removed %s", file.Filename)
+	}
+	if err := s.createVersion(ctx, tx, artId, note); err != nil {
+		return err
 	}
 
 	return tx.Commit()
 }
 
-func (s *ArtsSvc) ReplaceCover(artId int, cover *multipart.FileHeader) error {
+func (s *ArtsSvc) ReplaceCover(artId int, cover *multipart.FileHeader, note string) error {
 	ctx, cancel, tx, err := s.artsRepo.BeginTx()
 	defer cancel()
 	if err != nil {
 		return err
 	}
 
-	oldCoverURL, err := s.artsRepo.FindOneCoverURL(artId)
+	version, err := s.artsRepo.FindNextArtVersionWithDB(ctx, tx, artId)
 	if err != nil {
 		return err
 	}
-	oldCoverInfo := utils.NewUrlInfoByURL(s.cfg.App.BasePath, oldCoverURL)
 
-	newCoverDest := fmt.Sprintf("/arts/cover/%d/%s", artId, cover.Filename)
+	// the old cover is kept since the older versions still refer to it
+	newCoverDest := fmt.Sprintf("/arts/cover/%d/v%d/%s", artId, version, cover.Filename)
 	newCoverInfo := utils.NewUrlInfoByDest(s.cfg.App.BasePath, newCoverDest)
 
 	if err := s.artsRepo.UpdateArtCoverWithDB(ctx, tx, artId, newCoverInfo.Url()); err != nil {
 		return err
 	}
 
-	if err := s.storer.DeleteFile(oldCoverInfo.Dest()); err != nil {
+	if note == "" {
+		note = "Replaced cover"
+	}
+	if err := s.createVersion(ctx, tx, artId, note); err != nil {
 		return err
 	}
+
 	if _, err := uploadFile(s.storer, cover, newCoverInfo.Dir()); err != nil {
 		return err
 	}
@@ -221,6 +357,33 @@ func (s *ArtsSvc) ReplaceCover(artId int, cover *multipart.FileHeader) error {
 	return tx.Commit()
 }
 
+func (s *ArtsSvc) createVersion(ctx context.Context, db qrm.DB, artId int, note string) error {
+	version, err := s.artsRepo.CreateArtVersionWithDB(ctx, db, artId, note)
+	if err != nil {
+		return err
+	}
+
+	return s.artsRepo.NotifyFollowersAndBuyersWithDB(
+		ctx,
+		db,
+		artId,
+		fmt.Sprintf("Art #%d has been updated to v%d: %s", artId, version, note),
+		fmt.Sprint("/arts/", artId),
+	)
+}
+
+func (s *ArtsSvc) FindManyArtVersions(artId int) ([]model.ArtVersions, error) {
+	return s.artsRepo.FindManyArtVersions(artId)
+}
+
+func (s *ArtsSvc) FindOneArtVersion(artId, version int) (types.ArtVersion, error) {
+	return s.artsRepo.FindOneArtVersion(artId, version)
+}
+
+func (s *ArtsSvc) FindLatestArtVersion(artId int) (types.ArtVersion, error) {
+	return s.artsRepo.FindLatestArtVersion(artId)
+}
+
 func (s *ArtsSvc) FindManyArts(req types.ManyArtsReq) (types.ManyArtsRes, error) {
 	return s.artsRepo.FindManyArts(req)
 }
@@ -250,34 +413,31 @@ func (s *ArtsSvc) FindOneArt(id int) (types.Art, error) {
 	return s.artsRepo.FindOneArt(id)
 }
 
-func (s *ArtsSvc) AddDownloadedArt(artId int) error {
-	return s.artsRepo.InsertDownloadedArt(artId)
+// BuyArt buys the license of the art. If the user already holds a cheaper
+// license of the art, the license is upgraded and only the difference is paid.
+func (s *ArtsSvc) BuyArt(req types.BuyArtReq) error {
+	req.FeePercent = s.cfg.App.PlatformFee
+	req.CouponCode = types.NormalizeCouponCode(req.CouponCode)
+	req.Now = time.Now().UTC()
+	return s.artsRepo.BuyArt(req)
 }
 
-func (s *ArtsSvc) BuyArt(userId, artId, price int) error {
-	bought, err := s.artsRepo.HasUsersBoughtArts(userId, artId)
-	if err != nil {
-		return err
-	}
-	if bought {
-		return httperror.New("user already bought this art", http.StatusBadRequest)
-	}
-
-	coin, err := s.artsRepo.FindUserCoin(userId)
-	if err != nil {
-		return err
-	}
-	if coin < price {
-		return httperror.New("not enough coin to buy this art", http.StatusBadRequest)
-	}
-
-	return s.artsRepo.BuyArt(userId, artId, price)
+// PurgeIdempotencyKeys deletes the idempotency keys of purchases that are too
+// old to be retried.
+func (s *ArtsSvc) PurgeIdempotencyKeys() error {
+	return s.artsRepo.PurgeIdempotencyKeys(time.Now().UTC().Add(-idempotencyKeyRetention))
 }
 
 func (s *ArtsSvc) IsBought(userId, artId int) (bool, error) {
 	return s.artsRepo.HasUsersBoughtArts(userId, artId)
 }
 
+// HasMembersAccess reports whether the user can download the members-only
+// art through a subscription.
+func (s *ArtsSvc) HasMembersAccess(userId, artId int) (bool, error) {
+	return s.artsRepo.HasMembersAccess(userId, artId, time.Now().UTC())
+}
+
 func (s *ArtsSvc) ToggleStar(userId, artId int) (bool, error) {
 	isStarred, err := s.IsStarred(userId, artId)
 	if err != nil {
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrLastLicense = httperror.New(
+		"an art should have at least one license",
+		http.StatusBadRequest,
+	)
+	ErrLicenseHasHolders = httperror.New(
+		"this license is held by buyers and cannot be deleted",
+		http.StatusBadRequest,
+	)
+)
+
+func (s *ArtsSvc) FindManyArtLicenses(artId int) ([]model.ArtLicenses, error) {
+	return s.artsRepo.FindManyArtLicenses(artId)
+}
+
+func (s *ArtsSvc) FindBoughtLicense(userId, artId int) (types.BoughtLicense, error) {
+	return s.artsRepo.FindBoughtLicense(userId, artId)
+}
+
+func (s *ArtsSvc) CreateArtLicense(artId int, dto types.ArtLicenseDTO) error {
+	return s.artsRepo.CreateArtLicense(newArtLicenseReq(artId, 0, dto))
+}
+
+func (s *ArtsSvc) UpdateArtLicense(artId, licenseId int, dto types.ArtLicenseDTO) error {
+	return s.artsRepo.UpdateArtLicense(newArtLicenseReq(artId, licenseId, dto))
+}
+
+// DeleteArtLicense deletes the license unless it is the last license of the
+// art or somebody has bought it.
+func (s *ArtsSvc) DeleteArtLicense(artId, licenseId int) error {
+	licenses, err := s.artsRepo.FindManyArtLicenses(artId)
+	if err != nil {
+		return err
+	}
+	if len(licenses) <= 1 {
+		return ErrLastLicense
+	}
+
+	hasHolders, err := s.artsRepo.HasLicenseHolders(licenseId)
+	if err != nil {
+		return err
+	}
+	if hasHolders {
+		return ErrLicenseHasHolders
+	}
+
+	return s.artsRepo.DeleteArtLicense(artId, licenseId)
+}
+
+func newArtLicenseReq(artId, licenseId int, dto types.ArtLicenseDTO) types.ArtLicenseReq {
+	var downloadCap *int
+	if dto.DownloadCap > 0 {
+		downloadCap = &dto.DownloadCap
+	}
+
+	return types.ArtLicenseReq{
+		ArtId:       artId,
+		LicenseId:   licenseId,
+		Name:        dto.Name,
+		Price:       dto.Price,
+		Terms:       dto.Terms,
+		DownloadCap: downloadCap,
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrInvalidAuctionEnd = httperror.New(
+		"an auction should end in the future",
+		http.StatusBadRequest,
+	)
+	ErrInvalidReservePrice = httperror.New(
+		"the reserve price should not be lower than the start price",
+		http.StatusBadRequest,
+	)
+)
+
+type AuctionsSvc struct {
+	auctionsRepo *repositories.AuctionsRepo
+	cfg          *config.Config
+}
+
+func NewAuctionsSvc(auctionsRepo *repositories.AuctionsRepo, cfg *config.Config) *AuctionsSvc {
+	return &AuctionsSvc{
+		auctionsRepo: auctionsRepo,
+		cfg:          cfg,
+	}
+}
+
+func (s *AuctionsSvc) CreateAuction(artId, creatorId int, dto types.AuctionDTO) error {
+	if !dto.EndsAt.After(time.Now().UTC()) {
+		return ErrInvalidAuctionEnd
+	}
+
+	var reservePrice *int
+	if dto.ReservePrice > 0 {
+		if dto.ReservePrice < dto.StartPrice {
+			return ErrInvalidReservePrice
+		}
+		reservePrice = &dto.ReservePrice
+	}
+
+	return s.auctionsRepo.CreateAuction(types.AuctionReq{
+		ArtId:            artId,
+		CreatorId:        creatorId,
+		LicenseId:        dto.LicenseId,
+		StartPrice:       dto.StartPrice,
+		MinIncrement:     dto.MinIncrement,
+		ReservePrice:     reservePrice,
+		EndsAt:           dto.EndsAt.Time,
+		ExtensionSeconds: dto.ExtensionMinutes * 60,
+	})
+}
+
+func (s *AuctionsSvc) CancelAuction(creatorId, artId int) error {
+	return s.auctionsRepo.CancelAuction(creatorId, artId)
+}
+
+func (s *AuctionsSvc) FindLatestAuction(artId int) (*types.Auction, error) {
+	return s.auctionsRepo.FindLatestAuction(artId)
+}
+
+func (s *AuctionsSvc) PlaceBid(artId, userId int, dto types.BidDTO) error {
+	return s.auctionsRepo.PlaceBid(types.BidReq{
+		ArtId:  artId,
+		UserId: userId,
+		Amount: dto.Amount,
+		Now:    time.Now().UTC(),
+	})
+}
+
+// CloseAuctions settles the auctions that ended. An auction that fails to
+// close is retried on the next run.
+func (s *AuctionsSvc) CloseAuctions() error {
+	now := time.Now().UTC()
+	auctions, err := s.auctionsRepo.FindManyDueAuctions(now)
+	if err != nil {
+		return err
+	}
+
+	for _, auction := range auctions {
+		err := s.auctionsRepo.CloseAuction(int(*auction.ID), s.cfg.App.PlatformFee, now)
+		if err != nil {
+			slog.Error("close auction", "id", *auction.ID, "err", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrInvalidBundleArts = httperror.New(
+		"a bundle should contain at least two of your arts",
+		http.StatusBadRequest,
+	)
+	ErrBundlePriceTooHigh = httperror.New(
+		"a bundle should be cheaper than buying its arts separately",
+		http.StatusBadRequest,
+	)
+)
+
+type BundlesSvc struct {
+	bundlesRepo *repositories.BundlesRepo
+	artsRepo    *repositories.ArtsRepo
+	cfg         *config.Config
+}
+
+func NewBundlesSvc(
+	bundlesRepo *repositories.BundlesRepo,
+	artsRepo *repositories.ArtsRepo,
+	cfg *config.Config,
+) *BundlesSvc {
+	return &BundlesSvc{
+		bundlesRepo: bundlesRepo,
+		artsRepo:    artsRepo,
+		cfg:         cfg,
+	}
+}
+
+func (s *BundlesSvc) FindOneBundle(id int) (types.Bundle, error) {
+	return s.bundlesRepo.FindOneBundle(id)
+}
+
+func (s *BundlesSvc) FindManyCreatedBundles(creatorId int) ([]types.Bundle, error) {
+	return s.bundlesRepo.FindManyCreatedBundles(creatorId)
+}
+
+// FindManyBundles returns the bundles shown on top of the search results.
+// Bundles have no tags, so they are left out when searching by tags.
+func (s *BundlesSvc) FindManyBundles(req types.ManyArtsReq) ([]types.Bundle, error) {
+	if req.Pagination.Page > 1 || len(req.Filter.Tags) > 0 {
+		return nil, nil
+	}
+
+	return s.bundlesRepo.FindManyBundles(req)
+}
+
+func (s *BundlesSvc) FindManyBundleableArts(creatorId int) ([]model.Arts, error) {
+	return s.bundlesRepo.FindManyBundleableArts(creatorId)
+}
+
+func (s *BundlesSvc) IsForSale(bundleId int) (bool, error) {
+	return s.bundlesRepo.IsBundleForSale(bundleId)
+}
+
+func (s *BundlesSvc) FindOwnedArtsID(userId, bundleId int) (map[int]bool, error) {
+	return s.bundlesRepo.FindOwnedArtsID(userId, bundleId)
+}
+
+func (s *BundlesSvc) CreateBundle(creatorId int, dto types.BundleDTO) (int, error) {
+	req := newBundleReq(0, creatorId, dto)
+	if err := s.checkBundle(req); err != nil {
+		return 0, err
+	}
+
+	return s.bundlesRepo.CreateBundle(req)
+}
+
+func (s *BundlesSvc) UpdateBundle(bundleId, creatorId int, dto types.BundleDTO) error {
+	req := newBundleReq(bundleId, creatorId, dto)
+	if err := s.checkBundle(req); err != nil {
+		return err
+	}
+
+	return s.bundlesRepo.UpdateBundle(req)
+}
+
+func (s *BundlesSvc) DeleteBundle(creatorId, bundleId int) error {
+	return s.bundlesRepo.DeleteBundle(creatorId, bundleId)
+}
+
+// checkBundle checks that every art belongs to the creator and that the
+// bundle is cheaper than its arts.
+func (s *BundlesSvc) checkBundle(req types.BundleReq) error {
+	if len(req.ArtsID) < 2 {
+		return ErrInvalidBundleArts
+	}
+
+	count, total, err := s.bundlesRepo.CountCreatedArts(req.CreatorId, req.ArtsID)
+	if err != nil {
+		return err
+	}
+	if count != len(req.ArtsID) {
+		return ErrInvalidBundleArts
+	}
+	if req.Price >= total {
+		return ErrBundlePriceTooHigh
+	}
+
+	return nil
+}
+
+// BuyBundle buys every art of the bundle that the user does not own yet. The
+// price is what the user expects to pay.
+func (s *BundlesSvc) BuyBundle(userId, bundleId, price int) error {
+	return s.bundlesRepo.BuyBundle(userId, bundleId, price, s.cfg.App.PlatformFee)
+}
+
+func newBundleReq(bundleId, creatorId int, dto types.BundleDTO) types.BundleReq {
+	return types.BundleReq{
+		BundleId:    bundleId,
+		CreatorId:   creatorId,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Price:       dto.Price,
+		ArtsID:      dto.ArtsID,
+	}
+}
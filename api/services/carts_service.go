@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+)
+
+type CartsSvc struct {
+	cartsRepo *repositories.CartsRepo
+	cfg       *config.Config
+}
+
+func NewCartsSvc(cartsRepo *repositories.CartsRepo, cfg *config.Config) *CartsSvc {
+	return &CartsSvc{
+		cartsRepo: cartsRepo,
+		cfg:       cfg,
+	}
+}
+
+func (s *CartsSvc) AddCartItem(userId int, dto types.CartItemDTO) error {
+	return s.cartsRepo.AddCartItem(userId, dto.ArtId, dto.LicenseId)
+}
+
+func (s *CartsSvc) RemoveCartItem(userId, artId int) error {
+	return s.cartsRepo.RemoveCartItem(userId, artId)
+}
+
+func (s *CartsSvc) FindCart(userId int) (types.Cart, error) {
+	return s.cartsRepo.FindCart(userId)
+}
+
+func (s *CartsSvc) CountCartItems(userId int) (int, error) {
+	return s.cartsRepo.CountCartItems(userId)
+}
+
+func (s *CartsSvc) Checkout(userId int, dto types.CheckoutDTO) (int, error) {
+	return s.cartsRepo.Checkout(types.CheckoutReq{
+		UserId:     userId,
+		Total:      dto.Total,
+		FeePercent: s.cfg.App.PlatformFee,
+	})
+}
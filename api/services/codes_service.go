@@ -1,7 +1,11 @@
 package services
 
 import (
+	"crypto/rand"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DeepAung/deep-art/.gen/model"
@@ -12,8 +16,18 @@ import (
 )
 
 var (
-	ErrCodeExpired = httperror.New("code has already expired", http.StatusBadRequest)
-	ErrCodeUsed    = httperror.New("code has already used", http.StatusBadRequest)
+	ErrInvalidCodeTime  = httperror.New("code should expire after it starts", http.StatusBadRequest)
+	ErrInvalidCodeUsers = httperror.New(
+		"users should be a comma separated list of existing user ids",
+		http.StatusBadRequest,
+	)
+	ErrCodeBatchExists = httperror.New("code batch should be unique", http.StatusBadRequest)
+)
+
+// codeAlphabet leaves out the letters and digits that are easy to mix up.
+const (
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 10
 )
 
 type CodesSvc struct {
@@ -28,53 +42,193 @@ func NewCodesSvc(codesRepo *repositories.CodesRepo, cfg *config.Config) *CodesSv
 	}
 }
 
-func (s *CodesSvc) UseCode(userId int, name string) error {
-	// 1. check if code exist
-	code, err := s.codesRepo.FindOneCodeByName(name)
+func (s *CodesSvc) FindOneCodeByName(name string) (model.Codes, error) {
+	return s.codesRepo.FindOneCodeByName(name)
+}
+
+func (s *CodesSvc) UseCode(userId, codeId int) error {
+	now := time.Now().UTC()
+	return s.codesRepo.UseCode(types.UseCodeReq{
+		UserId:       userId,
+		CodeId:       codeId,
+		Now:          now,
+		NewUserSince: now.Add(-s.cfg.App.NewUserWindow),
+	})
+}
+
+func (s *CodesSvc) CreateCode(dto types.CodeDTO) (types.Code, error) {
+	rules, err := s.newCodeRules(dto.CodeRulesDTO)
 	if err != nil {
-		return err
+		return types.Code{}, err
 	}
 
-	// 2. check if code is expired
-	if code.ExpTime.Before(time.Now()) {
-		return ErrCodeExpired
+	id, err := s.codesRepo.CreateCode(types.CodeReq{Name: dto.Name, CodeRules: rules})
+	if err != nil {
+		return types.Code{}, err
 	}
 
-	// 3. check if code is used
-	used, err := s.codesRepo.HasUsedCode(userId, int(*code.ID))
+	return s.codesRepo.FindOneCodeById(id)
+}
+
+// CreateCodeBatch creates the given number of random codes that start with
+// the name of the batch.
+func (s *CodesSvc) CreateCodeBatch(dto types.CodeBatchDTO) error {
+	rules, err := s.newCodeRules(dto.CodeRulesDTO)
 	if err != nil {
 		return err
 	}
-	if used {
-		return ErrCodeUsed
+
+	batch := strings.ToUpper(dto.Batch)
+	exists, err := s.codesRepo.HasCodeBatch(batch)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCodeBatchExists
+	}
+
+	names := make([]string, 0, dto.Count)
+	seen := make(map[string]bool, dto.Count)
+	for len(names) < dto.Count {
+		code, err := randomCode()
+		if err != nil {
+			return err
+		}
+		if name := batch + "-" + code; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
 	}
 
-	// 4. use the code
-	return s.codesRepo.UseCode(userId, int(*code.ID))
+	return s.codesRepo.CreateCodeBatch(types.CodeBatchReq{
+		Batch:     batch,
+		Names:     names,
+		CodeRules: rules,
+	})
 }
 
-func (s *CodesSvc) CreateCode(req types.CodeReq) (model.Codes, error) {
-	return s.codesRepo.CreateCode(req)
+// GetCodes returns the codes that are not part of a batch.
+func (s *CodesSvc) GetCodes() ([]types.Code, error) {
+	return s.codesRepo.FindManyCodes("")
 }
 
-func (s *CodesSvc) GetCodes() ([]model.Codes, error) {
-	return s.codesRepo.FindAllCodes()
+func (s *CodesSvc) GetCodeBatches() ([]types.CodeBatch, error) {
+	return s.codesRepo.FindManyCodeBatches()
 }
 
-func (s *CodesSvc) UpdateCode(id int, req types.CodeReq) (model.Codes, error) {
-	if err := s.codesRepo.UpdateCode(id, req); err != nil {
-		return model.Codes{}, err
+func (s *CodesSvc) GetBatchCodes(batch string) ([]types.Code, error) {
+	if batch == "" {
+		return nil, repositories.ErrNotFound("code batch")
+	}
+
+	codes, err := s.codesRepo.FindManyCodes(batch)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, repositories.ErrNotFound("code batch")
 	}
 
-	ID := int32(id)
-	return model.Codes{
-		ID:      &ID,
-		Name:    req.Name,
-		Value:   int32(req.Value),
-		ExpTime: req.ExpTime.Time,
-	}, nil
+	return codes, nil
+}
+
+func (s *CodesSvc) UpdateCode(id int, dto types.CodeDTO) (types.Code, error) {
+	rules, err := s.newCodeRules(dto.CodeRulesDTO)
+	if err != nil {
+		return types.Code{}, err
+	}
+
+	if err := s.codesRepo.UpdateCode(id, types.CodeReq{Name: dto.Name, CodeRules: rules}); err != nil {
+		return types.Code{}, err
+	}
+
+	return s.codesRepo.FindOneCodeById(id)
 }
 
 func (s *CodesSvc) DeleteCode(id int) error {
 	return s.codesRepo.DeleteCode(id)
 }
+
+// newCodeRules parses the rules of the form, fills in the defaults and only
+// keeps the users when the code is restricted to them.
+func (s *CodesSvc) newCodeRules(dto types.CodeRulesDTO) (types.CodeRules, error) {
+	rules, err := NewCodeRules(dto)
+	if err != nil {
+		return types.CodeRules{}, err
+	}
+
+	if rules.Segment == types.CodeSegmentUsers {
+		count, err := s.codesRepo.CountUsers(rules.UsersID)
+		if err != nil {
+			return types.CodeRules{}, err
+		}
+		if count != len(rules.UsersID) {
+			return types.CodeRules{}, ErrInvalidCodeUsers
+		}
+	}
+
+	return rules, nil
+}
+
+// NewCodeRules parses the rules of the form without looking them up in the
+// database.
+func NewCodeRules(dto types.CodeRulesDTO) (types.CodeRules, error) {
+	rules := types.CodeRules{
+		Value:        dto.Value,
+		ExpTime:      dto.ExpTime.Time,
+		PerUserLimit: max(dto.PerUserLimit, 1),
+		Disabled:     dto.Disabled,
+		Segment:      dto.Segment,
+	}
+
+	if dto.StartsAt != "" {
+		var startsAt types.CustomTime
+		if err := startsAt.UnmarshalParam(dto.StartsAt); err != nil {
+			return types.CodeRules{}, err
+		}
+		if !rules.ExpTime.After(startsAt.Time) {
+			return types.CodeRules{}, ErrInvalidCodeTime
+		}
+		rules.StartsAt = &startsAt.Time
+	}
+	if dto.MaxUses > 0 {
+		rules.MaxUses = &dto.MaxUses
+	}
+
+	if rules.Segment == "" {
+		rules.Segment = types.CodeSegmentAll
+	}
+	if rules.Segment == types.CodeSegmentUsers {
+		for _, s := range strings.Split(dto.UsersID, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			id, err := strconv.Atoi(s)
+			if err != nil {
+				return types.CodeRules{}, ErrInvalidCodeUsers
+			}
+			if !slices.Contains(rules.UsersID, id) {
+				rules.UsersID = append(rules.UsersID, id)
+			}
+		}
+		if len(rules.UsersID) == 0 {
+			return types.CodeRules{}, ErrInvalidCodeUsers
+		}
+	}
+
+	return rules, nil
+}
+
+// randomCode returns a random string of the alphabet. The alphabet has 32
+// characters, which divides 256, so every character is as likely.
+func randomCode() (string, error) {
+	b := make([]byte, codeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b), nil
+}
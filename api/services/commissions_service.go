@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/storer"
+	"github.com/DeepAung/deep-art/pkg/utils"
+)
+
+type CommissionsSvc struct {
+	commissionsRepo *repositories.CommissionsRepo
+	storer          storer.Storer
+	cfg             *config.Config
+}
+
+func NewCommissionsSvc(
+	commissionsRepo *repositories.CommissionsRepo,
+	storer storer.Storer,
+	cfg *config.Config,
+) *CommissionsSvc {
+	return &CommissionsSvc{
+		commissionsRepo: commissionsRepo,
+		storer:          storer,
+		cfg:             cfg,
+	}
+}
+
+func (s *CommissionsSvc) CreateCommission(buyerId, creatorId int, dto types.CommissionDTO) error {
+	return s.commissionsRepo.CreateCommission(types.CommissionReq{
+		BuyerId:   buyerId,
+		CreatorId: creatorId,
+		Brief:     dto.Brief,
+		Budget:    dto.Budget,
+	})
+}
+
+func (s *CommissionsSvc) FindManyCommissions(userId int) ([]types.Commission, error) {
+	return s.commissionsRepo.FindManyCommissions(userId)
+}
+
+func (s *CommissionsSvc) FindAllDisputedCommissions() ([]types.Commission, error) {
+	return s.commissionsRepo.FindAllDisputedCommissions()
+}
+
+func (s *CommissionsSvc) FindOneCommission(
+	id, userId int,
+	isAdmin bool,
+) (types.CommissionDetail, error) {
+	return s.commissionsRepo.FindOneCommission(id, userId, isAdmin)
+}
+
+func (s *CommissionsSvc) QuoteCommission(id, creatorId int, dto types.CommissionQuoteDTO) error {
+	return s.commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: creatorId,
+		Role:    types.CommissionRoleCreator,
+		Status:  types.CommissionStatusQuoted,
+		Quote:   dto.Quote,
+		Note:    dto.Note,
+	})
+}
+
+// AcceptCommission holds the quote in escrow when it is still the quote the
+// buyer accepted. The commission is due within the delivery window.
+func (s *CommissionsSvc) AcceptCommission(id, buyerId int, dto types.CommissionAcceptDTO) error {
+	return s.commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:      id,
+		ActorId: buyerId,
+		Role:    types.CommissionRoleBuyer,
+		Status:  types.CommissionStatusAccepted,
+		Quote:   dto.Quote,
+		Note:    dto.Note,
+		DueAt:   time.Now().Add(s.cfg.App.CommissionDeliveryWindow),
+	})
+}
+
+// MoveCommission moves the commission to a status that needs no quote or
+// files. A completed commission releases the escrow to the creator, less the
+// platform fee.
+func (s *CommissionsSvc) MoveCommission(id, actorId int, role, status, note string) error {
+	return s.commissionsRepo.MoveCommission(types.CommissionMoveReq{
+		Id:         id,
+		ActorId:    actorId,
+		Role:       role,
+		Status:     status,
+		Note:       note,
+		FeePercent: s.cfg.App.PlatformFee,
+	})
+}
+
+// DeliverCommission uploads the files of the creator and marks the
+// commission delivered, for the buyer to accept or dispute.
+func (s *CommissionsSvc) DeliverCommission(
+	id, creatorId int,
+	files []*multipart.FileHeader,
+	note string,
+) error {
+	ctx, cancel, tx, err := s.commissionsRepo.BeginTx()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	filesDir := fmt.Sprintf("/commissions/%d", id)
+	filesReq := utils.Map(files, func(file *multipart.FileHeader) types.CommissionFileReq {
+		info := utils.NewUrlInfoByDest(s.cfg.App.BasePath, filesDir+"/"+file.Filename)
+		return types.CommissionFileReq{Filename: info.Filename(), URL: info.Url()}
+	})
+
+	err = s.commissionsRepo.MoveCommissionWithDB(ctx, tx, types.CommissionMoveReq{
+		Id:      id,
+		ActorId: creatorId,
+		Role:    types.CommissionRoleCreator,
+		Status:  types.CommissionStatusDelivered,
+		Note:    note,
+		Files:   filesReq,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := uploadFiles(s.storer, files, filesDir); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
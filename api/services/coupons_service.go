@@ -0,0 +1,126 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrInvalidCouponArts = httperror.New(
+		"a coupon for arts should contain at least one of your arts",
+		http.StatusBadRequest,
+	)
+	ErrInvalidCouponTags = httperror.New(
+		"a coupon for tags should contain at least one tag",
+		http.StatusBadRequest,
+	)
+	ErrInvalidCouponValue = httperror.New(
+		"a percentage coupon cannot take more than 100% off",
+		http.StatusBadRequest,
+	)
+	ErrInvalidCouponExpTime = httperror.New(
+		"the expiry of the coupon should be in the future",
+		http.StatusBadRequest,
+	)
+)
+
+type CouponsSvc struct {
+	couponsRepo *repositories.CouponsRepo
+}
+
+func NewCouponsSvc(couponsRepo *repositories.CouponsRepo) *CouponsSvc {
+	return &CouponsSvc{
+		couponsRepo: couponsRepo,
+	}
+}
+
+func (s *CouponsSvc) FindManyCreatedCoupons(creatorId int) ([]types.Coupon, error) {
+	return s.couponsRepo.FindManyCreatedCoupons(creatorId)
+}
+
+// FindApplicableCoupon finds the coupon that the user entered for the art.
+func (s *CouponsSvc) FindApplicableCoupon(artId int, code string) (types.Coupon, error) {
+	return s.couponsRepo.FindApplicableCoupon(artId, code, time.Now().UTC())
+}
+
+func (s *CouponsSvc) CreateCoupon(creatorId int, dto types.CouponDTO) error {
+	req, err := s.newCouponReq(0, creatorId, dto)
+	if err != nil {
+		return err
+	}
+
+	return s.couponsRepo.CreateCoupon(req)
+}
+
+func (s *CouponsSvc) UpdateCoupon(couponId, creatorId int, dto types.CouponDTO) error {
+	req, err := s.newCouponReq(couponId, creatorId, dto)
+	if err != nil {
+		return err
+	}
+
+	return s.couponsRepo.UpdateCoupon(req)
+}
+
+func (s *CouponsSvc) DeleteCoupon(creatorId, couponId int) error {
+	return s.couponsRepo.DeleteCoupon(creatorId, couponId)
+}
+
+// newCouponReq checks the coupon and only keeps the arts or the tags of its
+// scope.
+func (s *CouponsSvc) newCouponReq(
+	couponId, creatorId int,
+	dto types.CouponDTO,
+) (types.CouponReq, error) {
+	req := types.CouponReq{
+		CouponId:  couponId,
+		CreatorId: creatorId,
+		Code:      types.NormalizeCouponCode(dto.Code),
+		Kind:      dto.Kind,
+		Value:     dto.Value,
+		Scope:     dto.Scope,
+	}
+
+	if req.Kind == types.CouponKindPercent && req.Value > 100 {
+		return types.CouponReq{}, ErrInvalidCouponValue
+	}
+
+	switch req.Scope {
+	case types.CouponScopeArts:
+		if len(dto.ArtsID) == 0 {
+			return types.CouponReq{}, ErrInvalidCouponArts
+		}
+		count, err := s.couponsRepo.CountCreatedArts(creatorId, dto.ArtsID)
+		if err != nil {
+			return types.CouponReq{}, err
+		}
+		if count != len(dto.ArtsID) {
+			return types.CouponReq{}, ErrInvalidCouponArts
+		}
+		req.ArtsID = dto.ArtsID
+	case types.CouponScopeTags:
+		if len(dto.TagsID) == 0 {
+			return types.CouponReq{}, ErrInvalidCouponTags
+		}
+		req.TagsID = dto.TagsID
+	}
+
+	if dto.ExpTime != "" {
+		var expTime types.CustomTime
+		if err := expTime.UnmarshalParam(dto.ExpTime); err != nil {
+			return types.CouponReq{}, err
+		}
+		if !expTime.After(time.Now().UTC()) {
+			return types.CouponReq{}, ErrInvalidCouponExpTime
+		}
+		req.ExpTime = &expTime.Time
+	}
+	if dto.MaxUses > 0 {
+		req.MaxUses = &dto.MaxUses
+	}
+
+	return req, nil
+}
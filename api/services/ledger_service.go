@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+)
+
+type LedgerSvc struct {
+	ledgerRepo *repositories.LedgerRepo
+}
+
+func NewLedgerSvc(ledgerRepo *repositories.LedgerRepo) *LedgerSvc {
+	return &LedgerSvc{
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+func (s *LedgerSvc) FindManyWalletEntries(userId int) ([]types.WalletEntry, error) {
+	return s.ledgerRepo.FindManyWalletEntries(userId)
+}
+
+func (s *LedgerSvc) FindCreatorEarnings(creatorId int) (types.CreatorEarnings, error) {
+	return s.ledgerRepo.FindCreatorEarnings(creatorId)
+}
+
+func (s *LedgerSvc) FindLedgerReport() (types.LedgerReport, error) {
+	return s.ledgerRepo.FindLedgerReport()
+}
+
+func (s *LedgerSvc) AdjustCoin(dto types.AdjustCoinDTO) error {
+	return s.ledgerRepo.AdjustCoin(dto.Username, dto.Amount, dto.Reason)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"github.com/DeepAung/deep-art/.gen/model"
+	"github.com/DeepAung/deep-art/api/repositories"
+)
+
+type NotificationsSvc struct {
+	notificationsRepo *repositories.NotificationsRepo
+}
+
+func NewNotificationsSvc(notificationsRepo *repositories.NotificationsRepo) *NotificationsSvc {
+	return &NotificationsSvc{
+		notificationsRepo: notificationsRepo,
+	}
+}
+
+func (s *NotificationsSvc) FindManyNotifications(userId int) ([]model.Notifications, error) {
+	return s.notificationsRepo.FindManyNotifications(userId)
+}
+
+func (s *NotificationsSvc) CountUnreadNotifications(userId int) (int, error) {
+	return s.notificationsRepo.CountUnreadNotifications(userId)
+}
+
+func (s *NotificationsSvc) ReadAllNotifications(userId int) error {
+	return s.notificationsRepo.ReadAllNotifications(userId)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+)
+
+type OrdersSvc struct {
+	ordersRepo *repositories.OrdersRepo
+}
+
+func NewOrdersSvc(ordersRepo *repositories.OrdersRepo) *OrdersSvc {
+	return &OrdersSvc{
+		ordersRepo: ordersRepo,
+	}
+}
+
+func (s *OrdersSvc) FindManyOrders(userId int) ([]types.Order, error) {
+	return s.ordersRepo.FindManyOrders(userId)
+}
+
+// FindMyOrder finds the order for its buyer. The orders of other users are
+// not found, so that their receipts cannot be guessed.
+func (s *OrdersSvc) FindMyOrder(userId, orderId int) (types.Order, error) {
+	order, err := s.ordersRepo.FindOneOrder(orderId)
+	if err != nil {
+		return types.Order{}, err
+	}
+	if int(order.UserID) != userId {
+		return types.Order{}, repositories.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (s *OrdersSvc) FindManySales(creatorId int) ([]types.OrderSale, error) {
+	return s.ordersRepo.FindManySales(creatorId)
+}
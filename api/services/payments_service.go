@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/payment"
+)
+
+var ErrCoinPackageInactive = httperror.New("coin package is not for sale", http.StatusBadRequest)
+
+// pendingPaymentAge is how long a payment stays pending before it is looked up
+// at the provider, in case its webhook never arrived.
+const pendingPaymentAge = 15 * time.Minute
+
+type PaymentsSvc struct {
+	paymentsRepo *repositories.PaymentsRepo
+	gateway      payment.Gateway
+	cfg          *config.Config
+}
+
+func NewPaymentsSvc(
+	paymentsRepo *repositories.PaymentsRepo,
+	gateway payment.Gateway,
+	cfg *config.Config,
+) *PaymentsSvc {
+	return &PaymentsSvc{
+		paymentsRepo: paymentsRepo,
+		gateway:      gateway,
+		cfg:          cfg,
+	}
+}
+
+func (s *PaymentsSvc) GetCoinPackages(onlyActive bool) ([]model.CoinPackages, error) {
+	return s.paymentsRepo.FindManyCoinPackages(onlyActive)
+}
+
+func (s *PaymentsSvc) CreateCoinPackage(dto types.CoinPackageDTO) error {
+	return s.paymentsRepo.CreateCoinPackage(dto)
+}
+
+func (s *PaymentsSvc) UpdateCoinPackage(id int, dto types.CoinPackageDTO) error {
+	return s.paymentsRepo.UpdateCoinPackage(id, dto)
+}
+
+func (s *PaymentsSvc) DeleteCoinPackage(id int) error {
+	return s.paymentsRepo.DeleteCoinPackage(id)
+}
+
+func (s *PaymentsSvc) GetPayments() ([]types.Payment, error) {
+	return s.paymentsRepo.FindManyPayments()
+}
+
+// Checkout records a pending payment for the coin package and returns the
+// url of the checkout page at the provider.
+func (s *PaymentsSvc) Checkout(userId, packageId int) (string, error) {
+	pkg, err := s.paymentsRepo.FindOneCoinPackage(packageId)
+	if err != nil {
+		return "", err
+	}
+	if !pkg.IsActive {
+		return "", ErrCoinPackageInactive
+	}
+
+	paymentId, err := s.paymentsRepo.CreatePayment(types.CreatePaymentReq{
+		UserId:    userId,
+		PackageId: packageId,
+		Coins:     int(pkg.Coins),
+		Price:     int(pkg.Price),
+		Currency:  s.cfg.Payment.Currency,
+		Provider:  s.gateway.Name(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	checkout, err := s.gateway.CreateCheckout(payment.CheckoutReq{
+		PaymentId:   paymentId,
+		Amount:      int(pkg.Price),
+		Currency:    s.cfg.Payment.Currency,
+		Description: fmt.Sprintf("%d Coin (%s)", pkg.Coins, pkg.Name),
+		SuccessURL:  s.cfg.App.Address + "/me",
+		CancelURL:   s.cfg.App.Address + "/me",
+	})
+	if err != nil {
+		return "", errors.Join(err, s.paymentsRepo.FailPayment(paymentId))
+	}
+
+	if err := s.paymentsRepo.SetPaymentProviderId(paymentId, checkout.ProviderId); err != nil {
+		return "", err
+	}
+
+	return checkout.URL, nil
+}
+
+// FindOwnPayment returns the payment of the user. The payments of other users
+// are not found.
+func (s *PaymentsSvc) FindOwnPayment(userId, paymentId int) (model.Payments, error) {
+	p, err := s.paymentsRepo.FindOnePayment(paymentId)
+	if err != nil {
+		return model.Payments{}, err
+	}
+	if int(p.UserID) != userId {
+		return model.Payments{}, repositories.ErrPaymentNotFound
+	}
+	return p, nil
+}
+
+// HandleWebhook verifies the webhook of the provider and settles its payment.
+// The provider may send the same webhook more than once.
+func (s *PaymentsSvc) HandleWebhook(body []byte, header http.Header) error {
+	event, err := s.gateway.ParseWebhook(body, header)
+	if err != nil {
+		return err
+	}
+
+	return s.paymentsRepo.SettlePayment(event)
+}
+
+// Reconcile looks up the payment at the provider and settles it with the
+// status found there.
+func (s *PaymentsSvc) Reconcile(paymentId int) error {
+	p, err := s.paymentsRepo.FindOnePayment(paymentId)
+	if err != nil {
+		return err
+	}
+	if p.ProviderID == nil {
+		return s.paymentsRepo.FailPayment(paymentId)
+	}
+
+	event, err := s.gateway.FindPayment(*p.ProviderID)
+	if err != nil {
+		return err
+	}
+
+	return s.paymentsRepo.SettlePayment(event)
+}
+
+// ReconcilePayments reconciles the payments that have been pending for too
+// long. A payment that fails to reconcile is retried on the next run.
+func (s *PaymentsSvc) ReconcilePayments() error {
+	payments, err := s.paymentsRepo.FindManyPendingPayments(time.Now().UTC().Add(-pendingPaymentAge))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range payments {
+		if err := s.Reconcile(int(*p.ID)); err != nil {
+			slog.Error("reconcile payment", "id", *p.ID, "err", err)
+		}
+	}
+
+	return nil
+}
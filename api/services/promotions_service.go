@@ -0,0 +1,98 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrInvalidPromotionArts = httperror.New(
+		"a promotion for arts should contain at least one of your arts",
+		http.StatusBadRequest,
+	)
+	ErrInvalidPromotionTime = httperror.New(
+		"a promotion should end after it starts",
+		http.StatusBadRequest,
+	)
+)
+
+type PromotionsSvc struct {
+	promotionsRepo *repositories.PromotionsRepo
+	bundlesRepo    *repositories.BundlesRepo
+}
+
+func NewPromotionsSvc(
+	promotionsRepo *repositories.PromotionsRepo,
+	bundlesRepo *repositories.BundlesRepo,
+) *PromotionsSvc {
+	return &PromotionsSvc{
+		promotionsRepo: promotionsRepo,
+		bundlesRepo:    bundlesRepo,
+	}
+}
+
+func (s *PromotionsSvc) FindManyCreatedPromotions(creatorId int) ([]types.Promotion, error) {
+	return s.promotionsRepo.FindManyCreatedPromotions(creatorId)
+}
+
+func (s *PromotionsSvc) CreatePromotion(creatorId int, dto types.PromotionDTO) error {
+	req, err := s.newPromotionReq(0, creatorId, dto)
+	if err != nil {
+		return err
+	}
+
+	return s.promotionsRepo.CreatePromotion(req)
+}
+
+func (s *PromotionsSvc) UpdatePromotion(promotionId, creatorId int, dto types.PromotionDTO) error {
+	req, err := s.newPromotionReq(promotionId, creatorId, dto)
+	if err != nil {
+		return err
+	}
+
+	return s.promotionsRepo.UpdatePromotion(req)
+}
+
+func (s *PromotionsSvc) DeletePromotion(creatorId, promotionId int) error {
+	return s.promotionsRepo.DeletePromotion(creatorId, promotionId)
+}
+
+// newPromotionReq checks the promotion and only keeps the arts when it is
+// scoped to arts.
+func (s *PromotionsSvc) newPromotionReq(
+	promotionId, creatorId int,
+	dto types.PromotionDTO,
+) (types.PromotionReq, error) {
+	if !dto.EndsAt.After(dto.StartsAt.Time) {
+		return types.PromotionReq{}, ErrInvalidPromotionTime
+	}
+
+	req := types.PromotionReq{
+		PromotionId: promotionId,
+		CreatorId:   creatorId,
+		Name:        dto.Name,
+		Percent:     dto.Percent,
+		Scope:       dto.Scope,
+		StartsAt:    dto.StartsAt.Time,
+		EndsAt:      dto.EndsAt.Time,
+	}
+
+	if req.Scope == types.PromotionScopeArts {
+		if len(dto.ArtsID) == 0 {
+			return types.PromotionReq{}, ErrInvalidPromotionArts
+		}
+		count, _, err := s.bundlesRepo.CountCreatedArts(creatorId, dto.ArtsID)
+		if err != nil {
+			return types.PromotionReq{}, err
+		}
+		if count != len(dto.ArtsID) {
+			return types.PromotionReq{}, ErrInvalidPromotionArts
+		}
+		req.ArtsID = dto.ArtsID
+	}
+
+	return req, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var ErrNotRefundDecider = httperror.New(
+	"only the creator of the art or an admin can decide this refund",
+	http.StatusForbidden,
+)
+
+type RefundsSvc struct {
+	refundsRepo *repositories.RefundsRepo
+	cfg         *config.Config
+}
+
+func NewRefundsSvc(refundsRepo *repositories.RefundsRepo, cfg *config.Config) *RefundsSvc {
+	return &RefundsSvc{
+		refundsRepo: refundsRepo,
+		cfg:         cfg,
+	}
+}
+
+// RequestRefund asks the creator to refund the art. Purchases older than the
+// refund window cannot be refunded.
+func (s *RefundsSvc) RequestRefund(userId, artId int, dto types.RefundRequestDTO) error {
+	since := time.Now().UTC().Add(-s.cfg.App.RefundWindow)
+	return s.refundsRepo.CreateRefundRequest(userId, artId, dto.Reason, since)
+}
+
+func (s *RefundsSvc) ApproveRefund(id int, user types.User) error {
+	if err := s.checkDecider(id, user); err != nil {
+		return err
+	}
+	return s.refundsRepo.ApproveRefundRequest(id, user.Id)
+}
+
+func (s *RefundsSvc) RejectRefund(id int, user types.User) error {
+	if err := s.checkDecider(id, user); err != nil {
+		return err
+	}
+	return s.refundsRepo.RejectRefundRequest(id, user.Id)
+}
+
+// checkDecider allows the creator of the art to decide the refund, and admins
+// to override the creator.
+func (s *RefundsSvc) checkDecider(id int, user types.User) error {
+	if user.IsAdmin {
+		return nil
+	}
+
+	refund, err := s.refundsRepo.FindOneRefundRequest(id)
+	if err != nil {
+		return err
+	}
+	if int(refund.Art.CreatorID) != user.Id {
+		return ErrNotRefundDecider
+	}
+	return nil
+}
+
+func (s *RefundsSvc) FindManyRequestedRefunds(userId int) ([]types.RefundRequest, error) {
+	return s.refundsRepo.FindManyRefundRequestsByBuyer(userId)
+}
+
+func (s *RefundsSvc) FindManyIncomingRefunds(creatorId int) ([]types.RefundRequest, error) {
+	return s.refundsRepo.FindManyRefundRequestsByCreator(creatorId)
+}
+
+func (s *RefundsSvc) FindAllRefunds() ([]types.RefundRequest, error) {
+	return s.refundsRepo.FindAllRefundRequests()
+}
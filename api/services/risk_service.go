@@ -0,0 +1,125 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrRiskHeld = httperror.New(
+		"this looks unusual, so it is held until an admin reviews it",
+		http.StatusForbidden,
+	)
+	ErrRiskBlocked = httperror.New(
+		"this was blocked by our fraud checks",
+		http.StatusForbidden,
+	)
+)
+
+// riskRuleScores is what each fraud rule adds to the score of an attempt. A
+// new account only adds to the other rules, so new users are not flagged
+// for being new.
+var riskRuleScores = map[string]int{
+	types.RiskRuleSharedIP:         50,
+	types.RiskRuleNewAccount:       20,
+	types.RiskRuleRefundCycle:      50,
+	types.RiskRulePurchaseVelocity: 40,
+}
+
+type RiskSvc struct {
+	riskRepo *repositories.RiskRepo
+	cfg      *config.Config
+}
+
+func NewRiskSvc(riskRepo *repositories.RiskRepo, cfg *config.Config) *RiskSvc {
+	return &RiskSvc{
+		riskRepo: riskRepo,
+		cfg:      cfg,
+	}
+}
+
+// Screen scores the attempt with the fraud rules and records it. It returns
+// an error when the attempt is held or blocked, in which case the action
+// should not be carried out.
+func (s *RiskSvc) Screen(req types.RiskEventReq) error {
+	req.Now = time.Now().UTC()
+	signals, err := s.riskRepo.FindRiskSignals(
+		req,
+		req.Now.Add(-s.cfg.Risk.Window),
+		req.Now.Add(-s.cfg.App.NewUserWindow),
+	)
+	if err != nil {
+		return err
+	}
+
+	assessment := s.Assess(req.Action, signals)
+	if err := s.riskRepo.RecordRiskEvent(req, assessment); err != nil {
+		return err
+	}
+
+	switch assessment.Decision {
+	case types.RiskDecisionHold:
+		return ErrRiskHeld
+	case types.RiskDecisionBlock:
+		return ErrRiskBlocked
+	}
+	return nil
+}
+
+// Assess scores the signals of an attempt and decides what to do with it
+// from the thresholds of the config.
+func (s *RiskSvc) Assess(action string, signals types.RiskSignals) types.RiskAssessment {
+	if signals.IsCleared {
+		return types.RiskAssessment{Decision: types.RiskDecisionAllow}
+	}
+
+	var rules []string
+	switch action {
+	case types.RiskActionUseCode:
+		if signals.IPAccounts > s.cfg.Risk.IPAccounts {
+			rules = append(rules, types.RiskRuleSharedIP)
+		}
+	case types.RiskActionBuyArt:
+		if signals.Refunds >= s.cfg.Risk.Refunds {
+			rules = append(rules, types.RiskRuleRefundCycle)
+		}
+		if signals.Purchases >= s.cfg.Risk.Purchases {
+			rules = append(rules, types.RiskRulePurchaseVelocity)
+		}
+	}
+	if signals.IsNewUser && len(rules) > 0 {
+		rules = append(rules, types.RiskRuleNewAccount)
+	}
+
+	assessment := types.RiskAssessment{Rules: rules, Decision: types.RiskDecisionAllow}
+	for _, rule := range rules {
+		assessment.Score += riskRuleScores[rule]
+	}
+
+	switch {
+	case assessment.Score >= s.cfg.Risk.BlockScore:
+		assessment.Decision = types.RiskDecisionBlock
+	case assessment.Score >= s.cfg.Risk.HoldScore:
+		assessment.Decision = types.RiskDecisionHold
+	case assessment.Score >= s.cfg.Risk.FlagScore:
+		assessment.Decision = types.RiskDecisionFlag
+	}
+	return assessment
+}
+
+func (s *RiskSvc) FindManyOpenRiskFlags() ([]types.RiskFlag, error) {
+	return s.riskRepo.FindManyOpenRiskFlags()
+}
+
+func (s *RiskSvc) ClearRiskFlag(id, reviewerId int) error {
+	return s.riskRepo.ReviewRiskFlag(id, reviewerId, types.RiskFlagStatusCleared)
+}
+
+func (s *RiskSvc) ConfirmRiskFlag(id, reviewerId int) error {
+	return s.riskRepo.ReviewRiskFlag(id, reviewerId, types.RiskFlagStatusConfirmed)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+)
+
+type SubscriptionsSvc struct {
+	subscriptionsRepo *repositories.SubscriptionsRepo
+	cfg               *config.Config
+}
+
+func NewSubscriptionsSvc(
+	subscriptionsRepo *repositories.SubscriptionsRepo,
+	cfg *config.Config,
+) *SubscriptionsSvc {
+	return &SubscriptionsSvc{
+		subscriptionsRepo: subscriptionsRepo,
+		cfg:               cfg,
+	}
+}
+
+// FindManyTiers finds the tiers of the creator with the subscriptions of the
+// user.
+func (s *SubscriptionsSvc) FindManyTiers(creatorId, userId int) ([]types.SubscriptionTier, error) {
+	return s.subscriptionsRepo.FindManyTiers(creatorId, userId, time.Now().UTC())
+}
+
+func (s *SubscriptionsSvc) CreateTier(creatorId int, dto types.SubscriptionTierDTO) error {
+	return s.subscriptionsRepo.CreateTier(types.SubscriptionTierReq{
+		CreatorId:   creatorId,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Price:       dto.Price,
+	})
+}
+
+func (s *SubscriptionsSvc) UpdateTier(tierId, creatorId int, dto types.SubscriptionTierDTO) error {
+	return s.subscriptionsRepo.UpdateTier(types.SubscriptionTierReq{
+		TierId:      tierId,
+		CreatorId:   creatorId,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Price:       dto.Price,
+	})
+}
+
+func (s *SubscriptionsSvc) DeleteTier(creatorId, tierId int) error {
+	return s.subscriptionsRepo.DeleteTier(creatorId, tierId, time.Now().UTC())
+}
+
+func (s *SubscriptionsSvc) Subscribe(userId, tierId int) error {
+	return s.subscriptionsRepo.Subscribe(types.SubscribeReq{
+		UserId:     userId,
+		TierId:     tierId,
+		FeePercent: s.cfg.App.PlatformFee,
+	}, time.Now().UTC())
+}
+
+func (s *SubscriptionsSvc) CancelSubscription(userId, tierId int) error {
+	return s.subscriptionsRepo.CancelSubscription(userId, tierId)
+}
+
+func (s *SubscriptionsSvc) FindManySubscribers(creatorId int) ([]types.Subscriber, error) {
+	return s.subscriptionsRepo.FindManySubscribers(creatorId, time.Now().UTC())
+}
+
+// RenewSubscriptions renews the subscriptions that are due, or lets them
+// lapse when the wallet is short. A subscription that fails to renew is
+// retried on the next run.
+func (s *SubscriptionsSvc) RenewSubscriptions() error {
+	now := time.Now().UTC()
+	subscriptions, err := s.subscriptionsRepo.FindManyDueSubscriptions(now)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		err := s.subscriptionsRepo.RenewSubscription(
+			int(*subscription.ID),
+			s.cfg.App.PlatformFee,
+			now,
+		)
+		if err != nil {
+			slog.Error("renew subscription", "id", *subscription.ID, "err", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+var (
+	ErrTipToSelf = httperror.New("you cannot tip yourself", http.StatusBadRequest)
+	ErrTipAmount = func(tipMin, tipMax int) error {
+		return httperror.New(
+			fmt.Sprintf("a tip should be %d to %d Coin", tipMin, tipMax),
+			http.StatusBadRequest,
+		)
+	}
+)
+
+type TipsSvc struct {
+	tipsRepo *repositories.TipsRepo
+	cfg      *config.Config
+}
+
+func NewTipsSvc(tipsRepo *repositories.TipsRepo, cfg *config.Config) *TipsSvc {
+	return &TipsSvc{
+		tipsRepo: tipsRepo,
+		cfg:      cfg,
+	}
+}
+
+func (s *TipsSvc) Tip(senderId, creatorId int, dto types.TipDTO) error {
+	if senderId == creatorId {
+		return ErrTipToSelf
+	}
+	if dto.Amount < s.cfg.App.TipMin || dto.Amount > s.cfg.App.TipMax {
+		return ErrTipAmount(s.cfg.App.TipMin, s.cfg.App.TipMax)
+	}
+
+	req := types.TipReq{
+		SenderId:  senderId,
+		CreatorId: creatorId,
+		Amount:    dto.Amount,
+		Message:   dto.Message,
+		IsPublic:  dto.IsPublic,
+	}
+	if dto.ArtId != 0 {
+		req.ArtId = &dto.ArtId
+	}
+	return s.tipsRepo.CreateTip(req)
+}
+
+// FindCreatorTips finds the tips shown on the creator profile.
+func (s *TipsSvc) FindCreatorTips(creatorId int) (types.CreatorTips, error) {
+	return s.tipsRepo.FindCreatorTips(creatorId, false)
+}
+
+// FindReceivedTips finds the tips of the creator with the private ones.
+func (s *TipsSvc) FindReceivedTips(creatorId int) (types.CreatorTips, error) {
+	return s.tipsRepo.FindCreatorTips(creatorId, true)
+}
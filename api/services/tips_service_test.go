@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/services"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/asserts"
+)
+
+func Test_TipsSvc_Tip(t *testing.T) {
+	tipsSvc := services.NewTipsSvc(repositories.NewTipsRepo(testDB, 1*time.Second), cfg)
+	tipMin, tipMax := cfg.App.TipMin, cfg.App.TipMax
+
+	tests := []struct {
+		name          string
+		senderId      int
+		amount        int
+		expectedError error
+	}{
+		{
+			name:          "tip yourself",
+			senderId:      1,
+			amount:        tipMin,
+			expectedError: services.ErrTipToSelf,
+		},
+		{
+			name:          "below the minimum",
+			senderId:      2,
+			amount:        tipMin - 1,
+			expectedError: services.ErrTipAmount(tipMin, tipMax),
+		},
+		{
+			name:          "above the maximum",
+			senderId:      2,
+			amount:        tipMax + 1,
+			expectedError: services.ErrTipAmount(tipMin, tipMax),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tipsSvc.Tip(tt.senderId, 1, types.TipDTO{Amount: tt.amount})
+			asserts.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
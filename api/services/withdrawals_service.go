@@ -0,0 +1,87 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DeepAung/deep-art/api/repositories"
+	"github.com/DeepAung/deep-art/api/types"
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+type WithdrawalsSvc struct {
+	withdrawalsRepo *repositories.WithdrawalsRepo
+	cfg             *config.Config
+}
+
+func NewWithdrawalsSvc(
+	withdrawalsRepo *repositories.WithdrawalsRepo,
+	cfg *config.Config,
+) *WithdrawalsSvc {
+	return &WithdrawalsSvc{
+		withdrawalsRepo: withdrawalsRepo,
+		cfg:             cfg,
+	}
+}
+
+func (s *WithdrawalsSvc) RequestWithdrawal(creatorId int, dto types.WithdrawalDTO) error {
+	if dto.Amount < s.cfg.App.WithdrawalMin {
+		return httperror.New(
+			fmt.Sprintf("a withdrawal should be at least %d Coin", s.cfg.App.WithdrawalMin),
+			http.StatusBadRequest,
+		)
+	}
+
+	return s.withdrawalsRepo.RequestWithdrawal(types.WithdrawalReq{
+		CreatorId: creatorId,
+		Amount:    dto.Amount,
+		Note:      dto.Note,
+		HeldSince: s.heldSince(),
+	})
+}
+
+func (s *WithdrawalsSvc) FindWithdrawalBalance(creatorId int) (types.WithdrawalBalance, error) {
+	return s.withdrawalsRepo.FindWithdrawalBalance(creatorId, s.heldSince())
+}
+
+func (s *WithdrawalsSvc) FindManyWithdrawals(creatorId int) ([]types.Withdrawal, error) {
+	return s.withdrawalsRepo.FindManyWithdrawalsByCreator(creatorId)
+}
+
+func (s *WithdrawalsSvc) FindAllWithdrawals() ([]types.Withdrawal, error) {
+	return s.withdrawalsRepo.FindAllWithdrawals()
+}
+
+func (s *WithdrawalsSvc) FindStatement(creatorId int) ([]types.WalletEntry, error) {
+	return s.withdrawalsRepo.FindManyEarningsEntries(creatorId)
+}
+
+func (s *WithdrawalsSvc) DecideWithdrawal(
+	id int,
+	status string,
+	deciderId int,
+	dto types.WithdrawalNoteDTO,
+) error {
+	return s.withdrawalsRepo.DecideWithdrawal(types.WithdrawalDecisionReq{
+		Id:        id,
+		Status:    status,
+		AdminNote: dto.Note,
+		DeciderId: deciderId,
+		Now:       time.Now().UTC(),
+	})
+}
+
+func (s *WithdrawalsSvc) WithdrawalMin() int {
+	return s.cfg.App.WithdrawalMin
+}
+
+func (s *WithdrawalsSvc) WithdrawalHold() time.Duration {
+	return s.cfg.App.WithdrawalHold
+}
+
+// heldSince is the time after which the earnings of sales are on hold.
+func (s *WithdrawalsSvc) heldSince() time.Time {
+	return time.Now().UTC().Add(-s.cfg.App.WithdrawalHold)
+}
@@ -1,13 +1,23 @@
 package types
 
 import (
+	"fmt"
 	"mime/multipart"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/DeepAung/deep-art/.gen/model"
 )
 
+const (
+	ArtStatusDraft     = "draft"
+	ArtStatusScheduled = "scheduled"
+	ArtStatusPublished = "published"
+	ArtStatusUnlisted  = "unlisted"
+	ArtStatusArchived  = "archived"
+)
+
 /*
 - Update art info
 - Update art Files&Cover (case create art)
@@ -17,6 +27,16 @@ type FullArtDTO struct {
 	Description string `form:"description"`
 	Price       int    `form:"price"`
 	TagsID      []int  `form:"tags"`
+	Status      string `form:"status"      validate:"omitempty,oneof=draft scheduled published unlisted archived"`
+	PublishAt   string `form:"publishAt"`
+	EditionSize int    `form:"editionSize" validate:"min=0"`
+
+	PayWhatYouWant bool `form:"payWhatYouWant"`
+	SuggestedPrice int  `form:"suggestedPrice" validate:"min=0"`
+	MembersTierId  int  `form:"membersTierId"  validate:"min=0"`
+
+	DownloadLimit     int `form:"downloadLimit"     validate:"min=0"`
+	DownloadRateLimit int `form:"downloadRateLimit" validate:"min=0"`
 
 	Cover *multipart.FileHeader
 	Files []*multipart.FileHeader
@@ -25,8 +45,35 @@ type FullArtDTO struct {
 type ArtDTO struct {
 	Name        string `form:"name"        validate:"required"`
 	Description string `form:"description"`
-	Price       int    `form:"price"`
 	TagsID      []int  `form:"tags"`
+	Status      string `form:"status"      validate:"omitempty,oneof=draft scheduled published unlisted archived"`
+	PublishAt   string `form:"publishAt"`
+	EditionSize int    `form:"editionSize" validate:"min=0"`
+
+	PayWhatYouWant bool `form:"payWhatYouWant"`
+	SuggestedPrice int  `form:"suggestedPrice" validate:"min=0"`
+	MembersTierId  int  `form:"membersTierId"  validate:"min=0"`
+
+	DownloadLimit     int `form:"downloadLimit"     validate:"min=0"`
+	DownloadRateLimit int `form:"downloadRateLimit" validate:"min=0"`
+}
+
+// EditionSize turns the edition size of the form into the one of the art,
+// where 0 means the art is not limited.
+func EditionSize(size int) *int {
+	if size == 0 {
+		return nil
+	}
+	return &size
+}
+
+// SuggestedPrice turns the suggested price of the form into the one of the
+// art. Only pay-what-you-want arts have one, and 0 means no suggestion.
+func SuggestedPrice(payWhatYouWant bool, price int) *int {
+	if !payWhatYouWant || price == 0 {
+		return nil
+	}
+	return &price
 }
 
 type CreateArtReq struct {
@@ -35,6 +82,16 @@ type CreateArtReq struct {
 	Description string
 	Price       int
 	TagsID      []int
+	Status      string
+	PublishAt   *time.Time
+	EditionSize *int
+
+	PayWhatYouWant bool
+	SuggestedPrice *int
+	MembersTierId  *int
+
+	DownloadLimit     *int
+	DownloadRateLimit *int
 }
 
 type UpdateArtInfoReq struct {
@@ -42,8 +99,17 @@ type UpdateArtInfoReq struct {
 
 	Name        string
 	Description string
-	Price       int
 	TagsID      []int
+	Status      string
+	PublishAt   *time.Time
+	EditionSize *int
+
+	PayWhatYouWant bool
+	SuggestedPrice *int
+	MembersTierId  *int
+
+	DownloadLimit     *int
+	DownloadRateLimit *int
 }
 
 type UpdateArtFilesReq struct {
@@ -61,11 +127,18 @@ type Art struct {
 	// Users     model.Users `alias:"Creator.*"`
 	Creator  Creator `alias:"Creator.*"`
 	Files    []model.Files
+	Versions []model.ArtVersions
+	Licenses []model.ArtLicenses
 	Tags     []model.Tags
 	TagNames string `alias:"Temp.TagNames"`
 	TagIDs   string `alias:"Temp.TagIDs"`
 
+	// the best promotion running for the art, 0 when it is not on sale
+	PromotionPercent int `alias:"Promotion.Percent"`
+	EffectivePrice   int `alias:"Promotion.Price"`
+
 	TotalDownloads   int `alias:"Stats.TotalDownloads"`
+	TotalDownloaders int `alias:"Stats.TotalDownloaders"`
 	WeeklyDownloads  int `alias:"Stats.WeeklyDownloads"`
 	MonthlyDownloads int `alias:"Stats.MonthlyDownloads"`
 	YearlyDownloads  int `alias:"Stats.YearlyDownloads"`
@@ -74,6 +147,46 @@ type Art struct {
 	WeeklyStars  int `alias:"Stats.WeeklyStars"`
 	MonthlyStars int `alias:"Stats.MonthlyStars"`
 	YearlyStars  int `alias:"Stats.YearlyStars"`
+
+	Edition ArtEdition `alias:"Edition.*"`
+
+	// only set by FindOneArt, the art is only sold to the highest bid
+	OnAuction bool `alias:"Temp.OnAuction"`
+}
+
+// IsMembersOnly reports whether the art is only downloaded by the subscribers
+// of a tier of its creator, instead of being sold.
+func (art *Art) IsMembersOnly() bool {
+	return art.MembersTierID != nil
+}
+
+// IsPublic reports whether everyone can see the art.
+func (art *Art) IsPublic() bool {
+	return art.DeletedAt == nil &&
+		(art.Status == ArtStatusPublished || art.Status == ArtStatusUnlisted)
+}
+
+// OnSale reports whether a promotion is running for the art.
+func (art *Art) OnSale() bool {
+	return art.PromotionPercent > 0
+}
+
+// Suggested returns the amount first offered to the buyers of a
+// pay-what-you-want art for a license of the given minimum price.
+func (art *Art) Suggested(minimum int) int {
+	if art.SuggestedPrice == nil {
+		return minimum
+	}
+	return max(int(*art.SuggestedPrice), minimum)
+}
+
+// LatestVersion returns the newest version of the art. Versions are expected
+// to be sorted from newest to oldest.
+func (art *Art) LatestVersion() model.ArtVersions {
+	if len(art.Versions) == 0 {
+		return model.ArtVersions{Version: 1, CoverURL: art.CoverURL, CreatedAt: art.CreatedAt}
+	}
+	return art.Versions[0]
 }
 
 func (art *Art) FillTags() error {
@@ -101,9 +214,18 @@ func (art *Art) FillTags() error {
 	return nil
 }
 
+type ArtVersion struct {
+	model.ArtVersions
+
+	Files []model.ArtVersionsFiles
+}
+
 type ManyArtsRes struct {
 	Arts  ManyArts
 	Total int
+
+	// only filled for the search results
+	Bundles []Bundle
 }
 
 type ManyArts []struct {
@@ -114,7 +236,11 @@ type ManyArts []struct {
 	TagNames string
 	TagIDs   string
 
+	PromotionPercent int `alias:"Promotion.Percent"`
+	EffectivePrice   int `alias:"Promotion.Price"`
+
 	TotalDownloads   int `alias:"Stats.TotalDownloads"`
+	TotalDownloaders int `alias:"Stats.TotalDownloaders"`
 	WeeklyDownloads  int `alias:"Stats.WeeklyDownloads"`
 	MonthlyDownloads int `alias:"Stats.MonthlyDownloads"`
 	YearlyDownloads  int `alias:"Stats.YearlyDownloads"`
@@ -123,6 +249,8 @@ type ManyArts []struct {
 	WeeklyStars  int `alias:"Stats.WeeklyStars"`
 	MonthlyStars int `alias:"Stats.MonthlyStars"`
 	YearlyStars  int `alias:"Stats.YearlyStars"`
+
+	Edition ArtEdition `alias:"Edition.*"`
 }
 
 func (arts ManyArts) FillTags() error {
@@ -198,3 +326,141 @@ const (
 	YearlyStars      By = "yearlyStars"
 	Price            By = "price"
 )
+
+const (
+	DefaultLicenseName  = "Personal"
+	DefaultLicenseTerms = "For personal, non-commercial use only."
+)
+
+type ArtLicenseDTO struct {
+	Name  string `form:"name"        validate:"required"`
+	Price int    `form:"price"       validate:"gte=0"`
+	Terms string `form:"terms"`
+	// 0 means the license can be downloaded unlimited times
+	DownloadCap int `form:"downloadCap" validate:"gte=0"`
+}
+
+type ArtLicenseReq struct {
+	ArtId     int
+	LicenseId int
+
+	Name        string
+	Price       int
+	Terms       string
+	DownloadCap *int
+}
+
+// BuyArtReq buys the license of the art or upgrades the license that the user
+// already holds. Price is what the user expects to pay, after the discount of
+// the coupon if any. Requests with the same IdempotencyKey are only run once.
+type BuyArtReq struct {
+	UserId     int
+	ArtId      int
+	LicenseId  int
+	Price      int
+	CouponCode string
+
+	FeePercent     int
+	IdempotencyKey string
+	Now            time.Time
+}
+
+// Request describes the purchase to tell whether an idempotency key is reused
+// for another purchase.
+func (r *BuyArtReq) Request() string {
+	request := fmt.Sprintf("buy art %d license %d for %d", r.ArtId, r.LicenseId, r.Price)
+	if r.CouponCode != "" {
+		request += " with coupon " + r.CouponCode
+	}
+	return request
+}
+
+// BoughtLicense is the license tier that the user holds for an art.
+type BoughtLicense struct {
+	model.UsersBoughtArts
+	License       model.ArtLicenses
+	EditionSize   *int32 `alias:"arts.edition_size"`
+	DownloadLimit *int32 `alias:"arts.download_limit"`
+}
+
+// Edition returns the copy that the user holds of a limited-edition art.
+func (b *BoughtLicense) Edition() *Edition {
+	if b.EditionNumber == nil || b.EditionSize == nil {
+		return nil
+	}
+	return &Edition{Number: int(*b.EditionNumber), Size: int(*b.EditionSize)}
+}
+
+// DownloadMax returns how many times the user can download the art, the
+// lower of the download cap of the license and the download limit of the art,
+// or nil when it is unlimited.
+func (b *BoughtLicense) DownloadMax() *int32 {
+	limit := b.License.DownloadCap
+	if b.DownloadLimit != nil && (limit == nil || *b.DownloadLimit < *limit) {
+		limit = b.DownloadLimit
+	}
+	return limit
+}
+
+// CanDownload reports whether the download limit of the purchase is not
+// reached.
+func (b *BoughtLicense) CanDownload() bool {
+	limit := b.DownloadMax()
+	return limit == nil || b.DownloadCount < *limit
+}
+
+// ArtEdition tells how many copies of a limited-edition art are sold and
+// which copy the user holds. Size is nil when the art is not limited, and
+// Number is 0 outside of the library or when the user got the art before it
+// was limited.
+type ArtEdition struct {
+	Size   *int32
+	Sold   int
+	Number int
+}
+
+func (e ArtEdition) IsLimited() bool {
+	return e.Size != nil
+}
+
+func (e ArtEdition) IsSoldOut() bool {
+	return e.IsLimited() && e.Sold >= int(*e.Size)
+}
+
+// Left returns the copies that can still be bought of a limited-edition art.
+func (e ArtEdition) Left() int {
+	if !e.IsLimited() {
+		return 0
+	}
+	return max(int(*e.Size)-e.Sold, 0)
+}
+
+// Held returns the copy that the user holds, if any.
+func (e ArtEdition) Held() *Edition {
+	if !e.IsLimited() || e.Number == 0 {
+		return nil
+	}
+	return &Edition{Number: e.Number, Size: int(*e.Size)}
+}
+
+// Edition is the numbered copy of a limited-edition art that a buyer holds.
+type Edition struct {
+	Number int `json:"number"`
+	Size   int `json:"size"`
+}
+
+func (e Edition) String() string {
+	return fmt.Sprintf("Edition %d of %d", e.Number, e.Size)
+}
+
+// DownloadManifest is written as manifest.json into every zip file that is
+// downloaded, so the files can be traced back to the download.
+type DownloadManifest struct {
+	ArtId        int       `json:"artId"`
+	Version      int       `json:"version"`
+	Files        []string  `json:"files"`
+	License      string    `json:"license,omitempty"`
+	Edition      *Edition  `json:"edition,omitempty"`
+	DownloadedBy int       `json:"downloadedBy"`
+	DownloadedAt time.Time `json:"downloadedAt"`
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	AuctionStatusOpen      = "open"
+	AuctionStatusSold      = "sold"
+	AuctionStatusUnsold    = "unsold"
+	AuctionStatusCancelled = "cancelled"
+)
+
+const (
+	BidStatusLeading  = "leading"
+	BidStatusOutbid   = "outbid"
+	BidStatusWon      = "won"
+	BidStatusReleased = "released"
+)
+
+// AuctionDTO starts an auction of the art. A ReservePrice of 0 sells the art
+// to the highest bid whatever it is.
+type AuctionDTO struct {
+	LicenseId        int        `form:"licenseId"        validate:"required"`
+	StartPrice       int        `form:"startPrice"       validate:"required,gt=0"`
+	MinIncrement     int        `form:"minIncrement"     validate:"required,gt=0"`
+	ReservePrice     int        `form:"reservePrice"     validate:"gte=0"`
+	EndsAt           CustomTime `form:"endsAt"           validate:"required"`
+	ExtensionMinutes int        `form:"extensionMinutes" validate:"gte=0"`
+}
+
+type BidDTO struct {
+	Amount int `form:"amount" validate:"required,gt=0"`
+}
+
+type AuctionReq struct {
+	ArtId            int
+	CreatorId        int
+	LicenseId        int
+	StartPrice       int
+	MinIncrement     int
+	ReservePrice     *int
+	EndsAt           time.Time
+	ExtensionSeconds int
+}
+
+type BidReq struct {
+	ArtId  int
+	UserId int
+	Amount int
+	Now    time.Time
+}
+
+// Auction is an auction of the art with its leading bid and its bidder, if
+// there is one.
+type Auction struct {
+	model.Auctions
+
+	License    model.ArtLicenses
+	LeadingBid *model.AuctionBids `alias:"LeadingBid.*"`
+	Bidder     *Creator           `alias:"Bidder.*"`
+	CreatorId  int                `alias:"auction.creator_id"`
+	Bids       int                `alias:"auction.bids"`
+}
+
+// IsOpen reports whether the auction still takes bids at now.
+func (a *Auction) IsOpen(now time.Time) bool {
+	return a.Status == AuctionStatusOpen && now.Before(a.EndsAt)
+}
+
+// MinBid is the lowest amount the next bid can be.
+func (a *Auction) MinBid() int {
+	if a.LeadingBid == nil {
+		return int(a.StartPrice)
+	}
+	return int(a.LeadingBid.Amount + a.MinIncrement)
+}
+
+// ReserveMet reports whether the leading bid is high enough to sell the art.
+func (a *Auction) ReserveMet() bool {
+	return a.LeadingBid != nil &&
+		(a.ReservePrice == nil || a.LeadingBid.Amount >= *a.ReservePrice)
+}
+
+// ExtendedEnd is the end of the auction after a bid at now. A bid in the last
+// extension seconds pushes the end back to the extension after the bid.
+func (a *Auction) ExtendedEnd(now time.Time) time.Time {
+	extended := now.Add(time.Duration(a.ExtensionSeconds) * time.Second)
+	if extended.After(a.EndsAt) {
+		return extended
+	}
+	return a.EndsAt
+}
@@ -0,0 +1,71 @@
+package types
+
+import "github.com/DeepAung/deep-art/.gen/model"
+
+type BundleDTO struct {
+	Name        string `form:"name"        validate:"required"`
+	Description string `form:"description"`
+	Price       int    `form:"price"       validate:"gte=0"`
+	ArtsID      []int  `form:"arts"        validate:"min=2"`
+}
+
+type BundleReq struct {
+	BundleId  int
+	CreatorId int
+
+	Name        string
+	Description string
+	Price       int
+	ArtsID      []int
+}
+
+type Bundle struct {
+	model.Bundles
+
+	Creator Creator `alias:"Creator.*"`
+	Arts    []model.Arts
+}
+
+func (b *Bundle) CoverURL() string {
+	if len(b.Arts) == 0 {
+		return ""
+	}
+	return b.Arts[0].CoverURL
+}
+
+// TotalPrice is the price of buying every art of the bundle separately.
+func (b *Bundle) TotalPrice() int {
+	total := 0
+	for _, art := range b.Arts {
+		total += int(art.Price)
+	}
+	return total
+}
+
+// PriceFor returns the price of the bundle for a user who already owns the
+// given arts. The price is pro-rated by the prices of the arts that the user
+// does not own yet.
+func (b *Bundle) PriceFor(owned map[int]bool) int {
+	total, missing := 0, 0
+	for _, art := range b.Arts {
+		total += int(art.Price)
+		if !owned[int(*art.ID)] {
+			missing += int(art.Price)
+		}
+	}
+
+	if total == 0 || missing == total {
+		return int(b.Price)
+	}
+	return int(b.Price) * missing / total
+}
+
+// OwnsAll reports whether the user already owns every art of the bundle.
+func (b *Bundle) OwnsAll(owned map[int]bool) bool {
+	for _, art := range b.Arts {
+		if !owned[int(*art.ID)] {
+			return false
+		}
+	}
+	return true
+}
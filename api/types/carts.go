@@ -0,0 +1,84 @@
+package types
+
+import "github.com/DeepAung/deep-art/.gen/model"
+
+// CartItemDTO adds the art to the cart. A licenseId of 0 picks the cheapest
+// license of the art.
+type CartItemDTO struct {
+	ArtId     int `form:"artId"     validate:"required"`
+	LicenseId int `form:"licenseId" validate:"gte=0"`
+}
+
+type CheckoutDTO struct {
+	Total int `form:"total" validate:"gte=0"`
+}
+
+// CheckoutReq buys every item of the user's cart. Total is what the user
+// expects to pay for the items that they do not own yet.
+type CheckoutReq struct {
+	UserId     int
+	Total      int
+	FeePercent int
+}
+
+type CartItem struct {
+	model.CartItems
+
+	Art     model.Arts
+	License model.ArtLicenses
+	Creator Creator `alias:"Creator.*"`
+
+	// the best promotion running for the art, 0 when it is not on sale
+	PromotionPercent int  `alias:"cart_item.promotion_percent"`
+	IsBought         bool `alias:"cart_item.is_bought"`
+	OnAuction        bool `alias:"cart_item.on_auction"`
+}
+
+// Price is the price of the license after the running promotion.
+func (i *CartItem) Price() int {
+	return PromotionPrice(int(i.License.Price), i.PromotionPercent)
+}
+
+// IsForSale reports whether the art can still be bought from the cart. The
+// buyers of pay-what-you-want arts choose their price on the art's page, and
+// arts on auction are only sold to the highest bid.
+func (i *CartItem) IsForSale() bool {
+	return i.Art.DeletedAt == nil &&
+		!i.Art.PayWhatYouWant &&
+		!i.OnAuction &&
+		i.Art.MembersTierID == nil &&
+		(i.Art.Status == ArtStatusPublished || i.Art.Status == ArtStatusUnlisted)
+}
+
+type Cart struct {
+	Items []CartItem
+}
+
+// Total is the price of the items that the user does not own yet. Items that
+// are no longer for sale are left out, checking out fails until they are
+// removed.
+func (c *Cart) Total() int {
+	total := 0
+	for _, item := range c.Items {
+		if !item.IsBought && item.IsForSale() {
+			total += item.Price()
+		}
+	}
+	return total
+}
+
+// CanCheckout reports whether the cart has an item to buy and every item that
+// the user does not own yet is still for sale.
+func (c *Cart) CanCheckout() bool {
+	buyable := false
+	for _, item := range c.Items {
+		if item.IsBought {
+			continue
+		}
+		if !item.IsForSale() {
+			return false
+		}
+		buyable = true
+	}
+	return buyable
+}
@@ -1,7 +1,108 @@
 package types
 
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	CodeSegmentAll      = "all"
+	CodeSegmentNewUsers = "new_users"
+	CodeSegmentUsers    = "users"
+)
+
+// CodeRulesDTO is what a single code and a batch of codes have in common. An
+// empty startsAt makes the code usable right away and a maxUses of 0 makes it
+// unlimited.
+type CodeRulesDTO struct {
+	Value        int        `form:"value"        validate:"required,gte=0"`
+	ExpTime      CustomTime `form:"expTime"      validate:"required"`
+	StartsAt     string     `form:"startsAt"`
+	MaxUses      int        `form:"maxUses"      validate:"gte=0"`
+	PerUserLimit int        `form:"perUserLimit" validate:"gte=0"`
+	Disabled     bool       `form:"disabled"`
+	Segment      string     `form:"segment"      validate:"omitempty,oneof=all new_users users"`
+	UsersID      string     `form:"users"`
+}
+
+type CodeDTO struct {
+	Name string `form:"name" validate:"required"`
+	CodeRulesDTO
+}
+
+type CodeBatchDTO struct {
+	Batch string `form:"batch" validate:"required,alphanum,max=16"`
+	Count int    `form:"count" validate:"required,gt=0,lte=1000"`
+	CodeRulesDTO
+}
+
+type CodeRules struct {
+	Value        int
+	ExpTime      time.Time
+	StartsAt     *time.Time
+	MaxUses      *int
+	PerUserLimit int
+	Disabled     bool
+	Segment      string
+	UsersID      []int
+}
+
 type CodeReq struct {
-	Name    string     `form:"name"    validate:"required"`
-	Value   int        `form:"value"   validate:"required,gte=0"`
-	ExpTime CustomTime `form:"expTime" validate:"required"`
+	Name string
+	CodeRules
+}
+
+type CodeBatchReq struct {
+	Batch string
+	Names []string
+	CodeRules
+}
+
+type UseCodeReq struct {
+	UserId int
+	CodeId int
+	Now    time.Time
+	// NewUserSince is the earliest sign up time of a new user.
+	NewUserSince time.Time
+}
+
+type Code struct {
+	model.Codes
+
+	Users []model.CodesUsers
+
+	Uses      int `alias:"code.uses"`
+	Redeemers int `alias:"code.redeemers"`
+	Coins     int `alias:"code.coins"`
+}
+
+type CodeBatch struct {
+	Batch         string    `alias:"code_batch.batch"`
+	Value         int       `alias:"code_batch.value"`
+	ExpTime       time.Time `alias:"code_batch.exp_time"`
+	Codes         int       `alias:"code_batch.codes"`
+	RedeemedCodes int       `alias:"code_batch.redeemed_codes"`
+	Uses          int       `alias:"code_batch.uses"`
+	Coins         int       `alias:"code_batch.coins"`
+}
+
+// UsersText is the comma separated ids of the users allowed to use the code.
+func (c *Code) UsersText() string {
+	ids := make([]string, len(c.Users))
+	for i, user := range c.Users {
+		ids[i] = strconv.Itoa(int(user.UserID))
+	}
+	return strings.Join(ids, ", ")
+}
+
+// LimitText describes how many times the code can be redeemed.
+func (c *Code) LimitText() string {
+	total := "unlimited"
+	if c.MaxUses != nil {
+		total = strconv.Itoa(int(*c.MaxUses))
+	}
+	return total + " total, " + strconv.Itoa(int(c.PerUserLimit)) + " per user"
 }
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	CommissionStatusRequested = "requested"
+	CommissionStatusQuoted    = "quoted"
+	CommissionStatusAccepted  = "accepted"
+	CommissionStatusDelivered = "delivered"
+	CommissionStatusCompleted = "completed"
+	CommissionStatusDisputed  = "disputed"
+	CommissionStatusRefunded  = "refunded"
+	CommissionStatusDeclined  = "declined"
+	CommissionStatusCancelled = "cancelled"
+)
+
+// roles of the users who move a commission
+const (
+	CommissionRoleBuyer   = "buyer"
+	CommissionRoleCreator = "creator"
+	CommissionRoleAdmin   = "admin"
+)
+
+type CommissionDTO struct {
+	Brief  string `form:"brief"  validate:"required"`
+	Budget int    `form:"budget" validate:"required,gt=0"`
+}
+
+type CommissionQuoteDTO struct {
+	Quote int    `form:"quote" validate:"required,gt=0"`
+	Note  string `form:"note"`
+}
+
+// CommissionAcceptDTO accepts the quote of the creator. Quote is the quote
+// the buyer saw, so a quote changed in the meantime is not accepted.
+type CommissionAcceptDTO struct {
+	Quote int    `form:"quote" validate:"required,gt=0"`
+	Note  string `form:"note"`
+}
+
+type CommissionNoteDTO struct {
+	Note string `form:"note"`
+}
+
+type CommissionReq struct {
+	BuyerId   int
+	CreatorId int
+	Brief     string
+	Budget    int
+}
+
+// CommissionMoveReq moves the commission to the status. Quote is the new
+// quote when the creator quotes and the accepted quote when the buyer accepts.
+// Files are only used when the creator delivers, and DueAt when the buyer
+// accepts.
+type CommissionMoveReq struct {
+	Id         int
+	ActorId    int
+	Role       string
+	Status     string
+	Quote      int
+	Note       string
+	Files      []CommissionFileReq
+	FeePercent int
+	DueAt      time.Time
+}
+
+type CommissionFileReq struct {
+	Filename string
+	URL      string
+}
+
+type Commission struct {
+	model.Commissions
+
+	Buyer   Creator `alias:"Buyer.*"`
+	Creator Creator `alias:"Creator.*"`
+}
+
+// CommissionDetail is a commission with its delivered files and every move
+// made to it, oldest first.
+type CommissionDetail struct {
+	Commission
+
+	Files  []model.CommissionFiles
+	Events []CommissionEvent
+}
+
+type CommissionEvent struct {
+	model.CommissionEvents
+
+	Actor *Creator `alias:"Actor.*"`
+}
+
+// commissionMoves are the statuses a commission can move to from each status,
+// with the role allowed to make the move. The creator can quote again until
+// the buyer accepts, and the buyer can dispute an accepted commission once it
+// is overdue.
+var commissionMoves = map[string]map[string]string{
+	CommissionStatusRequested: {
+		CommissionStatusQuoted:    CommissionRoleCreator,
+		CommissionStatusDeclined:  CommissionRoleCreator,
+		CommissionStatusCancelled: CommissionRoleBuyer,
+	},
+	CommissionStatusQuoted: {
+		CommissionStatusQuoted:    CommissionRoleCreator,
+		CommissionStatusAccepted:  CommissionRoleBuyer,
+		CommissionStatusDeclined:  CommissionRoleCreator,
+		CommissionStatusCancelled: CommissionRoleBuyer,
+	},
+	CommissionStatusAccepted: {
+		CommissionStatusDelivered: CommissionRoleCreator,
+		CommissionStatusDisputed:  CommissionRoleBuyer,
+	},
+	CommissionStatusDelivered: {
+		CommissionStatusCompleted: CommissionRoleBuyer,
+		CommissionStatusDisputed:  CommissionRoleBuyer,
+	},
+	CommissionStatusDisputed: {
+		CommissionStatusCompleted: CommissionRoleAdmin,
+		CommissionStatusRefunded:  CommissionRoleAdmin,
+	},
+}
+
+func (c *Commission) CanMoveTo(status, role string) bool {
+	allowed, ok := commissionMoves[c.Status][status]
+	if !ok || allowed != role {
+		return false
+	}
+	if c.Status == CommissionStatusAccepted && status == CommissionStatusDisputed {
+		return c.IsOverdue()
+	}
+	return true
+}
+
+// IsOverdue reports whether the creator has not delivered the accepted
+// commission by its due date.
+func (c *Commission) IsOverdue() bool {
+	return c.Status == CommissionStatusAccepted && c.DueAt != nil && time.Now().After(*c.DueAt)
+}
+
+// RoleOf is the role of the user in the commission, or "" when the user is
+// neither its buyer nor its creator.
+func (c *Commission) RoleOf(userId int) string {
+	switch userId {
+	case int(c.BuyerID):
+		return CommissionRoleBuyer
+	case int(c.CreatorID):
+		return CommissionRoleCreator
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	CouponKindPercent = "percent"
+	CouponKindFixed   = "fixed"
+)
+
+const (
+	CouponScopeAll  = "all"
+	CouponScopeArts = "arts"
+	CouponScopeTags = "tags"
+)
+
+type CouponDTO struct {
+	Code    string `form:"code"    validate:"required,alphanum,max=32"`
+	Kind    string `form:"kind"    validate:"required,oneof=percent fixed"`
+	Value   int    `form:"value"   validate:"required,gt=0"`
+	Scope   string `form:"scope"   validate:"required,oneof=all arts tags"`
+	ArtsID  []int  `form:"arts"`
+	TagsID  []int  `form:"tags"`
+	ExpTime string `form:"expTime"`
+	MaxUses int    `form:"maxUses" validate:"gte=0"`
+}
+
+// CouponReq creates or updates a coupon of the creator. ExpTime and MaxUses
+// are nil when the coupon never expires or has no usage cap.
+type CouponReq struct {
+	CouponId  int
+	CreatorId int
+
+	Code    string
+	Kind    string
+	Value   int
+	Scope   string
+	ArtsID  []int
+	TagsID  []int
+	ExpTime *time.Time
+	MaxUses *int
+}
+
+type Coupon struct {
+	model.Coupons
+
+	Arts []model.Arts
+	Tags []model.Tags
+
+	// redemption stats
+	Uses          int `alias:"coupon.uses"`
+	TotalDiscount int `alias:"coupon.total_discount"`
+	Revenue       int `alias:"coupon.revenue"`
+}
+
+// NormalizeCouponCode makes coupon codes case insensitive.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Discount returns how much the coupon takes off the price. The discount is
+// never more than the price.
+func (c *Coupon) Discount(price int) int {
+	discount := int(c.Value)
+	if c.Kind == CouponKindPercent {
+		discount = price * int(c.Value) / 100
+	}
+	return min(discount, price)
+}
+
+func (c *Coupon) DiscountText() string {
+	if c.Kind == CouponKindPercent {
+		return fmt.Sprintf("%d%% off", c.Value)
+	}
+	return fmt.Sprintf("%d Coin off", c.Value)
+}
+
+// HasArt reports whether the art is picked for a coupon scoped to arts.
+func (c *Coupon) HasArt(artId int32) bool {
+	for _, art := range c.Arts {
+		if *art.ID == artId {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the tag is picked for a coupon scoped to tags.
+func (c *Coupon) HasTag(tagId int32) bool {
+	for _, tag := range c.Tags {
+		if *tag.ID == tagId {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUsedUp reports whether the usage cap of the coupon is reached.
+func (c *Coupon) IsUsedUp() bool {
+	return c.MaxUses != nil && c.Uses >= int(*c.MaxUses)
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+// DownloadLimit turns the download limit of the form into the one of the art,
+// where 0 means the art can be downloaded unlimited times.
+func DownloadLimit(limit int) *int {
+	if limit == 0 {
+		return nil
+	}
+	return &limit
+}
+
+// Download is a download of an art in the download history of the user.
+type Download struct {
+	model.DownloadedArts
+
+	Art model.Arts `alias:"Art.*"`
+}
+
+// DownloadRate is how many times the user downloaded the art recently, against
+// the rate limit of the art. The creator is not limited.
+type DownloadRate struct {
+	Limit     *int32 `alias:"arts.download_rate_limit"`
+	Count     int    `alias:"rate.count"`
+	IsCreator bool   `alias:"rate.is_creator"`
+}
+
+func (r *DownloadRate) IsReached() bool {
+	return !r.IsCreator && r.Limit != nil && r.Count >= int(*r.Limit)
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+type GiftArtDTO struct {
+	LicenseId int    `form:"licenseId" validate:"required"`
+	Price     int    `form:"price"     validate:"gte=0"`
+	Username  string `form:"username"  validate:"required"`
+	Message   string `form:"message"`
+}
+
+// GiftArtReq buys the license of the art for the recipient. The sender is the
+// user of the embedded BuyArtReq.
+type GiftArtReq struct {
+	BuyArtReq
+
+	RecipientName string
+	Message       string
+}
+
+func (r *GiftArtReq) Request() string {
+	return fmt.Sprintf(
+		"gift art %d license %d for %d to %s",
+		r.ArtId,
+		r.LicenseId,
+		r.Price,
+		r.RecipientName,
+	)
+}
+
+type Gift struct {
+	model.Gifts
+
+	Art     model.Arts
+	License *model.ArtLicenses
+	Sender  Creator `alias:"Sender.*"`
+}
+
+func (g *Gift) IsClaimed() bool {
+	return g.ClaimedAt != nil
+}
@@ -0,0 +1,184 @@
+package types
+
+import "github.com/DeepAung/deep-art/.gen/model"
+
+// types of coin transactions
+const (
+	CoinTxOpeningBalance     = "opening_balance"
+	CoinTxCodeRedemption     = "code_redemption"
+	CoinTxPurchase           = "purchase"
+	CoinTxRefund             = "refund"
+	CoinTxAdminAdjustment    = "admin_adjustment"
+	CoinTxTip                = "tip"
+	CoinTxTopUp              = "top_up"
+	CoinTxWithdrawal         = "withdrawal"
+	CoinTxWithdrawalReversal = "withdrawal_reversal"
+	CoinTxSubscription       = "subscription"
+	CoinTxCommissionEscrow   = "commission_escrow"
+	CoinTxCommissionRelease  = "commission_release"
+	CoinTxCommissionRefund   = "commission_refund"
+	CoinTxAuctionBid         = "auction_bid"
+	CoinTxAuctionRelease     = "auction_release"
+	// a purchase for another user, which the sender cannot refund
+	CoinTxGift = "gift"
+)
+
+// SaleTxTypes are the transactions that pay a creator for something sold:
+// arts, bundles, gifts, subscriptions, commissions and auctions. The earnings
+// they credit are held for the withdrawal hold after each sale. Tips are not
+// sales and can be withdrawn right away.
+var SaleTxTypes = []string{
+	CoinTxPurchase,
+	CoinTxGift,
+	CoinTxSubscription,
+	CoinTxCommissionRelease,
+	CoinTxAuctionRelease,
+}
+
+// accounts of coin transaction legs. The wallet and earnings accounts belong
+// to a user, the others are accounts of the platform.
+const (
+	AccountWallet      = "wallet"
+	AccountEarnings    = "earnings"
+	AccountCodes       = "codes"
+	AccountSales       = "sales"
+	AccountFees        = "fees"
+	AccountAdjustments = "adjustments"
+	AccountTopUps      = "top_ups"
+	AccountPayouts     = "payouts"
+	AccountEscrow      = "escrow"
+)
+
+const (
+	Debit  = "debit"
+	Credit = "credit"
+)
+
+type CoinTransactionReq struct {
+	Type        string
+	Description string
+	CodeId      *int
+	ArtId       *int
+	BundleId    *int
+	Legs        []CoinLeg
+}
+
+type CoinLeg struct {
+	Account   string
+	UserId    *int
+	Direction string
+	Amount    int
+}
+
+func WalletLeg(userId int, direction string, amount int) CoinLeg {
+	return CoinLeg{Account: AccountWallet, UserId: &userId, Direction: direction, Amount: amount}
+}
+
+func EarningsLeg(userId int, direction string, amount int) CoinLeg {
+	return CoinLeg{Account: AccountEarnings, UserId: &userId, Direction: direction, Amount: amount}
+}
+
+func AccountLeg(account string, direction string, amount int) CoinLeg {
+	return CoinLeg{Account: account, Direction: direction, Amount: amount}
+}
+
+func IsUserAccount(account string) bool {
+	return account == AccountWallet || account == AccountEarnings
+}
+
+// PlatformFee is the part of the price kept by the platform. It is rounded
+// down in favor of the creator.
+func PlatformFee(price, feePercent int) int {
+	return price * feePercent / 100
+}
+
+// SaleLegs moves the price from the buyer's wallet to the creator's earnings
+// and the platform fee to the fees account.
+func SaleLegs(buyerId, creatorId, price, feePercent int) []CoinLeg {
+	fee := PlatformFee(price, feePercent)
+	return []CoinLeg{
+		WalletLeg(buyerId, Debit, price),
+		EarningsLeg(creatorId, Credit, price-fee),
+		AccountLeg(AccountFees, Credit, fee),
+	}
+}
+
+// IsBalanced reports whether the debited amount of the legs equals the
+// credited amount.
+func (r *CoinTransactionReq) IsBalanced() bool {
+	balance := 0
+	for _, leg := range r.Legs {
+		switch leg.Direction {
+		case Debit:
+			balance -= leg.Amount
+		case Credit:
+			balance += leg.Amount
+		default:
+			return false
+		}
+	}
+	return balance == 0
+}
+
+type AdjustCoinDTO struct {
+	Username string `form:"username" validate:"required"`
+	Amount   int    `form:"amount"   validate:"required"`
+	Reason   string `form:"reason"   validate:"required"`
+}
+
+// WalletEntry is a leg of the user's wallet with its transaction.
+type WalletEntry struct {
+	model.CoinTransactionLegs
+
+	Transaction model.CoinTransactions
+	Art         *model.Arts
+	Code        *model.Codes
+	Bundle      *model.Bundles
+}
+
+// SignedAmount is the change of the wallet balance made by the entry.
+func (e *WalletEntry) SignedAmount() int {
+	if e.Direction == Debit {
+		return -int(e.Amount)
+	}
+	return int(e.Amount)
+}
+
+type WalletMismatch struct {
+	UserId          int    `alias:"users.id"`
+	Username        string `alias:"users.username"`
+	Coin            int    `alias:"users.coin"`
+	Balance         int    `alias:"wallet_mismatch.balance"`
+	Earnings        int    `alias:"users.earnings"`
+	EarningsBalance int    `alias:"wallet_mismatch.earnings_balance"`
+}
+
+type LedgerReport struct {
+	MismatchedWallets      []WalletMismatch
+	UnbalancedTransactions []int
+	CollectedFees          int
+}
+
+func (r *LedgerReport) IsHealthy() bool {
+	return len(r.MismatchedWallets) == 0 && len(r.UnbalancedTransactions) == 0
+}
+
+// Sale is an earnings entry of the creator with the fee kept by the platform.
+type Sale struct {
+	Transaction model.CoinTransactions
+	Art         *model.Arts
+	Bundle      *model.Bundles
+	Order       *model.Orders
+
+	Earned int `alias:"sale.earned"`
+	Fee    int `alias:"sale.fee"`
+}
+
+func (s *Sale) Price() int {
+	return s.Earned + s.Fee
+}
+
+type CreatorEarnings struct {
+	Balance int
+	Sales   []Sale
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	OrderKindArt     = "art"
+	OrderKindUpgrade = "upgrade"
+	OrderKindGift    = "gift"
+	OrderKindBundle  = "bundle"
+	OrderKindCart    = "cart"
+)
+
+// ReceiptNumber is the number printed on the receipt of the order. It must
+// match the numbers given to the orders by the migrations.
+func ReceiptNumber(orderId int, createdAt time.Time) string {
+	return fmt.Sprintf("DA-%d-%06d", createdAt.Year(), orderId)
+}
+
+// OrderReq records a purchase. Price of the items is what the user paid and
+// ListPrice is the price before the promotion and the coupon.
+type OrderReq struct {
+	UserId      int
+	Kind        string
+	Description string
+	Items       []OrderItemReq
+	FeePercent  int
+}
+
+type OrderItemReq struct {
+	ArtId       *int
+	LicenseId   *int
+	BundleId    *int
+	CreatorId   int
+	Name        string
+	LicenseName string
+	ListPrice   int
+	Price       int
+	CouponCode  string
+}
+
+func (r *OrderReq) Total() int {
+	total := 0
+	for _, item := range r.Items {
+		total += item.Price
+	}
+	return total
+}
+
+type Order struct {
+	model.Orders
+
+	Items       []model.OrderItems
+	Buyer       Creator `alias:"Buyer.*"`
+	Transaction *model.CoinTransactions
+}
+
+// Receipt returns the receipt number of the order.
+func (o *Order) Receipt() string {
+	if o.ReceiptNumber == nil {
+		return ""
+	}
+	return *o.ReceiptNumber
+}
+
+// Subtotal is the price of the items before their discounts.
+func (o *Order) Subtotal() int {
+	subtotal := 0
+	for _, item := range o.Items {
+		subtotal += int(item.ListPrice)
+	}
+	return subtotal
+}
+
+func (o *Order) Discount() int {
+	return o.Subtotal() - int(o.Total)
+}
+
+// IsRefunded reports whether the payment of the order was refunded. Free
+// orders have no payment.
+func (o *Order) IsRefunded() bool {
+	return o.Transaction != nil && o.Transaction.RefundID != nil
+}
+
+func (o *Order) Title() string {
+	if o.Description != "" {
+		return o.Description
+	}
+	switch o.Kind {
+	case OrderKindArt:
+		return "Art purchase"
+	case OrderKindBundle:
+		return "Bundle purchase"
+	case OrderKindCart:
+		return "Cart checkout"
+	}
+	return o.Kind
+}
+
+// OrderItemName names the item on the receipt with its license.
+func OrderItemName(item model.OrderItems) string {
+	if item.BundleID != nil || item.LicenseName == "" {
+		return "Bundle " + item.ArtName
+	}
+	return fmt.Sprintf("%s (%s)", item.ArtName, item.LicenseName)
+}
+
+// OrderSale is an item of an order sold by the creator.
+type OrderSale struct {
+	model.OrderItems
+
+	Order model.Orders
+	Buyer Creator `alias:"Buyer.*"`
+}
+
+func (s *OrderSale) Discount() int {
+	return int(s.ListPrice - s.Price)
+}
+
+func (s *OrderSale) Earned() int {
+	return int(s.Price - s.Fee)
+}
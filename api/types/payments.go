@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+// CoinPackageDTO has the price in the smallest unit of the currency, like
+// cents.
+type CoinPackageDTO struct {
+	Name     string `form:"name"     validate:"required"`
+	Coins    int    `form:"coins"    validate:"required,gt=0"`
+	Price    int    `form:"price"    validate:"required,gt=0"`
+	IsActive bool   `form:"isActive"`
+}
+
+type CreatePaymentReq struct {
+	UserId    int
+	PackageId int
+	Coins     int
+	Price     int
+	Currency  string
+	Provider  string
+}
+
+type Payment struct {
+	model.Payments
+
+	User model.Users
+}
+
+// FormatPrice formats an amount in the smallest unit of the currency, like
+// "4.99 USD".
+func FormatPrice(amount int, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+}
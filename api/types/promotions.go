@@ -0,0 +1,60 @@
+package types
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	PromotionScopeAll  = "all"
+	PromotionScopeArts = "arts"
+)
+
+type PromotionDTO struct {
+	Name     string     `form:"name"     validate:"required"`
+	Percent  int        `form:"percent"  validate:"required,gt=0,lt=100"`
+	Scope    string     `form:"scope"    validate:"required,oneof=all arts"`
+	ArtsID   []int      `form:"arts"`
+	StartsAt CustomTime `form:"startsAt" validate:"required"`
+	EndsAt   CustomTime `form:"endsAt"   validate:"required"`
+}
+
+type PromotionReq struct {
+	PromotionId int
+	CreatorId   int
+
+	Name     string
+	Percent  int
+	Scope    string
+	ArtsID   []int
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+type Promotion struct {
+	model.Promotions
+
+	Arts []model.Arts
+}
+
+// IsRunning reports whether the promotion applies at the given time.
+func (p *Promotion) IsRunning(now time.Time) bool {
+	return !now.Before(p.StartsAt) && now.Before(p.EndsAt)
+}
+
+// HasArt reports whether the art is picked for a promotion scoped to arts.
+func (p *Promotion) HasArt(artId int32) bool {
+	for _, art := range p.Arts {
+		if *art.ID == artId {
+			return true
+		}
+	}
+	return false
+}
+
+// PromotionPrice returns the price after the percentage off of a promotion.
+// It rounds the same way as the effective price computed by the database.
+func PromotionPrice(price, percent int) int {
+	return price - price*percent/100
+}
@@ -0,0 +1,66 @@
+package types
+
+import "github.com/DeepAung/deep-art/.gen/model"
+
+const (
+	RefundStatusPending  = "pending"
+	RefundStatusApproved = "approved"
+	RefundStatusRejected = "rejected"
+)
+
+type RefundRequestDTO struct {
+	Reason string `form:"reason" validate:"required"`
+}
+
+type RefundRequest struct {
+	model.RefundRequests
+
+	Art   model.Arts
+	Buyer Creator `alias:"Buyer.*"`
+}
+
+func (r *RefundRequest) IsPending() bool {
+	return r.Status == RefundStatusPending
+}
+
+// ReverseLegs returns the legs that undo the given legs. Legs of the same
+// account are merged, so reversing a purchase with its upgrades gives one leg
+// per account.
+func ReverseLegs(legs []model.CoinTransactionLegs) []CoinLeg {
+	type account struct {
+		name   string
+		userId int32
+	}
+
+	accounts := []account{}
+	balances := map[account]int{}
+	for _, leg := range legs {
+		acc := account{name: leg.Account}
+		if leg.UserID != nil {
+			acc.userId = *leg.UserID
+		}
+		if _, ok := balances[acc]; !ok {
+			accounts = append(accounts, acc)
+		}
+
+		if leg.Direction == Debit {
+			balances[acc] += int(leg.Amount)
+		} else {
+			balances[acc] -= int(leg.Amount)
+		}
+	}
+
+	reversed := make([]CoinLeg, 0, len(accounts))
+	for _, acc := range accounts {
+		leg := CoinLeg{Account: acc.name, Direction: Credit, Amount: balances[acc]}
+		if IsUserAccount(acc.name) {
+			userId := int(acc.userId)
+			leg.UserId = &userId
+		}
+		if leg.Amount < 0 {
+			leg.Direction, leg.Amount = Debit, -leg.Amount
+		}
+		reversed = append(reversed, leg)
+	}
+	return reversed
+}
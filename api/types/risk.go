@@ -0,0 +1,75 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+// actions screened by the fraud rules
+const (
+	RiskActionUseCode = "use_code"
+	RiskActionBuyArt  = "buy_art"
+)
+
+// decisions of the fraud rules, from the mildest. Only flagged, held and
+// blocked attempts are kept for review.
+const (
+	RiskDecisionAllow = "allow"
+	RiskDecisionFlag  = "flag"
+	RiskDecisionHold  = "hold"
+	RiskDecisionBlock = "block"
+)
+
+const (
+	RiskFlagStatusOpen      = "open"
+	RiskFlagStatusCleared   = "cleared"
+	RiskFlagStatusConfirmed = "confirmed"
+)
+
+const (
+	RiskRuleSharedIP         = "shared_ip"
+	RiskRuleNewAccount       = "new_account"
+	RiskRuleRefundCycle      = "refund_cycle"
+	RiskRulePurchaseVelocity = "purchase_velocity"
+)
+
+// RiskEventReq is an attempt to redeem a code or to buy an art. SubjectId is
+// the code or the art.
+type RiskEventReq struct {
+	UserId    int
+	Action    string
+	SubjectId int
+	IP        string
+	Now       time.Time
+}
+
+// RiskSignals are what the fraud rules score, counted over the risk window.
+type RiskSignals struct {
+	// accounts, the user included, that attempted the action on the subject
+	// from the ip of the attempt
+	IPAccounts int
+	Refunds    int
+	Purchases  int
+	IsNewUser  bool
+	// an admin cleared a hold or a block of the user on the subject
+	IsCleared bool
+}
+
+type RiskAssessment struct {
+	Score    int
+	Rules    []string
+	Decision string
+}
+
+type RiskFlag struct {
+	model.RiskFlags
+
+	User Creator `alias:"User.*"`
+	IP   string  `alias:"risk_events.ip"`
+}
+
+func (f *RiskFlag) RuleList() []string {
+	return strings.Split(f.Rules, ",")
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusCancelled = "cancelled"
+	SubscriptionStatusLapsed    = "lapsed"
+)
+
+type SubscriptionTierDTO struct {
+	Name        string `form:"name"        validate:"required"`
+	Description string `form:"description"`
+	Price       int    `form:"price"       validate:"required,gt=0"`
+}
+
+// SubscriptionTierReq creates or updates a tier of the creator. TierId is 0
+// when the tier is created.
+type SubscriptionTierReq struct {
+	TierId    int
+	CreatorId int
+
+	Name        string
+	Description string
+	Price       int
+}
+
+// SubscribeReq charges the first month of the tier to the user.
+type SubscribeReq struct {
+	UserId     int
+	TierId     int
+	FeePercent int
+}
+
+// SubscriptionTier is a tier of the creator with the subscription of the user
+// who views it, if there is one.
+type SubscriptionTier struct {
+	model.SubscriptionTiers
+
+	Subscribers  int `alias:"tier.subscribers"`
+	Subscription *model.Subscriptions
+}
+
+// IsSubscribed reports whether the user is renewed every month.
+func (t *SubscriptionTier) IsSubscribed() bool {
+	return t.Subscription != nil && t.Subscription.Status == SubscriptionStatusActive
+}
+
+// IsCancelled reports whether the user cancelled the subscription but still
+// has access until the end of the paid month.
+func (t *SubscriptionTier) IsCancelled(now time.Time) bool {
+	return t.Subscription != nil &&
+		t.Subscription.Status == SubscriptionStatusCancelled &&
+		t.Subscription.RenewsAt.After(now)
+}
+
+// Subscriber is a user with access to a tier of the creator.
+type Subscriber struct {
+	model.Subscriptions
+
+	Subscriber Creator `alias:"Subscriber.*"`
+	Tier       model.SubscriptionTiers
+}
+
+// NextRenewal is a month after the last renewal. A renewal that is more than
+// a month late starts a new month from now, so the missed months are not
+// charged at once.
+func NextRenewal(renewsAt, now time.Time) time.Time {
+	next := renewsAt.AddDate(0, 1, 0)
+	if !next.After(now) {
+		return now.AddDate(0, 1, 0)
+	}
+	return next
+}
+
+// MembersTierId turns the tier of the form into the one of the art, where 0
+// means the art is not members-only.
+func MembersTierId(tierId int) *int {
+	if tierId == 0 {
+		return nil
+	}
+	return &tierId
+}
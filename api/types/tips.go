@@ -0,0 +1,42 @@
+package types
+
+import "github.com/DeepAung/deep-art/.gen/model"
+
+type TipDTO struct {
+	Amount   int    `form:"amount"   validate:"required"`
+	Message  string `form:"message"`
+	IsPublic bool   `form:"isPublic"`
+	ArtId    int    `form:"artId"    validate:"gte=0"`
+}
+
+// TipReq gives coins of the sender to the creator. The art is only set when
+// the tip is given from the art's page.
+type TipReq struct {
+	SenderId  int
+	CreatorId int
+	ArtId     *int
+	Amount    int
+	Message   string
+	IsPublic  bool
+}
+
+type Tip struct {
+	model.Tips
+
+	Sender Creator `alias:"Sender.*"`
+	Art    *model.Arts
+}
+
+// Supporter is a user with the total coins they tipped to a creator.
+type Supporter struct {
+	UserId    int    `alias:"supporter.user_id"`
+	Username  string `alias:"supporter.username"`
+	AvatarURL string `alias:"supporter.avatar_url"`
+	Total     int    `alias:"supporter.total"`
+	Tips      int    `alias:"supporter.tips"`
+}
+
+type CreatorTips struct {
+	Supporters []Supporter
+	Tips       []Tip
+}
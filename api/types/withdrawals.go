@@ -0,0 +1,73 @@
+package types
+
+import (
+	"slices"
+	"time"
+
+	"github.com/DeepAung/deep-art/.gen/model"
+)
+
+const (
+	WithdrawalStatusPending  = "pending"
+	WithdrawalStatusApproved = "approved"
+	WithdrawalStatusRejected = "rejected"
+	WithdrawalStatusPaid     = "paid"
+)
+
+type WithdrawalDTO struct {
+	Amount int    `form:"amount" validate:"required,gt=0"`
+	Note   string `form:"note"`
+}
+
+type WithdrawalNoteDTO struct {
+	Note string `form:"note"`
+}
+
+type WithdrawalReq struct {
+	CreatorId int
+	Amount    int
+	Note      string
+	// HeldSince is the time after which sales are still on hold.
+	HeldSince time.Time
+}
+
+type WithdrawalDecisionReq struct {
+	Id        int
+	Status    string
+	AdminNote string
+	DeciderId int
+	Now       time.Time
+}
+
+type Withdrawal struct {
+	model.Withdrawals
+
+	Creator Creator `alias:"Creator.*"`
+}
+
+// withdrawalMoves are the statuses a withdrawal can move to from each status.
+// Approved withdrawals can still be rejected until they are paid.
+var withdrawalMoves = map[string][]string{
+	WithdrawalStatusPending:  {WithdrawalStatusApproved, WithdrawalStatusRejected},
+	WithdrawalStatusApproved: {WithdrawalStatusPaid, WithdrawalStatusRejected},
+}
+
+func (w *Withdrawal) CanMoveTo(status string) bool {
+	return slices.Contains(withdrawalMoves[w.Status], status)
+}
+
+// WithdrawalBalance splits the earnings of the creator into the coins that
+// can be withdrawn and the coins of recent sales that are still on hold.
+type WithdrawalBalance struct {
+	Earnings  int
+	Held      int
+	Available int
+}
+
+func NewWithdrawalBalance(earnings, held int) WithdrawalBalance {
+	return WithdrawalBalance{
+		Earnings:  earnings,
+		Held:      min(held, earnings),
+		Available: max(earnings-held, 0),
+	}
+}
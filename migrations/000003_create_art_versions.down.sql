@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "art_versions_files"; -- CASCADE;
+DROP TABLE IF EXISTS "art_versions"; -- CASCADE;
+DROP TABLE IF EXISTS "notifications"; -- CASCADE;
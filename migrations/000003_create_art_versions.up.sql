@@ -0,0 +1,38 @@
+CREATE TABLE "art_versions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "art_id" INT NOT NULL,
+  "version" INT NOT NULL,
+  "note" VARCHAR NOT NULL DEFAULT '',
+  "cover_url" VARCHAR NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("art_id", "version"),
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+-- snapshot of the art's files at the time the version is created
+CREATE TABLE "art_versions_files" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "version_id" INT NOT NULL,
+  "filename" VARCHAR NOT NULL,
+  "filetype" VARCHAR NOT NULL,
+  "url" VARCHAR NOT NULL,
+  FOREIGN KEY ("version_id") REFERENCES "art_versions" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "notifications" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "message" VARCHAR NOT NULL,
+  "link" VARCHAR NOT NULL DEFAULT '',
+  "is_read" BOOLEAN NOT NULL DEFAULT false,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- existing arts start at version 1
+INSERT INTO "art_versions" ("art_id", "version", "note", "cover_url", "created_at")
+  SELECT "id", 1, 'Initial version', "cover_url", "created_at" FROM "arts";
+
+INSERT INTO "art_versions_files" ("version_id", "filename", "filetype", "url")
+  SELECT "art_versions"."id", "files"."filename", "files"."filetype", "files"."url"
+  FROM "files" INNER JOIN "art_versions" ON "art_versions"."art_id" = "files"."art_id";
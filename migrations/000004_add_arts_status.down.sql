@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "arts_status_publish_at_idx";
+ALTER TABLE "arts" DROP COLUMN "publish_at";
+ALTER TABLE "arts" DROP COLUMN "status";
+
+DROP TRIGGER IF EXISTS [update_timestamp_oauths];
+CREATE TRIGGER [update_timestamp_oauths] AFTER UPDATE ON "oauths" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "oauths" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
@@ -0,0 +1,16 @@
+-- "oauths" has no "id" column, sqlite refuses to alter any table while this
+-- trigger is broken
+DROP TRIGGER IF EXISTS [update_timestamp_oauths];
+CREATE TRIGGER [update_timestamp_oauths] AFTER UPDATE ON "oauths" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "oauths" SET "updated_at"=CURRENT_TIMESTAMP WHERE "provider"=OLD."provider" AND "provider_user_id"=OLD."provider_user_id"; END;
+
+-- draft: only the creator can see it
+-- scheduled: becomes published at "publish_at"
+-- published: visible to everyone and listed in search
+-- unlisted: visible to everyone who has the link, not listed in search
+-- archived: no longer listed or sold, buyers keep their access
+ALTER TABLE "arts" ADD COLUMN "status" VARCHAR NOT NULL DEFAULT 'published'
+  CHECK ("status" IN ('draft', 'scheduled', 'published', 'unlisted', 'archived'));
+ALTER TABLE "arts" ADD COLUMN "publish_at" TIMESTAMP;
+
+CREATE INDEX "arts_status_publish_at_idx" ON "arts" ("status", "publish_at");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "arts_deleted_at_idx";
+ALTER TABLE "arts" DROP COLUMN "deleted_at";
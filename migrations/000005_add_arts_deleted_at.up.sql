@@ -0,0 +1,5 @@
+-- soft deleted arts stay in the creator's trash until they are restored or
+-- purged. arts that were bought are never purged, buyers keep their access.
+ALTER TABLE "arts" ADD COLUMN "deleted_at" TIMESTAMP;
+
+CREATE INDEX "arts_deleted_at_idx" ON "arts" ("deleted_at");
@@ -0,0 +1,15 @@
+-- sqlite cannot drop a column that is used in a foreign key
+CREATE TABLE "users_bought_arts_old" (
+  "user_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  PRIMARY KEY ("user_id", "art_id"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+INSERT INTO "users_bought_arts_old" ("user_id", "art_id")
+  SELECT "user_id", "art_id" FROM "users_bought_arts";
+DROP TABLE "users_bought_arts";
+ALTER TABLE "users_bought_arts_old" RENAME TO "users_bought_arts";
+
+DROP TRIGGER IF EXISTS [update_timestamp_art_licenses];
+DROP TABLE IF EXISTS "art_licenses";
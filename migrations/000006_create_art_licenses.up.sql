@@ -0,0 +1,29 @@
+-- every art is sold under one or more license tiers, e.g. personal,
+-- commercial and extended. "download_cap" limits how many times a buyer of
+-- the tier can download the art, NULL means unlimited.
+CREATE TABLE "art_licenses" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "art_id" INT NOT NULL,
+  "name" VARCHAR NOT NULL,
+  "price" INT NOT NULL CHECK ("price" >= 0),
+  "terms" TEXT NOT NULL DEFAULT '',
+  "download_cap" INT CHECK ("download_cap" > 0),
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("art_id", "name"),
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+CREATE TRIGGER [update_timestamp_art_licenses] AFTER UPDATE ON "art_licenses" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "art_licenses" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+ALTER TABLE "users_bought_arts" ADD COLUMN "license_id" INT REFERENCES "art_licenses" ("id");
+ALTER TABLE "users_bought_arts" ADD COLUMN "download_count" INT NOT NULL DEFAULT 0;
+
+-- existing arts are sold under a single personal license
+INSERT INTO "art_licenses" ("art_id", "name", "price", "terms")
+  SELECT "id", 'Personal', "price", 'For personal, non-commercial use only.' FROM "arts";
+
+UPDATE "users_bought_arts" SET "license_id" = (
+  SELECT "id" FROM "art_licenses" WHERE "art_licenses"."art_id" = "users_bought_arts"."art_id"
+);
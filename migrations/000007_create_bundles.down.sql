@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS [update_timestamp_bundles];
+DROP TABLE IF EXISTS "users_bought_bundles";
+DROP TABLE IF EXISTS "bundles_arts";
+DROP TABLE IF EXISTS "bundles";
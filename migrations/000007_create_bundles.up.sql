@@ -0,0 +1,33 @@
+CREATE TABLE "bundles" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "creator_id" INT NOT NULL,
+  "name" VARCHAR NOT NULL,
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "price" INT NOT NULL CHECK ("price" >= 0),
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "bundles_arts" (
+  "bundle_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  PRIMARY KEY ("bundle_id", "art_id"),
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+-- the arts themselves are granted in "users_bought_arts", this only keeps
+-- what the user paid for the bundle
+CREATE TABLE "users_bought_bundles" (
+  "user_id" INT NOT NULL,
+  "bundle_id" INT NOT NULL,
+  "price" INT NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "bundle_id"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE CASCADE
+);
+
+CREATE TRIGGER [update_timestamp_bundles] AFTER UPDATE ON "bundles" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "bundles" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "coin_transaction_legs";
+DROP TABLE IF EXISTS "coin_transactions";
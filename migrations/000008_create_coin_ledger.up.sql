@@ -0,0 +1,40 @@
+-- every change of a coin balance is a transaction with balanced legs, the
+-- debited amount of a transaction always equals its credited amount.
+-- "users"."coin" is a cached balance of the user's wallet.
+CREATE TABLE "coin_transactions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+
+-- "user_id" has no foreign key so the ledger outlives deleted users
+CREATE TABLE "coin_transaction_legs" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL CHECK ("account" IN ('wallet', 'codes', 'sales', 'adjustments')),
+  "user_id" INT CHECK (("account" = 'wallet') = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+-- open the existing balances, the transaction ids are the user ids
+INSERT INTO "coin_transactions" ("id", "type", "description")
+  SELECT "id", 'opening_balance', 'Balance before the ledger' FROM "users" WHERE "coin" > 0;
+
+INSERT INTO "coin_transaction_legs" ("transaction_id", "account", "user_id", "direction", "amount")
+  SELECT "id", 'adjustments', NULL, 'debit', "coin" FROM "users" WHERE "coin" > 0;
+
+INSERT INTO "coin_transaction_legs" ("transaction_id", "account", "user_id", "direction", "amount")
+  SELECT "id", 'wallet', "id", 'credit', "coin" FROM "users" WHERE "coin" > 0;
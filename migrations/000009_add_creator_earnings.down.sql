@@ -0,0 +1,26 @@
+-- earnings and fees go back to the sales account so the transactions stay balanced
+CREATE TABLE "coin_transaction_legs_old" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL CHECK ("account" IN ('wallet', 'codes', 'sales', 'adjustments')),
+  "user_id" INT CHECK (("account" = 'wallet') = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_old"
+  SELECT
+    "id",
+    "transaction_id",
+    CASE WHEN "account" IN ('earnings', 'fees') THEN 'sales' ELSE "account" END,
+    CASE WHEN "account" = 'earnings' THEN NULL ELSE "user_id" END,
+    "direction",
+    "amount"
+  FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_old" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+ALTER TABLE "users" DROP COLUMN "earnings";
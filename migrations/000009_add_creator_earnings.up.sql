@@ -0,0 +1,20 @@
+-- cached balance of the creator's earnings account
+ALTER TABLE "users" ADD COLUMN "earnings" INT NOT NULL DEFAULT 0;
+
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transaction_legs_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL
+    CHECK ("account" IN ('wallet', 'earnings', 'codes', 'sales', 'fees', 'adjustments')),
+  "user_id" INT CHECK (("account" IN ('wallet', 'earnings')) = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_new" SELECT * FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_new" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
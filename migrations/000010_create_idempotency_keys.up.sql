@@ -0,0 +1,10 @@
+-- keys of requests that already succeeded, so that retried requests with the
+-- same key do not run twice. "request" describes what the key was used for.
+CREATE TABLE "idempotency_keys" (
+  "user_id" INT NOT NULL,
+  "key" VARCHAR NOT NULL,
+  "request" VARCHAR NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "key"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS [update_timestamp_refund_requests];
+DROP TABLE IF EXISTS "refund_requests";
+
+ALTER TABLE "coin_transactions" DROP COLUMN "refund_id";
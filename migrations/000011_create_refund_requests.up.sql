@@ -0,0 +1,27 @@
+-- the refund transaction that reversed a purchase. Ledger rows are never
+-- deleted, so the column needs no foreign key.
+ALTER TABLE "coin_transactions" ADD COLUMN "refund_id" INT;
+
+CREATE TABLE "refund_requests" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  "reason" VARCHAR NOT NULL DEFAULT '',
+  "status" VARCHAR NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "amount" INT NOT NULL DEFAULT 0,
+  "decided_by" INT,
+  "transaction_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("decided_by") REFERENCES "users" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+-- a purchase can only have one pending request at a time
+CREATE UNIQUE INDEX "refund_requests_pending_idx" ON "refund_requests" ("user_id", "art_id")
+  WHERE "status" = 'pending';
+
+CREATE TRIGGER [update_timestamp_refund_requests] AFTER UPDATE ON "refund_requests" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "refund_requests" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
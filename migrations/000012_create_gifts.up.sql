@@ -0,0 +1,19 @@
+-- arts bought by one user for another. The recipient owns the art from the
+-- moment it is bought, claiming only opens the gift.
+CREATE TABLE "gifts" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "sender_id" INT NOT NULL,
+  "recipient_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  "license_id" INT,
+  "message" VARCHAR NOT NULL DEFAULT '',
+  "price" INT NOT NULL,
+  "claimed_at" TIMESTAMP,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("sender_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("recipient_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("license_id") REFERENCES "art_licenses" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "gifts_recipient_id_idx" ON "gifts" ("recipient_id");
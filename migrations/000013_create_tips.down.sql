@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS "tips";
+
+-- tips stay in the ledger as adjustments, so the balances still add up
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new"
+  SELECT "id", CASE "type" WHEN 'tip' THEN 'admin_adjustment' ELSE "type" END, "description",
+    "code_id", "art_id", "bundle_id", "created_at", "refund_id"
+  FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
@@ -0,0 +1,38 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- coins given to a creator on top of buying their arts. Private messages are
+-- only shown to the creator.
+CREATE TABLE "tips" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "sender_id" INT NOT NULL,
+  "creator_id" INT NOT NULL,
+  "art_id" INT,
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  "message" VARCHAR NOT NULL DEFAULT '',
+  "is_public" BOOLEAN NOT NULL DEFAULT TRUE,
+  "transaction_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("sender_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "tips_creator_id_idx" ON "tips" ("creator_id");
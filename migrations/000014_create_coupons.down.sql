@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS [update_timestamp_coupons];
+DROP TABLE IF EXISTS "coupon_redemptions";
+DROP TABLE IF EXISTS "coupons_tags";
+DROP TABLE IF EXISTS "coupons_arts";
+DROP TABLE IF EXISTS "coupons";
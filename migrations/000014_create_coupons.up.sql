@@ -0,0 +1,51 @@
+-- discount coupons of a creator. They only apply to the arts of the creator,
+-- narrowed down by "coupons_arts" or "coupons_tags" depending on the scope.
+CREATE TABLE "coupons" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "creator_id" INT NOT NULL,
+  "code" VARCHAR NOT NULL,
+  "kind" VARCHAR NOT NULL CHECK ("kind" IN ('percent', 'fixed')),
+  "value" INT NOT NULL CHECK ("value" > 0 AND ("kind" != 'percent' OR "value" <= 100)),
+  "scope" VARCHAR NOT NULL DEFAULT 'all' CHECK ("scope" IN ('all', 'arts', 'tags')),
+  "exp_time" TIMESTAMP,
+  "max_uses" INT CHECK ("max_uses" > 0),
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("creator_id", "code"),
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "coupons_arts" (
+  "coupon_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  PRIMARY KEY ("coupon_id", "art_id"),
+  FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "coupons_tags" (
+  "coupon_id" INT NOT NULL,
+  "tag_id" INT NOT NULL,
+  PRIMARY KEY ("coupon_id", "tag_id"),
+  FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE
+);
+
+-- every purchase made with a coupon, price is what the user paid
+CREATE TABLE "coupon_redemptions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "coupon_id" INT NOT NULL,
+  "user_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  "price" INT NOT NULL,
+  "discount" INT NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "coupon_redemptions_coupon_id_idx" ON "coupon_redemptions" ("coupon_id");
+
+CREATE TRIGGER [update_timestamp_coupons] AFTER UPDATE ON "coupons" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "coupons" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS [update_timestamp_promotions];
+DROP TABLE IF EXISTS "promotions_arts";
+DROP TABLE IF EXISTS "promotions";
@@ -0,0 +1,28 @@
+-- time-boxed sales of a creator. The effective price of an art is its price
+-- minus the best promotion running at the moment.
+CREATE TABLE "promotions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "creator_id" INT NOT NULL,
+  "name" VARCHAR NOT NULL,
+  "percent" INT NOT NULL CHECK ("percent" > 0 AND "percent" < 100),
+  "scope" VARCHAR NOT NULL DEFAULT 'all' CHECK ("scope" IN ('all', 'arts')),
+  "starts_at" TIMESTAMP NOT NULL,
+  "ends_at" TIMESTAMP NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  CHECK ("ends_at" > "starts_at"),
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "promotions_arts" (
+  "promotion_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  PRIMARY KEY ("promotion_id", "art_id"),
+  FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "promotions_creator_id_idx" ON "promotions" ("creator_id");
+
+CREATE TRIGGER [update_timestamp_promotions] AFTER UPDATE ON "promotions" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "promotions" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
@@ -0,0 +1,21 @@
+CREATE TABLE "users_used_codes_old" (
+  "user_id" INT NOT NULL,
+  "code_id" INT NOT NULL,
+  PRIMARY KEY ("user_id", "code_id"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE CASCADE
+);
+INSERT INTO "users_used_codes_old" ("user_id", "code_id")
+  SELECT DISTINCT "user_id", "code_id" FROM "users_used_codes";
+DROP TABLE "users_used_codes";
+ALTER TABLE "users_used_codes_old" RENAME TO "users_used_codes";
+
+DROP TABLE IF EXISTS "codes_users";
+
+DROP INDEX IF EXISTS "codes_batch_idx";
+ALTER TABLE "codes" DROP COLUMN "batch";
+ALTER TABLE "codes" DROP COLUMN "segment";
+ALTER TABLE "codes" DROP COLUMN "disabled";
+ALTER TABLE "codes" DROP COLUMN "starts_at";
+ALTER TABLE "codes" DROP COLUMN "per_user_limit";
+ALTER TABLE "codes" DROP COLUMN "max_uses";
@@ -0,0 +1,39 @@
+-- a code without max_uses can be redeemed any number of times in total
+ALTER TABLE "codes" ADD COLUMN "max_uses" INT CHECK ("max_uses" > 0);
+ALTER TABLE "codes" ADD COLUMN "per_user_limit" INT NOT NULL DEFAULT 1 CHECK ("per_user_limit" > 0);
+ALTER TABLE "codes" ADD COLUMN "starts_at" TIMESTAMP;
+ALTER TABLE "codes" ADD COLUMN "disabled" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "codes" ADD COLUMN "segment" VARCHAR NOT NULL DEFAULT 'all'
+  CHECK ("segment" IN ('all', 'new_users', 'users'));
+-- codes generated together share a batch, single codes have none
+ALTER TABLE "codes" ADD COLUMN "batch" VARCHAR NOT NULL DEFAULT '';
+
+CREATE INDEX "codes_batch_idx" ON "codes" ("batch");
+
+-- the users allowed to redeem a code of the 'users' segment
+CREATE TABLE "codes_users" (
+  "code_id" INT NOT NULL,
+  "user_id" INT NOT NULL,
+  PRIMARY KEY ("code_id", "user_id"),
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- sqlite cannot drop a primary key, and a user may now redeem a code more than
+-- once. The value is kept since the code can be edited after it is redeemed.
+CREATE TABLE "users_used_codes_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "code_id" INT NOT NULL,
+  "value" INT NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE CASCADE
+);
+INSERT INTO "users_used_codes_new" ("user_id", "code_id", "value")
+  SELECT u."user_id", u."code_id", c."value"
+  FROM "users_used_codes" u JOIN "codes" c ON c."id" = u."code_id";
+DROP TABLE "users_used_codes";
+ALTER TABLE "users_used_codes_new" RENAME TO "users_used_codes";
+
+CREATE INDEX "users_used_codes_code_id_user_id_idx" ON "users_used_codes" ("code_id", "user_id");
@@ -0,0 +1,77 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- coins bought with real money are debited from the top_ups account
+CREATE TABLE "coin_transaction_legs_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL
+    CHECK ("account" IN ('wallet', 'earnings', 'codes', 'sales', 'fees', 'adjustments', 'top_ups')),
+  "user_id" INT CHECK (("account" IN ('wallet', 'earnings')) = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_new" SELECT * FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_new" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+-- the price is in the smallest unit of the currency, like cents
+CREATE TABLE "coin_packages" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" VARCHAR NOT NULL,
+  "coins" INT NOT NULL CHECK ("coins" > 0),
+  "price" INT NOT NULL CHECK ("price" > 0),
+  "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER [update_timestamp_coin_packages] AFTER UPDATE ON "coin_packages" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "coin_packages" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+-- the coins and price are copied from the package, which can change or be
+-- deleted while the payment is pending
+CREATE TABLE "payments" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "package_id" INT,
+  "coins" INT NOT NULL CHECK ("coins" > 0),
+  "price" INT NOT NULL CHECK ("price" > 0),
+  "currency" VARCHAR NOT NULL,
+  "provider" VARCHAR NOT NULL,
+  "provider_id" VARCHAR UNIQUE,
+  "status" VARCHAR NOT NULL DEFAULT 'pending'
+    CHECK ("status" IN ('pending', 'succeeded', 'failed')),
+  "transaction_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("package_id") REFERENCES "coin_packages" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "payments_user_id_idx" ON "payments" ("user_id");
+CREATE INDEX "payments_status_idx" ON "payments" ("status");
+
+CREATE TRIGGER [update_timestamp_payments] AFTER UPDATE ON "payments" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "payments" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
@@ -0,0 +1,65 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- withdrawn earnings are held in the payouts account until they are paid out,
+-- or given back when the withdrawal is rejected
+CREATE TABLE "coin_transaction_legs_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL
+    CHECK ("account" IN ('wallet', 'earnings', 'codes', 'sales', 'fees', 'adjustments', 'top_ups', 'payouts')),
+  "user_id" INT CHECK (("account" IN ('wallet', 'earnings')) = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_new" SELECT * FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_new" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+-- "note" is written by the creator, like where to send the money, and
+-- "admin_note" by the admin who last moved the withdrawal
+CREATE TABLE "withdrawals" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "creator_id" INT NOT NULL,
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  "status" VARCHAR NOT NULL DEFAULT 'pending'
+    CHECK ("status" IN ('pending', 'approved', 'rejected', 'paid')),
+  "note" VARCHAR NOT NULL DEFAULT '',
+  "admin_note" VARCHAR NOT NULL DEFAULT '',
+  "decided_by" INT,
+  "transaction_id" INT,
+  "reversal_id" INT,
+  "paid_at" TIMESTAMP,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("decided_by") REFERENCES "users" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("reversal_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "withdrawals_creator_id_idx" ON "withdrawals" ("creator_id");
+CREATE INDEX "withdrawals_status_idx" ON "withdrawals" ("status");
+
+CREATE TRIGGER [update_timestamp_withdrawals] AFTER UPDATE ON "withdrawals" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "withdrawals" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
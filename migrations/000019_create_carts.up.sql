@@ -0,0 +1,44 @@
+CREATE TABLE "cart_items" (
+  "user_id" INT NOT NULL,
+  "art_id" INT NOT NULL,
+  "license_id" INT NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "art_id"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("license_id") REFERENCES "art_licenses" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "orders" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "total" INT NOT NULL,
+  "transaction_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "orders_user_id_idx" ON "orders" ("user_id");
+
+-- the names are copied so that the order still reads the same after the art
+-- or the license is changed or deleted. "fee" is the part of "price" kept by
+-- the platform.
+CREATE TABLE "order_items" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "order_id" INT NOT NULL,
+  "art_id" INT,
+  "license_id" INT,
+  "creator_id" INT,
+  "art_name" VARCHAR NOT NULL,
+  "license_name" VARCHAR NOT NULL,
+  "price" INT NOT NULL,
+  "fee" INT NOT NULL,
+  FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("license_id") REFERENCES "art_licenses" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "order_items_order_id_idx" ON "order_items" ("order_id");
+CREATE INDEX "order_items_art_id_idx" ON "order_items" ("art_id");
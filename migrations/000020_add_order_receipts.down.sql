@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS "order_items_creator_id_idx";
+
+ALTER TABLE "order_items" DROP COLUMN "bundle_id";
+ALTER TABLE "order_items" DROP COLUMN "coupon_code";
+ALTER TABLE "order_items" DROP COLUMN "list_price";
+
+DROP INDEX IF EXISTS "orders_receipt_number_idx";
+
+-- orders of single purchases did not exist before
+DELETE FROM "orders" WHERE "kind" != 'cart';
+
+ALTER TABLE "orders" DROP COLUMN "receipt_number";
+ALTER TABLE "orders" DROP COLUMN "description";
+ALTER TABLE "orders" DROP COLUMN "kind";
@@ -0,0 +1,22 @@
+-- every purchase now leaves an order, not only the checkouts of the cart.
+-- "description" tells who a gift was for and which license was upgraded.
+ALTER TABLE "orders" ADD COLUMN "kind" VARCHAR NOT NULL DEFAULT 'cart'
+  CHECK ("kind" IN ('art', 'upgrade', 'gift', 'bundle', 'cart'));
+ALTER TABLE "orders" ADD COLUMN "description" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "orders" ADD COLUMN "receipt_number" VARCHAR;
+
+UPDATE "orders"
+SET "receipt_number" = 'DA-' || strftime('%Y', "created_at") || '-' || printf('%06d', "id");
+
+CREATE UNIQUE INDEX "orders_receipt_number_idx" ON "orders" ("receipt_number");
+
+-- "list_price" is the price before the promotion and the coupon, so the
+-- discount of the item is "list_price" - "price". Bundles are a single item
+-- without an art.
+ALTER TABLE "order_items" ADD COLUMN "list_price" INT NOT NULL DEFAULT 0;
+ALTER TABLE "order_items" ADD COLUMN "coupon_code" VARCHAR;
+ALTER TABLE "order_items" ADD COLUMN "bundle_id" INT REFERENCES "bundles" ("id") ON DELETE SET NULL;
+
+UPDATE "order_items" SET "list_price" = "price";
+
+CREATE INDEX "order_items_creator_id_idx" ON "order_items" ("creator_id");
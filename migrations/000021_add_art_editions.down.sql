@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "users_bought_arts_edition_number_idx";
+
+ALTER TABLE "users_bought_arts" DROP COLUMN "edition_number";
+ALTER TABLE "arts" DROP COLUMN "edition_size";
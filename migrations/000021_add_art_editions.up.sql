@@ -0,0 +1,8 @@
+-- a limited-edition art only sells "edition_size" copies, NULL is unlimited.
+-- Every copy sold after the art became limited gets its own edition number.
+ALTER TABLE "arts" ADD COLUMN "edition_size" INT CHECK ("edition_size" > 0);
+ALTER TABLE "users_bought_arts" ADD COLUMN "edition_number" INT;
+
+CREATE UNIQUE INDEX "users_bought_arts_edition_number_idx"
+  ON "users_bought_arts" ("art_id", "edition_number")
+  WHERE "edition_number" IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE "arts" DROP COLUMN "suggested_price";
+ALTER TABLE "arts" DROP COLUMN "pay_what_you_want";
@@ -0,0 +1,4 @@
+-- the buyers of a pay-what-you-want art choose how much they pay, at least
+-- the price of the license. "suggested_price" is the amount offered first.
+ALTER TABLE "arts" ADD COLUMN "pay_what_you_want" BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE "arts" ADD COLUMN "suggested_price" INT CHECK ("suggested_price" >= 0);
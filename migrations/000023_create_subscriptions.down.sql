@@ -0,0 +1,26 @@
+ALTER TABLE "arts" DROP COLUMN "members_tier_id";
+DROP TABLE IF EXISTS "subscriptions";
+DROP TABLE IF EXISTS "subscription_tiers";
+
+-- subscriptions stay in the ledger as tips, so the balances still add up
+CREATE TABLE "coin_transactions_old" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_old"
+  SELECT "id",
+    CASE "type" WHEN 'subscription' THEN 'tip' ELSE "type" END,
+    "description", "code_id", "art_id", "bundle_id", "created_at", "refund_id"
+  FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_old" RENAME TO "coin_transactions";
@@ -0,0 +1,63 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal', 'subscription')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- the price of a tier is charged every month from the wallet of its
+-- subscribers
+CREATE TABLE "subscription_tiers" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "creator_id" INT NOT NULL,
+  "name" VARCHAR NOT NULL,
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "price" INT NOT NULL CHECK ("price" > 0),
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("creator_id", "name"),
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE TRIGGER [update_timestamp_subscription_tiers] AFTER UPDATE ON "subscription_tiers" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "subscription_tiers" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+-- a subscription is paid until "renews_at". A cancelled subscription is not
+-- renewed but keeps its access until then, and a lapsed one could not be
+-- renewed because the wallet did not have enough coins.
+CREATE TABLE "subscriptions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "tier_id" INT NOT NULL,
+  "status" VARCHAR NOT NULL DEFAULT 'active'
+    CHECK ("status" IN ('active', 'cancelled', 'lapsed')),
+  "renews_at" TIMESTAMP NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("user_id", "tier_id"),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("tier_id") REFERENCES "subscription_tiers" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "subscriptions_tier_id_idx" ON "subscriptions" ("tier_id");
+CREATE INDEX "subscriptions_status_renews_at_idx" ON "subscriptions" ("status", "renews_at");
+
+CREATE TRIGGER [update_timestamp_subscriptions] AFTER UPDATE ON "subscriptions" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "subscriptions" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+-- members-only arts are not sold, they are downloaded by the subscribers of
+-- the tier or of a pricier tier of the same creator
+ALTER TABLE "arts" ADD COLUMN "members_tier_id" INT
+  REFERENCES "subscription_tiers" ("id") ON DELETE SET NULL;
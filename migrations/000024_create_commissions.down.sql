@@ -0,0 +1,47 @@
+DROP TABLE IF EXISTS "commission_events";
+DROP TABLE IF EXISTS "commission_files";
+DROP TABLE IF EXISTS "commissions";
+
+-- commissions stay in the ledger as adjustments, so the balances still add up
+CREATE TABLE "coin_transaction_legs_old" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL
+    CHECK ("account" IN ('wallet', 'earnings', 'codes', 'sales', 'fees', 'adjustments', 'top_ups', 'payouts')),
+  "user_id" INT CHECK (("account" IN ('wallet', 'earnings')) = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_old"
+  SELECT "id", "transaction_id",
+    CASE "account" WHEN 'escrow' THEN 'adjustments' ELSE "account" END,
+    "user_id", "direction", "amount"
+  FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_old" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+CREATE TABLE "coin_transactions_old" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal', 'subscription')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_old"
+  SELECT "id",
+    CASE WHEN "type" IN ('commission_escrow', 'commission_release', 'commission_refund') THEN 'admin_adjustment' ELSE "type" END,
+    "description", "code_id", "art_id", "bundle_id", "created_at", "refund_id"
+  FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_old" RENAME TO "coin_transactions";
@@ -0,0 +1,93 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal', 'subscription', 'commission_escrow', 'commission_release', 'commission_refund')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- the coins of an accepted quote are held in the escrow account until the
+-- buyer accepts the delivery, or an admin settles the dispute
+CREATE TABLE "coin_transaction_legs_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "transaction_id" INT NOT NULL,
+  "account" VARCHAR NOT NULL
+    CHECK ("account" IN ('wallet', 'earnings', 'codes', 'sales', 'fees', 'adjustments', 'top_ups', 'payouts', 'escrow')),
+  "user_id" INT CHECK (("account" IN ('wallet', 'earnings')) = ("user_id" IS NOT NULL)),
+  "direction" VARCHAR NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  FOREIGN KEY ("transaction_id") REFERENCES "coin_transactions" ("id") ON DELETE CASCADE
+);
+INSERT INTO "coin_transaction_legs_new" SELECT * FROM "coin_transaction_legs";
+DROP TABLE "coin_transaction_legs";
+ALTER TABLE "coin_transaction_legs_new" RENAME TO "coin_transaction_legs";
+
+CREATE INDEX "coin_transaction_legs_user_id_idx" ON "coin_transaction_legs" ("user_id");
+CREATE INDEX "coin_transaction_legs_transaction_id_idx" ON "coin_transaction_legs" ("transaction_id");
+
+-- "budget" is what the buyer offers and "quote" what the creator asks for.
+-- "escrow_id" holds the quote and "settlement_id" releases or refunds it.
+CREATE TABLE "commissions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "buyer_id" INT NOT NULL,
+  "creator_id" INT NOT NULL,
+  "brief" VARCHAR NOT NULL,
+  "budget" INT NOT NULL CHECK ("budget" > 0),
+  "quote" INT CHECK ("quote" > 0),
+  "status" VARCHAR NOT NULL DEFAULT 'requested'
+    CHECK ("status" IN ('requested', 'quoted', 'accepted', 'delivered', 'completed', 'disputed', 'refunded', 'declined', 'cancelled')),
+  "escrow_id" INT,
+  "settlement_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  CHECK ("buyer_id" != "creator_id"),
+  FOREIGN KEY ("buyer_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("creator_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("escrow_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("settlement_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "commissions_buyer_id_idx" ON "commissions" ("buyer_id");
+CREATE INDEX "commissions_creator_id_idx" ON "commissions" ("creator_id");
+CREATE INDEX "commissions_status_idx" ON "commissions" ("status");
+
+CREATE TRIGGER [update_timestamp_commissions] AFTER UPDATE ON "commissions" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "commissions" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+CREATE TABLE "commission_files" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "commission_id" INT NOT NULL,
+  "filename" VARCHAR NOT NULL,
+  "url" VARCHAR NOT NULL,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("commission_id") REFERENCES "commissions" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "commission_files_commission_id_idx" ON "commission_files" ("commission_id");
+
+-- every move of a commission, with who made it. "from_status" is null for
+-- the request itself.
+CREATE TABLE "commission_events" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "commission_id" INT NOT NULL,
+  "actor_id" INT,
+  "from_status" VARCHAR,
+  "to_status" VARCHAR NOT NULL,
+  "note" VARCHAR NOT NULL DEFAULT '',
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("commission_id") REFERENCES "commissions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "commission_events_commission_id_idx" ON "commission_events" ("commission_id");
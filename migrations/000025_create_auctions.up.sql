@@ -0,0 +1,68 @@
+-- sqlite cannot alter a check constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal', 'subscription', 'commission_escrow', 'commission_release', 'commission_refund', 'auction_bid', 'auction_release')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- an auction sells the only copy of the art under "license_id". A bid placed
+-- in the last "extension_seconds" pushes "ends_at" back by that much, so
+-- nobody can win by bidding at the last second.
+CREATE TABLE "auctions" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "art_id" INT NOT NULL,
+  "license_id" INT NOT NULL,
+  "start_price" INT NOT NULL CHECK ("start_price" > 0),
+  "min_increment" INT NOT NULL CHECK ("min_increment" > 0),
+  "reserve_price" INT CHECK ("reserve_price" > 0),
+  "ends_at" TIMESTAMP NOT NULL,
+  "extension_seconds" INT NOT NULL DEFAULT 300 CHECK ("extension_seconds" >= 0),
+  "status" VARCHAR NOT NULL DEFAULT 'open'
+    CHECK ("status" IN ('open', 'sold', 'unsold', 'cancelled')),
+  "winner_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("license_id") REFERENCES "art_licenses" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("winner_id") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX "auctions_open_art_id_idx" ON "auctions" ("art_id") WHERE "status" = 'open';
+CREATE INDEX "auctions_status_ends_at_idx" ON "auctions" ("status", "ends_at");
+
+CREATE TRIGGER [update_timestamp_auctions] AFTER UPDATE ON "auctions" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "auctions" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
+
+-- the coins of the leading bid are reserved by "reservation_id" and given
+-- back by "release_id" once the bid is outbid or the auction does not sell
+CREATE TABLE "auction_bids" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "auction_id" INT NOT NULL,
+  "user_id" INT NOT NULL,
+  "amount" INT NOT NULL CHECK ("amount" > 0),
+  "status" VARCHAR NOT NULL DEFAULT 'leading'
+    CHECK ("status" IN ('leading', 'outbid', 'won', 'released')),
+  "reservation_id" INT,
+  "release_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("auction_id") REFERENCES "auctions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("reservation_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("release_id") REFERENCES "coin_transactions" ("id") ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX "auction_bids_leading_idx" ON "auction_bids" ("auction_id") WHERE "status" = 'leading';
+CREATE INDEX "auction_bids_auction_id_idx" ON "auction_bids" ("auction_id");
+CREATE INDEX "auction_bids_user_id_idx" ON "auction_bids" ("user_id");
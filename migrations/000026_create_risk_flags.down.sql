@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "risk_flags";
+DROP TABLE IF EXISTS "risk_events";
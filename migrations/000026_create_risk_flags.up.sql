@@ -0,0 +1,40 @@
+-- every use_code and buy_art attempt screened by the fraud rules. "subject_id"
+-- is the code or the art of the attempt.
+CREATE TABLE "risk_events" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" INT NOT NULL,
+  "action" VARCHAR NOT NULL CHECK ("action" IN ('use_code', 'buy_art')),
+  "subject_id" INT NOT NULL,
+  "ip" VARCHAR NOT NULL DEFAULT '',
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX "risk_events_user_id_idx" ON "risk_events" ("user_id", "action", "created_at");
+CREATE INDEX "risk_events_subject_idx" ON "risk_events" ("action", "subject_id", "ip", "created_at");
+
+-- the attempts the rules flagged, held or blocked, waiting for an admin to
+-- review them. "rules" is the comma separated rules that matched.
+CREATE TABLE "risk_flags" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "event_id" INT NOT NULL,
+  "user_id" INT NOT NULL,
+  "action" VARCHAR NOT NULL CHECK ("action" IN ('use_code', 'buy_art')),
+  "subject_id" INT NOT NULL,
+  "rules" VARCHAR NOT NULL,
+  "score" INT NOT NULL,
+  "decision" VARCHAR NOT NULL CHECK ("decision" IN ('flag', 'hold', 'block')),
+  "status" VARCHAR NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'cleared', 'confirmed')),
+  "reviewed_by" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("event_id") REFERENCES "risk_events" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX "risk_flags_status_idx" ON "risk_flags" ("status");
+CREATE INDEX "risk_flags_user_id_idx" ON "risk_flags" ("user_id", "action", "subject_id");
+
+CREATE TRIGGER [update_timestamp_risk_flags] AFTER UPDATE ON "risk_flags" FOR EACH ROW WHEN NEW."updated_at" < OLD."updated_at"
+BEGIN UPDATE "risk_flags" SET "updated_at"=CURRENT_TIMESTAMP WHERE id=OLD.id; END;
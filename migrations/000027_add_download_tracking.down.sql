@@ -0,0 +1,5 @@
+ALTER TABLE "arts" DROP COLUMN "download_rate_limit";
+ALTER TABLE "arts" DROP COLUMN "download_limit";
+
+DROP INDEX IF EXISTS "downloaded_arts_user_id_created_at_idx";
+ALTER TABLE "downloaded_arts" DROP COLUMN "user_id";
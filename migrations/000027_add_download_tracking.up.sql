@@ -0,0 +1,13 @@
+-- downloads are kept per user for their download history and for the rate
+-- limit of the art. Older downloads have no user.
+ALTER TABLE "downloaded_arts" ADD COLUMN "user_id" INT
+  REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX "downloaded_arts_user_id_created_at_idx" ON "downloaded_arts" ("user_id", "created_at");
+
+-- "download_limit" is how many times each purchase of the art can be
+-- downloaded, on top of the download cap of the license.
+-- "download_rate_limit" is how many times a user can download the art in an
+-- hour. Both are unlimited when NULL.
+ALTER TABLE "arts" ADD COLUMN "download_limit" INT CHECK ("download_limit" > 0);
+ALTER TABLE "arts" ADD COLUMN "download_rate_limit" INT CHECK ("download_rate_limit" > 0);
@@ -0,0 +1,26 @@
+-- gifts were posted as purchases, so the sender could refund a gift together
+-- with their own purchase of the same art. sqlite cannot alter a check
+-- constraint
+CREATE TABLE "coin_transactions_new" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "type" VARCHAR NOT NULL
+    CHECK ("type" IN ('opening_balance', 'code_redemption', 'purchase', 'refund', 'admin_adjustment', 'tip', 'top_up', 'withdrawal', 'withdrawal_reversal', 'subscription', 'commission_escrow', 'commission_release', 'commission_refund', 'auction_bid', 'auction_release', 'gift')),
+  "description" VARCHAR NOT NULL DEFAULT '',
+  "code_id" INT,
+  "art_id" INT,
+  "bundle_id" INT,
+  "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  "refund_id" INT,
+  FOREIGN KEY ("code_id") REFERENCES "codes" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("art_id") REFERENCES "arts" ("id") ON DELETE SET NULL,
+  FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL
+);
+INSERT INTO "coin_transactions_new" SELECT * FROM "coin_transactions";
+DROP TABLE "coin_transactions";
+ALTER TABLE "coin_transactions_new" RENAME TO "coin_transactions";
+
+-- gifts from before the orders only have their description
+UPDATE "coin_transactions" SET "type" = 'gift'
+WHERE "type" = 'purchase'
+  AND ("id" IN (SELECT "transaction_id" FROM "orders" WHERE "kind" = 'gift')
+    OR "description" LIKE 'Gift for %');
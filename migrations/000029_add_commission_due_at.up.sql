@@ -0,0 +1,3 @@
+-- "due_at" is when the creator should have delivered an accepted commission.
+-- After it the buyer can dispute the commission to get the escrow back.
+ALTER TABLE "commissions" ADD COLUMN "due_at" TIMESTAMP;
@@ -12,10 +12,12 @@ import (
 )
 
 type Config struct {
-	App   *AppConfig
-	DB    *DBConfig
-	Jwt   *JwtConfig
-	OAuth *OAuthConfig
+	App     *AppConfig
+	DB      *DBConfig
+	Jwt     *JwtConfig
+	OAuth   *OAuthConfig
+	Payment *PaymentConfig
+	Risk    *RiskConfig
 }
 
 func (c *Config) Print() {
@@ -25,8 +27,19 @@ func (c *Config) Print() {
 	fmt.Println("- Address: ", c.App.Address)
 	fmt.Println("- Timeout: ", c.App.Timeout)
 	fmt.Println("- CorsOrigins: ", c.App.CorsOrigins)
+	fmt.Println("- TrustedProxies: ", c.App.TrustedProxies)
 	fmt.Println("- GcpBucket: ", c.App.GcpBucket)
 	fmt.Println("- BasePath: ", c.App.BasePath)
+	fmt.Println("- SchedulerInterval: ", c.App.SchedulerInterval)
+	fmt.Println("- TrashRetention: ", c.App.TrashRetention)
+	fmt.Println("- PlatformFee: ", c.App.PlatformFee)
+	fmt.Println("- RefundWindow: ", c.App.RefundWindow)
+	fmt.Println("- TipMin: ", c.App.TipMin)
+	fmt.Println("- TipMax: ", c.App.TipMax)
+	fmt.Println("- NewUserWindow: ", c.App.NewUserWindow)
+	fmt.Println("- WithdrawalMin: ", c.App.WithdrawalMin)
+	fmt.Println("- WithdrawalHold: ", c.App.WithdrawalHold)
+	fmt.Println("- CommissionDeliveryWindow: ", c.App.CommissionDeliveryWindow)
 
 	fmt.Println("Jwt")
 	fmt.Println("- SecretKey: ", string(c.Jwt.SecretKey))
@@ -40,15 +53,41 @@ func (c *Config) Print() {
 	fmt.Println("- GithubSecret: ", c.OAuth.GithubSecret)
 	fmt.Println("- SessionSecret: ", c.OAuth.SessionSecret)
 
+	fmt.Println("Payment")
+	fmt.Println("- Provider: ", c.Payment.Provider)
+	fmt.Println("- WebhookSecret: ", c.Payment.WebhookSecret)
+	fmt.Println("- Currency: ", c.Payment.Currency)
+
+	fmt.Println("Risk")
+	fmt.Println("- Window: ", c.Risk.Window)
+	fmt.Println("- IPAccounts: ", c.Risk.IPAccounts)
+	fmt.Println("- Refunds: ", c.Risk.Refunds)
+	fmt.Println("- Purchases: ", c.Risk.Purchases)
+	fmt.Println("- FlagScore: ", c.Risk.FlagScore)
+	fmt.Println("- HoldScore: ", c.Risk.HoldScore)
+	fmt.Println("- BlockScore: ", c.Risk.BlockScore)
+
 	fmt.Println("===========================================")
 }
 
 type AppConfig struct {
-	Address     string
-	Timeout     time.Duration
-	CorsOrigins []string
-	GcpBucket   string
-	BasePath    string
+	Address           string
+	Timeout           time.Duration
+	CorsOrigins       []string
+	TrustedProxies    []string // CIDR ranges of the proxies whose X-Forwarded-For is trusted
+	GcpBucket         string
+	BasePath          string
+	SchedulerInterval time.Duration
+	TrashRetention    time.Duration
+	PlatformFee       int // percent of every sale kept by the platform
+	RefundWindow      time.Duration
+	TipMin            int
+	TipMax            int
+	NewUserWindow     time.Duration // how long after signing up a user counts as new
+	WithdrawalMin     int
+	WithdrawalHold    time.Duration // how long the earnings of a sale cannot be withdrawn
+
+	CommissionDeliveryWindow time.Duration // how long the creator has to deliver an accepted commission
 }
 
 type DBConfig struct {
@@ -69,6 +108,25 @@ type OAuthConfig struct {
 	SessionSecret string
 }
 
+type PaymentConfig struct {
+	Provider      string
+	WebhookSecret string
+	Currency      string
+}
+
+// RiskConfig holds the thresholds of the fraud rules. An attempt scoring at
+// least FlagScore is flagged for review, HoldScore is held until an admin
+// clears it and BlockScore is refused.
+type RiskConfig struct {
+	Window     time.Duration // how far back the rules count the attempts
+	IPAccounts int           // accounts that can redeem the same code from one ip
+	Refunds    int           // refunds a user can request before buying looks risky
+	Purchases  int           // purchases a user can make before buying looks risky
+	FlagScore  int
+	HoldScore  int
+	BlockScore int
+}
+
 func loadEnvPath() string {
 	if len(os.Args) == 1 {
 		return ""
@@ -86,11 +144,25 @@ func NewConfig(path string) *Config {
 
 	return &Config{
 		App: &AppConfig{
-			Address:     os.Getenv("APP_ADDRESS"),
-			Timeout:     getAsDuration("APP_TIMEOUT"),
-			CorsOrigins: strings.Split(os.Getenv("APP_CORS_ORIGINS"), " "),
-			GcpBucket:   os.Getenv("APP_GCP_BUCKET"),
-			BasePath:    os.Getenv("APP_BASE_PATH"),
+			Address:           os.Getenv("APP_ADDRESS"),
+			Timeout:           getAsDuration("APP_TIMEOUT"),
+			CorsOrigins:       strings.Split(os.Getenv("APP_CORS_ORIGINS"), " "),
+			TrustedProxies:    strings.Fields(os.Getenv("APP_TRUSTED_PROXIES")),
+			GcpBucket:         os.Getenv("APP_GCP_BUCKET"),
+			BasePath:          os.Getenv("APP_BASE_PATH"),
+			SchedulerInterval: getAsDuration("APP_SCHEDULER_INTERVAL"),
+			TrashRetention:    getAsDurationOr("APP_TRASH_RETENTION", 30*24*time.Hour),
+			PlatformFee:       getAsPercentOr("APP_PLATFORM_FEE", 10),
+			RefundWindow:      getAsDurationOr("APP_REFUND_WINDOW", 14*24*time.Hour),
+			TipMin:            getAsIntOr("APP_TIP_MIN", 1),
+			TipMax:            getAsIntOr("APP_TIP_MAX", 1000),
+			NewUserWindow:     getAsDurationOr("APP_NEW_USER_WINDOW", 7*24*time.Hour),
+			WithdrawalMin:     getAsIntOr("APP_WITHDRAWAL_MIN", 100),
+			WithdrawalHold:    getAsDurationOr("APP_WITHDRAWAL_HOLD", 14*24*time.Hour),
+			CommissionDeliveryWindow: getAsDurationOr(
+				"APP_COMMISSION_DELIVERY_WINDOW",
+				30*24*time.Hour,
+			),
 		},
 		DB: &DBConfig{
 			Path: os.Getenv("DB_PATH"),
@@ -107,7 +179,28 @@ func NewConfig(path string) *Config {
 			GithubSecret:  os.Getenv("OAUTH_GITHUB_SECRET"),
 			SessionSecret: os.Getenv("SESSION_SECRET"),
 		},
+		Payment: &PaymentConfig{
+			Provider:      os.Getenv("PAYMENT_PROVIDER"),
+			WebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+			Currency:      getOr("PAYMENT_CURRENCY", "USD"),
+		},
+		Risk: &RiskConfig{
+			Window:     getAsDurationOr("RISK_WINDOW", 24*time.Hour),
+			IPAccounts: getAsIntOr("RISK_IP_ACCOUNTS", 3),
+			Refunds:    getAsIntOr("RISK_REFUNDS", 3),
+			Purchases:  getAsIntOr("RISK_PURCHASES", 30),
+			FlagScore:  getAsIntOr("RISK_FLAG_SCORE", 40),
+			HoldScore:  getAsIntOr("RISK_HOLD_SCORE", 60),
+			BlockScore: getAsIntOr("RISK_BLOCK_SCORE", 90),
+		},
+	}
+}
+
+func getOr(key string, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
 	}
+	return fallback
 }
 
 func getAsDuration(key string) time.Duration {
@@ -124,3 +217,36 @@ func getAsDuration(key string) time.Duration {
 
 	return time.Duration(num) * time.Second
 }
+
+func getAsDurationOr(key string, fallback time.Duration) time.Duration {
+	if os.Getenv(key) == "" {
+		return fallback
+	}
+	return getAsDuration(key)
+}
+
+func getAsIntOr(key string, fallback int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	num, err := strconv.Atoi(val)
+	if err != nil {
+		log.Fatalf("config.go: convert string to int error. (\"%s\"=\"%s\")\n", key, val)
+	}
+	return num
+}
+
+func getAsPercentOr(key string, fallback int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	num, err := strconv.Atoi(val)
+	if err != nil || num < 0 || num > 100 {
+		log.Fatalf("config.go: invalid percent. (\"%s\"=\"%s\")\n", key, val)
+	}
+	return num
+}
@@ -3,11 +3,23 @@ package db
 import (
 	"database/sql"
 	"log"
+	"strings"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// every transaction takes the write lock when it begins, so that concurrent
+// transactions run one after another instead of failing halfway. Connections
+// wait for the lock instead of failing with "database is locked".
+const options = "_txlock=immediate&_busy_timeout=5000"
+
 func InitDB(dataSourceName string) *sql.DB {
+	if strings.Contains(dataSourceName, "?") {
+		dataSourceName += "&" + options
+	} else {
+		dataSourceName += "?" + options
+	}
+
 	db, err := sql.Open("sqlite3", dataSourceName)
 	if err != nil {
 		log.Fatal(err)
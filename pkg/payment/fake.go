@@ -0,0 +1,119 @@
+package payment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FakeGateway is a provider that runs inside the app, for development and
+// tests. Its checkout page is served by the app itself, and completing it
+// returns the signed webhook that a real provider would send.
+type FakeGateway struct {
+	secret []byte
+
+	mu       sync.Mutex
+	payments map[string]*FakePayment
+}
+
+type FakePayment struct {
+	CheckoutReq
+	Status string
+}
+
+// Webhook is a request from the provider to the webhook endpoint.
+type Webhook struct {
+	Body   []byte
+	Header http.Header
+}
+
+func NewFakeGateway(secret string) *FakeGateway {
+	return &FakeGateway{
+		secret:   []byte(secret),
+		payments: make(map[string]*FakePayment),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) CreateCheckout(req CheckoutReq) (Checkout, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return Checkout{}, err
+	}
+	providerId := "fake_" + hex.EncodeToString(b)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.payments[providerId] = &FakePayment{CheckoutReq: req, Status: StatusPending}
+	return Checkout{ProviderId: providerId, URL: "/payments/fake/" + providerId}, nil
+}
+
+func (g *FakeGateway) ParseWebhook(body []byte, header http.Header) (Event, error) {
+	if err := Verify(g.secret, body, header.Get(SignatureHeader), time.Now()); err != nil {
+		return Event{}, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil || event.ProviderId == "" {
+		return Event{}, ErrInvalidEvent
+	}
+	return event, nil
+}
+
+func (g *FakeGateway) FindPayment(providerId string) (Event, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[providerId]
+	if !ok {
+		return Event{}, ErrPaymentNotFound
+	}
+	return Event{ProviderId: providerId, Status: payment.Status}, nil
+}
+
+// FindCheckout returns the payment shown on the checkout page.
+func (g *FakeGateway) FindCheckout(providerId string) (FakePayment, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[providerId]
+	if !ok {
+		return FakePayment{}, ErrPaymentNotFound
+	}
+	return *payment, nil
+}
+
+// Complete settles the payment like the user paying, or failing to pay, on the
+// checkout page of a real provider. It returns the webhook for the new status.
+func (g *FakeGateway) Complete(providerId string, succeeded bool) (Webhook, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[providerId]
+	if !ok {
+		return Webhook{}, ErrPaymentNotFound
+	}
+	if payment.Status == StatusPending {
+		payment.Status = StatusFailed
+		if succeeded {
+			payment.Status = StatusSucceeded
+		}
+	}
+
+	body, err := json.Marshal(Event{ProviderId: providerId, Status: payment.Status})
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(SignatureHeader, Sign(g.secret, body, time.Now()))
+	return Webhook{Body: body, Header: header}, nil
+}
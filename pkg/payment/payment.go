@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/httperror"
+)
+
+// statuses of a payment
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var (
+	ErrInvalidSignature = httperror.New("invalid webhook signature", http.StatusBadRequest)
+	ErrInvalidEvent     = httperror.New("invalid webhook event", http.StatusBadRequest)
+	ErrPaymentNotFound  = httperror.New("payment not found at the provider", http.StatusNotFound)
+)
+
+// Gateway is a payment provider that takes the money for coin packages.
+type Gateway interface {
+	// Name is stored with every payment made through the gateway.
+	Name() string
+	// CreateCheckout starts a payment and returns the page where the user pays.
+	CreateCheckout(req CheckoutReq) (Checkout, error)
+	// ParseWebhook verifies the signature of a webhook sent by the provider and
+	// returns its event.
+	ParseWebhook(body []byte, header http.Header) (Event, error)
+	// FindPayment asks the provider for the status of a payment, for payments
+	// whose webhook never arrived.
+	FindPayment(providerId string) (Event, error)
+}
+
+type CheckoutReq struct {
+	PaymentId   int
+	Amount      int // in the smallest unit of the currency
+	Currency    string
+	Description string
+	SuccessURL  string
+	CancelURL   string
+}
+
+type Checkout struct {
+	ProviderId string
+	URL        string
+}
+
+type Event struct {
+	ProviderId string `json:"id"`
+	Status     string `json:"status"`
+}
+
+// NewGateway returns the gateway of the configured provider. The fake provider
+// hands out coins to anyone, so it is only used when it is asked for.
+func NewGateway(cfg *config.Config) (Gateway, error) {
+	if cfg.Payment.WebhookSecret == "" {
+		return nil, errors.New("PAYMENT_WEBHOOK_SECRET is not set")
+	}
+
+	switch cfg.Payment.Provider {
+	case "":
+		return nil, errors.New(`PAYMENT_PROVIDER is not set, set it to "fake" for the fake provider`)
+	case "fake":
+		return NewFakeGateway(cfg.Payment.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider)
+	}
+}
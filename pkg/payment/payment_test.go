@@ -0,0 +1,110 @@
+package payment_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/payment"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("mysecret")
+	body := []byte(`{"id":"fake_1","status":"succeeded"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{"valid", payment.Sign(secret, body, now), nil},
+		{"other secret", payment.Sign([]byte("other"), body, now), payment.ErrInvalidSignature},
+		{"too old", payment.Sign(secret, body, now.Add(-time.Hour)), payment.ErrInvalidSignature},
+		{"no signature", "t=1700000000", payment.ErrInvalidSignature},
+		{"empty", "", payment.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := payment.Verify(secret, body, tt.header, now); err != tt.err {
+				t.Fatalf("expect=%v, got=%v", tt.err, err)
+			}
+		})
+	}
+
+	if err := payment.Verify(secret, []byte(`{}`), payment.Sign(secret, body, now), now); err == nil {
+		t.Fatal("expect the changed body to be rejected")
+	}
+	if err := payment.Verify(nil, body, payment.Sign(nil, body, now), now); err != payment.ErrInvalidSignature {
+		t.Fatalf("expect a webhook signed without a secret to be rejected, got=%v", err)
+	}
+}
+
+func TestNewGateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		secret   string
+		ok       bool
+	}{
+		{"fake", "fake", "mysecret", true},
+		{"no provider", "", "mysecret", false},
+		{"no secret", "fake", "", false},
+		{"unknown provider", "other", "mysecret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Payment: &config.PaymentConfig{Provider: tt.provider, WebhookSecret: tt.secret},
+			}
+			_, err := payment.NewGateway(cfg)
+			if (err == nil) != tt.ok {
+				t.Fatalf("expect ok=%v, got err=%v", tt.ok, err)
+			}
+		})
+	}
+}
+
+func TestFakeGateway(t *testing.T) {
+	gateway := payment.NewFakeGateway("mysecret")
+
+	checkout, err := gateway.CreateCheckout(payment.CheckoutReq{PaymentId: 1, Amount: 499, Currency: "usd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := gateway.FindPayment(checkout.ProviderId)
+	if err != nil || event.Status != payment.StatusPending {
+		t.Fatalf("expect a pending payment, got=%+v, err=%v", event, err)
+	}
+
+	webhook, err := gateway.Complete(checkout.ProviderId, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err = gateway.ParseWebhook(webhook.Body, webhook.Header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ProviderId != checkout.ProviderId || event.Status != payment.StatusSucceeded {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	// a settled payment stays settled
+	webhook, err = gateway.Complete(checkout.ProviderId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, _ = gateway.ParseWebhook(webhook.Body, webhook.Header)
+	if event.Status != payment.StatusSucceeded {
+		t.Fatalf("expect status=%s, got=%s", payment.StatusSucceeded, event.Status)
+	}
+
+	if _, err := gateway.ParseWebhook(webhook.Body, http.Header{}); err != payment.ErrInvalidSignature {
+		t.Fatalf("expect=%v, got=%v", payment.ErrInvalidSignature, err)
+	}
+}
@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader holds the time the webhook was sent and the HMAC-SHA256 of
+// the time and the body, like "t=1700000000,v1=5257a8...".
+const SignatureHeader = "Payment-Signature"
+
+// SignatureTolerance is how old a webhook can be, so that a captured webhook
+// cannot be replayed later.
+const SignatureTolerance = 5 * time.Minute
+
+func Sign(secret []byte, body []byte, t time.Time) string {
+	return fmt.Sprintf("t=%d,v1=%s", t.Unix(), signature(secret, body, t.Unix()))
+}
+
+// Verify checks the signature header of the webhook. Without a secret every
+// webhook is rejected, since anyone could sign it.
+func Verify(secret []byte, body []byte, header string, now time.Time) error {
+	if len(secret) == 0 {
+		return ErrInvalidSignature
+	}
+
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = t
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := signature(secret, body, timestamp)
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func signature(secret []byte, body []byte, timestamp int64) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
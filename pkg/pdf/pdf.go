@@ -0,0 +1,167 @@
+// Package pdf writes simple PDF documents of text and lines. It only uses
+// the standard fonts that every reader has, so nothing is embedded, but the
+// text is limited to the Latin-1 characters. Other characters are written
+// as "?".
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Font string
+
+const (
+	Helvetica     Font = "Helvetica"
+	HelveticaBold Font = "Helvetica-Bold"
+	Courier       Font = "Courier"
+)
+
+var fonts = []Font{Helvetica, HelveticaBold, Courier}
+
+// size of an A4 page in points
+const (
+	PageWidth  = 595
+	PageHeight = 842
+)
+
+type Document struct {
+	pages []*Page
+}
+
+// Page is drawn from the bottom left corner, like the PDF coordinates.
+type Page struct {
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(
+		&p.content,
+		"BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		fontName(font),
+		num(size),
+		num(x),
+		num(y),
+		escape(text),
+	)
+}
+
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%s %s m %s %s l S\n", num(x1), num(y1), num(x2), num(y2))
+}
+
+// Bytes returns the whole document.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// WriteTo writes the document with the catalog, the page tree and the fonts
+// first, then every page followed by its content.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// objects 1 and 2, then a font object each, then a page and its content
+	// for every page
+	firstPage := 3 + len(fonts)
+	kids := ""
+	for i := range d.pages {
+		kids += fmt.Sprintf("%d 0 R ", firstPage+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(d.pages)))
+
+	resources := ""
+	for i, font := range fonts {
+		object(fmt.Sprintf(
+			"<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>",
+			font,
+		))
+		resources += fmt.Sprintf("/%s %d 0 R ", fontName(font), 3+i)
+	}
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			PageWidth,
+			PageHeight,
+			resources,
+			firstPage+2*i+1,
+		))
+		object(fmt.Sprintf(
+			"<< /Length %d >>\nstream\n%sendstream",
+			page.content.Len(),
+			page.content.String(),
+		))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(offsets)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&buf,
+		"trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1,
+		xref,
+	)
+
+	return buf.WriteTo(w)
+}
+
+// fontName is the name of the font in the resources of the pages.
+func fontName(font Font) string {
+	for i, f := range fonts {
+		if f == font {
+			return "F" + strconv.Itoa(i+1)
+		}
+	}
+	return "F1"
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// escape encodes the text as a PDF string in the WinAnsi encoding, which
+// matches Latin-1 for the characters that are kept.
+func escape(text string) string {
+	var buf bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r >= 32 && r < 127:
+			buf.WriteRune(r)
+		case r >= 160 && r < 256:
+			fmt.Fprintf(&buf, "\\%03o", r)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
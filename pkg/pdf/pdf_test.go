@@ -0,0 +1,55 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/DeepAung/deep-art/pkg/pdf"
+)
+
+func TestDocument(t *testing.T) {
+	doc := pdf.New()
+	page := doc.AddPage()
+	page.Text(50, 800, pdf.HelveticaBold, 20, "Receipt (copy)")
+	page.Line(50, 790, 545, 790)
+	doc.AddPage().Text(50, 800, pdf.Courier, 10, "café ใบเสร็จ")
+
+	b := doc.Bytes()
+	if !bytes.HasPrefix(b, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(b, []byte("%%EOF\n")) {
+		t.Fatal("expect the PDF header and trailer")
+	}
+	if !bytes.Contains(b, []byte("/Count 2")) {
+		t.Fatal("expect two pages")
+	}
+	if !bytes.Contains(b, []byte(`(Receipt \(copy\)) Tj`)) {
+		t.Fatal("expect the parentheses to be escaped")
+	}
+	if !bytes.Contains(b, []byte(`(caf\351 ???????) Tj`)) {
+		t.Fatal("expect Latin-1 characters to be kept and the others replaced")
+	}
+
+	// every object must start at the offset given by the cross-reference
+	// table, which must start at startxref
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(b)
+	if startxref == nil {
+		t.Fatal("expect startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(b[xref:], []byte("xref\n")) {
+		t.Fatalf("expect xref at %d", xref)
+	}
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(b[xref:], -1)
+	if len(offsets) != 9 {
+		t.Fatalf("expect 9 objects, got %d", len(offsets))
+	}
+	for i, offset := range offsets {
+		n, _ := strconv.Atoi(string(offset[1]))
+		if !bytes.HasPrefix(b[n:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))) {
+			t.Fatalf("expect object %d at %d", i+1, n)
+		}
+	}
+}
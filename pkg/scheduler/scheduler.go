@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is used when a job is added with a non-positive interval.
+const DefaultInterval = time.Minute
+
+type Job func() error
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       Job
+}
+
+// Scheduler runs jobs in the background. Each job runs once when the
+// scheduler starts and then every interval until the scheduler stops.
+type Scheduler struct {
+	jobs []job
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		done: make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Every(interval time.Duration, name string, fn Job) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	s.jobs = append(s.jobs, job{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+	})
+}
+
+func (s *Scheduler) Start() {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.run(j)
+	}
+}
+
+// Stop waits for the running jobs to finish.
+func (s *Scheduler) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.fn(); err != nil {
+			slog.Error("scheduler: "+j.name, "error", err.Error())
+		}
+
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package scheduler_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DeepAung/deep-art/pkg/scheduler"
+)
+
+func TestScheduler(t *testing.T) {
+	var ok, failed atomic.Int32
+
+	s := scheduler.NewScheduler()
+	s.Every(10*time.Millisecond, "ok", func() error {
+		ok.Add(1)
+		return nil
+	})
+	s.Every(10*time.Millisecond, "failed", func() error {
+		failed.Add(1)
+		return errors.New("failed")
+	})
+
+	s.Start()
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	if ok.Load() < 2 {
+		t.Fatalf("expect job \"ok\" to run at least 2 times, got=%d", ok.Load())
+	}
+	if failed.Load() < 2 {
+		t.Fatalf("expect job \"failed\" to keep running after an error, got=%d", failed.Load())
+	}
+
+	// no more runs after stop
+	n := ok.Load()
+	time.Sleep(30 * time.Millisecond)
+	if ok.Load() != n {
+		t.Fatalf("expect job to stop, got %d runs after stop", ok.Load()-n)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/DeepAung/deep-art/api/repositories"
 	"github.com/DeepAung/deep-art/api/services"
 	"github.com/DeepAung/deep-art/pkg/httperror"
+	"github.com/DeepAung/deep-art/pkg/payment"
 	"github.com/DeepAung/deep-art/pkg/storer"
 	"github.com/DeepAung/deep-art/pkg/utils"
 	"github.com/DeepAung/deep-art/views/pages"
@@ -15,20 +16,23 @@ import (
 )
 
 type Router struct {
-	s      *Server
-	mid    *middlewares.Middleware
-	storer storer.Storer
+	s       *Server
+	mid     *middlewares.Middleware
+	storer  storer.Storer
+	gateway payment.Gateway
 }
 
 func NewRouter(
 	s *Server,
 	mid *middlewares.Middleware,
 	storer storer.Storer,
+	gateway payment.Gateway,
 ) *Router {
 	return &Router{
-		s:      s,
-		mid:    mid,
-		storer: storer,
+		s:       s,
+		mid:     mid,
+		storer:  storer,
+		gateway: gateway,
 	}
 }
 
@@ -39,7 +43,26 @@ func (r *Router) PagesRouter() {
 	artsSvc := services.NewArtsSvc(artsRepo, r.storer, r.s.cfg)
 	tagsRepo := repositories.NewTagsRepo(r.s.db, r.s.cfg.App.Timeout)
 	tagsSvc := services.NewTagsSvc(tagsRepo)
-	handler := handlers.NewPagesHandler(usersSvc, artsSvc, tagsSvc)
+	notificationsRepo := repositories.NewNotificationsRepo(r.s.db, r.s.cfg.App.Timeout)
+	notificationsSvc := services.NewNotificationsSvc(notificationsRepo)
+	bundlesRepo := repositories.NewBundlesRepo(r.s.db, r.s.cfg.App.Timeout)
+	bundlesSvc := services.NewBundlesSvc(bundlesRepo, artsRepo, r.s.cfg)
+	couponsRepo := repositories.NewCouponsRepo(r.s.db, r.s.cfg.App.Timeout)
+	couponsSvc := services.NewCouponsSvc(couponsRepo)
+	promotionsRepo := repositories.NewPromotionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	promotionsSvc := services.NewPromotionsSvc(promotionsRepo, bundlesRepo)
+	subscriptionsRepo := repositories.NewSubscriptionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	subscriptionsSvc := services.NewSubscriptionsSvc(subscriptionsRepo, r.s.cfg)
+	handler := handlers.NewPagesHandler(
+		usersSvc,
+		artsSvc,
+		tagsSvc,
+		notificationsSvc,
+		bundlesSvc,
+		couponsSvc,
+		promotionsSvc,
+		subscriptionsSvc,
+	)
 
 	setUserData := middlewares.SetUserData
 
@@ -50,6 +73,11 @@ func (r *Router) PagesRouter() {
 	r.s.app.GET("/arts/:id", handler.ArtDetail, r.mid.OnlyAuthorized(setUserData()))
 	r.s.app.GET("/me", handler.MyProfile, r.mid.OnlyAuthorized(setUserData()))
 	r.s.app.GET("/creators/:id", handler.CreatorProfile, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/notifications", handler.Notifications, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/cart", handler.Cart, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/orders", handler.Orders, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/commissions", handler.Commissions, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/bundles/:id", handler.BundleDetail, r.mid.OnlyAuthorized(setUserData()))
 
 	r.s.app.GET("/creator", handler.CreatorHomePage, r.mid.OnlyAuthorized(setUserData()))
 	r.s.app.GET(
@@ -63,6 +91,19 @@ func (r *Router) PagesRouter() {
 		r.mid.OnlyAuthorized(setUserData()),
 		r.mid.OwnedArt("id"),
 	)
+	r.s.app.GET("/creator/trash", handler.CreatorTrash, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/creator/bundles", handler.CreatorBundles, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET("/creator/coupons", handler.CreatorCoupons, r.mid.OnlyAuthorized(setUserData()))
+	r.s.app.GET(
+		"/creator/promotions",
+		handler.CreatorPromotions,
+		r.mid.OnlyAuthorized(setUserData()),
+	)
+	r.s.app.GET(
+		"/creator/subscriptions",
+		handler.CreatorSubscriptions,
+		r.mid.OnlyAuthorized(setUserData()),
+	)
 
 	r.s.app.GET(
 		"/admin",
@@ -113,7 +154,11 @@ func (r *Router) UsersRouter() {
 func (r *Router) ArtsRouter() {
 	repo := repositories.NewArtsRepo(r.storer, r.s.db, r.s.cfg.App.Timeout)
 	svc := services.NewArtsSvc(repo, r.storer, r.s.cfg)
-	handler := handlers.NewArtsHandler(svc, r.storer, r.s.cfg)
+	bundlesRepo := repositories.NewBundlesRepo(r.s.db, r.s.cfg.App.Timeout)
+	bundlesSvc := services.NewBundlesSvc(bundlesRepo, repo, r.s.cfg)
+	riskRepo := repositories.NewRiskRepo(r.s.db, r.s.cfg.App.Timeout)
+	riskSvc := services.NewRiskSvc(riskRepo, r.s.cfg)
+	handler := handlers.NewArtsHandler(svc, bundlesSvc, riskSvc, r.storer, r.s.cfg)
 
 	setPayload := middlewares.SetPayload
 
@@ -137,12 +182,43 @@ func (r *Router) ArtsRouter() {
 		r.mid.OnlyAuthorized(setPayload()),
 		r.mid.OwnedArt("id"),
 	)
+	r.s.app.POST(
+		"/api/arts/:id/licenses",
+		handler.CreateArtLicense,
+		r.mid.OnlyAuthorized(setPayload()),
+		r.mid.OwnedArt("id"),
+	)
+	r.s.app.PUT(
+		"/api/arts/:id/licenses/:licenseId",
+		handler.UpdateArtLicense,
+		r.mid.OnlyAuthorized(setPayload()),
+		r.mid.OwnedArt("id"),
+	)
+	r.s.app.DELETE(
+		"/api/arts/:id/licenses/:licenseId",
+		handler.DeleteArtLicense,
+		r.mid.OnlyAuthorized(setPayload()),
+		r.mid.OwnedArt("id"),
+	)
+	r.s.app.POST(
+		"/api/arts/:id/restore",
+		handler.RestoreArt,
+		r.mid.OnlyAuthorized(setPayload()),
+		r.mid.OwnedArt("id"),
+	)
 	r.s.app.GET(
 		"/api/arts/:id/download",
 		handler.DownloadArt,
 		r.mid.OnlyAuthorized(setPayload()),
 		r.mid.CanDownload("id"),
 	)
+	r.s.app.GET(
+		"/api/arts/:id/versions/:version/download",
+		handler.DownloadArtVersion,
+		r.mid.OnlyAuthorized(setPayload()),
+		r.mid.CanDownload("id"),
+	)
+	r.s.app.GET("/api/downloads", handler.DownloadHistory, r.mid.OnlyAuthorized(setPayload()))
 	r.s.app.POST(
 		"/api/arts/:id/files",
 		handler.UploadFiles,
@@ -168,6 +244,10 @@ func (r *Router) ArtsRouter() {
 		r.mid.OnlyAuthorized(setPayload()),
 	)
 	r.s.app.POST("/api/arts/:id/buy", handler.BuyArt, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/arts/:id/gift", handler.GiftArt, r.mid.OnlyAuthorized(setPayload()))
+
+	r.s.app.GET("/api/gifts", handler.ReceivedGifts, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/gifts/:id/claim", handler.ClaimGift, r.mid.OnlyAuthorized(setPayload()))
 }
 
 func (r *Router) TagsRouter() {
@@ -209,7 +289,9 @@ func (r *Router) TagsRouter() {
 func (r *Router) CodesRouter() {
 	repo := repositories.NewCodesRepo(r.s.db, r.s.cfg.App.Timeout)
 	svc := services.NewCodesSvc(repo, r.s.cfg)
-	handler := handlers.NewCodesHandler(svc, r.s.cfg)
+	riskRepo := repositories.NewRiskRepo(r.s.db, r.s.cfg.App.Timeout)
+	riskSvc := services.NewRiskSvc(riskRepo, r.s.cfg)
+	handler := handlers.NewCodesHandler(svc, riskSvc, r.s.cfg)
 
 	setPayload := middlewares.SetPayload
 	setUserData := middlewares.SetUserData
@@ -228,6 +310,18 @@ func (r *Router) CodesRouter() {
 		r.mid.OnlyAuthorized(setUserData()),
 		r.mid.OnlyAdmin,
 	)
+	r.s.app.POST(
+		"/api/codes/batches",
+		handler.CreateCodeBatch,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.GET(
+		"/api/codes/batches/:batch/csv",
+		handler.ExportCodeBatch,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
 	r.s.app.PUT(
 		"/api/codes/:id",
 		handler.UpdateCode,
@@ -242,6 +336,416 @@ func (r *Router) CodesRouter() {
 	)
 }
 
+func (r *Router) BundlesRouter() {
+	artsRepo := repositories.NewArtsRepo(r.storer, r.s.db, r.s.cfg.App.Timeout)
+	repo := repositories.NewBundlesRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewBundlesSvc(repo, artsRepo, r.s.cfg)
+	handler := handlers.NewBundlesHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.POST("/api/bundles", handler.CreateBundle, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.PUT("/api/bundles/:id", handler.UpdateBundle, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.DELETE("/api/bundles/:id", handler.DeleteBundle, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/bundles/:id/buy", handler.BuyBundle, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) NotificationsRouter() {
+	repo := repositories.NewNotificationsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewNotificationsSvc(repo)
+	handler := handlers.NewNotificationsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.GET(
+		"/api/notifications/badge",
+		handler.NotificationsBadge,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+}
+
+func (r *Router) LedgerRouter() {
+	repo := repositories.NewLedgerRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewLedgerSvc(repo)
+	handler := handlers.NewLedgerHandler(svc)
+
+	setPayload := middlewares.SetPayload
+	setUserData := middlewares.SetUserData
+
+	r.s.app.GET("/api/wallet", handler.WalletHistory, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/earnings", handler.CreatorEarnings, r.mid.OnlyAuthorized(setPayload()))
+
+	r.s.app.GET(
+		"/api/ledger",
+		handler.LedgerReport,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/ledger/adjustments",
+		handler.AdjustCoin,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+}
+
+func (r *Router) RefundsRouter() {
+	repo := repositories.NewRefundsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewRefundsSvc(repo, r.s.cfg)
+	handler := handlers.NewRefundsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+	setUserData := middlewares.SetUserData
+
+	r.s.app.POST("/api/arts/:id/refunds", handler.RequestRefund, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/refunds", handler.RequestedRefunds, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET(
+		"/api/refunds/incoming",
+		handler.IncomingRefunds,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.GET(
+		"/api/refunds/all",
+		handler.AllRefunds,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/refunds/:id/approve",
+		handler.ApproveRefund,
+		r.mid.OnlyAuthorized(setUserData()),
+	)
+	r.s.app.POST(
+		"/api/refunds/:id/reject",
+		handler.RejectRefund,
+		r.mid.OnlyAuthorized(setUserData()),
+	)
+}
+
+func (r *Router) TipsRouter() {
+	repo := repositories.NewTipsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewTipsSvc(repo, r.s.cfg)
+	handler := handlers.NewTipsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.POST("/api/creators/:id/tips", handler.Tip, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/creators/:id/tips", handler.CreatorTips, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/tips", handler.ReceivedTips, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) PaymentsRouter() {
+	repo := repositories.NewPaymentsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewPaymentsSvc(repo, r.gateway, r.s.cfg)
+	handler := handlers.NewPaymentsHandler(svc, r.gateway, r.s.cfg)
+
+	setPayload := middlewares.SetPayload
+	setUserData := middlewares.SetUserData
+
+	r.s.app.GET("/api/coin-packages", handler.GetCoinPackages, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST(
+		"/api/coin-packages/:id/checkout",
+		handler.Checkout,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST("/api/payments/webhook", handler.Webhook)
+
+	r.s.app.GET(
+		"/api/coin-packages/all",
+		handler.GetAllCoinPackages,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/coin-packages",
+		handler.CreateCoinPackage,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.PUT(
+		"/api/coin-packages/:id",
+		handler.UpdateCoinPackage,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.DELETE(
+		"/api/coin-packages/:id",
+		handler.DeleteCoinPackage,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.GET(
+		"/api/payments",
+		handler.GetPayments,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/payments/:id/reconcile",
+		handler.Reconcile,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+
+	if _, ok := r.gateway.(*payment.FakeGateway); ok {
+		r.s.app.GET("/payments/fake/:id", handler.FakeCheckout, r.mid.OnlyAuthorized(setUserData()))
+		r.s.app.POST(
+			"/api/payments/fake/:id",
+			handler.CompleteFakeCheckout,
+			r.mid.OnlyAuthorized(setPayload()),
+		)
+	}
+}
+
+func (r *Router) CartsRouter() {
+	repo := repositories.NewCartsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewCartsSvc(repo, r.s.cfg)
+	handler := handlers.NewCartsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.GET("/api/cart", handler.GetCart, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/cart/badge", handler.CartBadge, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/cart", handler.AddCartItem, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.DELETE("/api/cart/:artId", handler.RemoveCartItem, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/cart/checkout", handler.Checkout, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) OrdersRouter() {
+	repo := repositories.NewOrdersRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewOrdersSvc(repo)
+	handler := handlers.NewOrdersHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.GET("/api/orders", handler.MyOrders, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/orders/:id/receipt", handler.Receipt, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/sales", handler.MySales, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) SubscriptionsRouter() {
+	repo := repositories.NewSubscriptionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewSubscriptionsSvc(repo, r.s.cfg)
+	handler := handlers.NewSubscriptionsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.GET(
+		"/api/creators/:id/tiers",
+		handler.CreatorTiers,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST("/api/tiers", handler.CreateTier, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.PUT("/api/tiers/:id", handler.UpdateTier, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.DELETE("/api/tiers/:id", handler.DeleteTier, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/tiers/:id/subscribe", handler.Subscribe, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST(
+		"/api/tiers/:id/cancel",
+		handler.CancelSubscription,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+}
+
+func (r *Router) CommissionsRouter() {
+	repo := repositories.NewCommissionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewCommissionsSvc(repo, r.storer, r.s.cfg)
+	handler := handlers.NewCommissionsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+	setUserData := middlewares.SetUserData
+
+	r.s.app.POST(
+		"/api/creators/:id/commissions",
+		handler.CreateCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.GET("/api/commissions", handler.MyCommissions, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET(
+		"/api/commissions/disputed",
+		handler.DisputedCommissions,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.GET(
+		"/api/commissions/:id",
+		handler.CommissionDetail,
+		r.mid.OnlyAuthorized(setUserData()),
+	)
+
+	r.s.app.POST(
+		"/api/commissions/:id/quote",
+		handler.QuoteCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/decline",
+		handler.DeclineCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/deliver",
+		handler.DeliverCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/accept",
+		handler.AcceptCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/cancel",
+		handler.CancelCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/complete",
+		handler.CompleteCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/dispute",
+		handler.DisputeCommission,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+
+	r.s.app.POST(
+		"/api/commissions/:id/release",
+		handler.ReleaseCommission,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/commissions/:id/refund",
+		handler.RefundCommission,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+}
+
+func (r *Router) AuctionsRouter() {
+	repo := repositories.NewAuctionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewAuctionsSvc(repo, r.s.cfg)
+	handler := handlers.NewAuctionsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.GET("/api/arts/:id/auction", handler.ArtAuction, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/arts/:id/auction", handler.CreateAuction, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.DELETE("/api/arts/:id/auction", handler.CancelAuction, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/arts/:id/bids", handler.PlaceBid, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) RiskRouter() {
+	repo := repositories.NewRiskRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewRiskSvc(repo, r.s.cfg)
+	handler := handlers.NewRiskHandler(svc)
+
+	setUserData := middlewares.SetUserData
+
+	r.s.app.GET(
+		"/api/risk-flags",
+		handler.RiskFlags,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/risk-flags/:id/clear",
+		handler.ClearRiskFlag,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/risk-flags/:id/confirm",
+		handler.ConfirmRiskFlag,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+}
+
+func (r *Router) WithdrawalsRouter() {
+	repo := repositories.NewWithdrawalsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewWithdrawalsSvc(repo, r.s.cfg)
+	handler := handlers.NewWithdrawalsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+	setUserData := middlewares.SetUserData
+
+	r.s.app.GET("/api/withdrawals", handler.MyWithdrawals, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.POST("/api/withdrawals", handler.RequestWithdrawal, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET(
+		"/api/withdrawals/statement",
+		handler.ExportStatement,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+
+	r.s.app.GET(
+		"/api/withdrawals/all",
+		handler.AllWithdrawals,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.GET(
+		"/api/withdrawals/all/csv",
+		handler.ExportWithdrawals,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/withdrawals/:id/approve",
+		handler.ApproveWithdrawal,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/withdrawals/:id/reject",
+		handler.RejectWithdrawal,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+	r.s.app.POST(
+		"/api/withdrawals/:id/paid",
+		handler.MarkWithdrawalPaid,
+		r.mid.OnlyAuthorized(setUserData()),
+		r.mid.OnlyAdmin,
+	)
+}
+
+func (r *Router) CouponsRouter() {
+	artsRepo := repositories.NewArtsRepo(r.storer, r.s.db, r.s.cfg.App.Timeout)
+	artsSvc := services.NewArtsSvc(artsRepo, r.storer, r.s.cfg)
+	repo := repositories.NewCouponsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewCouponsSvc(repo)
+	handler := handlers.NewCouponsHandler(svc, artsSvc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.POST("/api/coupons", handler.CreateCoupon, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.PUT("/api/coupons/:id", handler.UpdateCoupon, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.DELETE("/api/coupons/:id", handler.DeleteCoupon, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.GET("/api/arts/:id/coupon", handler.ApplyCoupon, r.mid.OnlyAuthorized(setPayload()))
+}
+
+func (r *Router) PromotionsRouter() {
+	bundlesRepo := repositories.NewBundlesRepo(r.s.db, r.s.cfg.App.Timeout)
+	repo := repositories.NewPromotionsRepo(r.s.db, r.s.cfg.App.Timeout)
+	svc := services.NewPromotionsSvc(repo, bundlesRepo)
+	handler := handlers.NewPromotionsHandler(svc)
+
+	setPayload := middlewares.SetPayload
+
+	r.s.app.POST("/api/promotions", handler.CreatePromotion, r.mid.OnlyAuthorized(setPayload()))
+	r.s.app.PUT(
+		"/api/promotions/:id",
+		handler.UpdatePromotion,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+	r.s.app.DELETE(
+		"/api/promotions/:id",
+		handler.DeletePromotion,
+		r.mid.OnlyAuthorized(setPayload()),
+	)
+}
+
 // ------------------------------------------------------------------------- //
 
 func (r *Router) TestRouter() {
@@ -275,7 +779,7 @@ func (r *Router) testTagsRouter(testGroup *echo.Group) {
 
 func (r *Router) testCodesRouter(testGroup *echo.Group) {
 	repo := repositories.NewCodesRepo(r.s.db, r.s.cfg.App.Timeout)
-	handler := handlers.NewTestCodesHandler(repo)
+	handler := handlers.NewTestCodesHandler(repo, r.s.cfg.App.NewUserWindow)
 
 	codesGroup := testGroup.Group("/codes")
 	codesGroup.GET("/:id", handler.FindOneCodeById)
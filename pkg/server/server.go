@@ -2,12 +2,16 @@ package server
 
 import (
 	"database/sql"
+	"log"
+	"net"
 	"net/http"
 
 	"github.com/DeepAung/deep-art/api/middlewares"
 	"github.com/DeepAung/deep-art/api/repositories"
 	"github.com/DeepAung/deep-art/api/services"
 	"github.com/DeepAung/deep-art/pkg/config"
+	"github.com/DeepAung/deep-art/pkg/payment"
+	"github.com/DeepAung/deep-art/pkg/scheduler"
 	"github.com/DeepAung/deep-art/pkg/storer"
 	"github.com/DeepAung/deep-art/pkg/utils"
 	"github.com/DeepAung/deep-art/views/pages"
@@ -54,16 +58,76 @@ func (s *Server) Start() {
 		),
 	)
 
+	ipExtractor, err := newIPExtractor(s.cfg.App.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.app.IPExtractor = ipExtractor
+
 	myStorer := storer.NewGCPStorer(s.cfg)
 	mid := s.InitMiddleware(myStorer)
 
+	gateway, err := payment.NewGateway(s.cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	s.app.Static("/static", "static")
 
-	s.InitRouter(mid, myStorer)
+	s.InitRouter(mid, myStorer, gateway)
+
+	sched := s.InitScheduler(myStorer, gateway)
+	sched.Start()
+	defer sched.Stop()
 
 	s.app.Start(":3000")
 }
 
+// newIPExtractor reads the IP of the client from X-Forwarded-For only when the
+// request comes through one of the trusted proxies. Without any, the IP is the
+// one the request comes from, so clients cannot spoof it for the risk rules.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(opts...), nil
+}
+
+func (s *Server) InitScheduler(storer storer.Storer, gateway payment.Gateway) *scheduler.Scheduler {
+	artsRepo := repositories.NewArtsRepo(storer, s.db, s.cfg.App.Timeout)
+	artsSvc := services.NewArtsSvc(artsRepo, storer, s.cfg)
+	paymentsRepo := repositories.NewPaymentsRepo(s.db, s.cfg.App.Timeout)
+	paymentsSvc := services.NewPaymentsSvc(paymentsRepo, gateway, s.cfg)
+	subscriptionsRepo := repositories.NewSubscriptionsRepo(s.db, s.cfg.App.Timeout)
+	subscriptionsSvc := services.NewSubscriptionsSvc(subscriptionsRepo, s.cfg)
+	auctionsRepo := repositories.NewAuctionsRepo(s.db, s.cfg.App.Timeout)
+	auctionsSvc := services.NewAuctionsSvc(auctionsRepo, s.cfg)
+
+	sched := scheduler.NewScheduler()
+	sched.Every(s.cfg.App.SchedulerInterval, "publish scheduled arts", artsSvc.PublishScheduledArts)
+	sched.Every(s.cfg.App.SchedulerInterval, "purge deleted arts", artsSvc.PurgeDeletedArts)
+	sched.Every(s.cfg.App.SchedulerInterval, "purge idempotency keys", artsSvc.PurgeIdempotencyKeys)
+	sched.Every(s.cfg.App.SchedulerInterval, "reconcile payments", paymentsSvc.ReconcilePayments)
+	sched.Every(s.cfg.App.SchedulerInterval, "renew subscriptions", subscriptionsSvc.RenewSubscriptions)
+	sched.Every(s.cfg.App.SchedulerInterval, "close auctions", auctionsSvc.CloseAuctions)
+
+	return sched
+}
+
 func (s *Server) InitMiddleware(storer storer.Storer) *middlewares.Middleware {
 	usersRepo := repositories.NewUsersRepo(s.db, s.cfg.App.Timeout)
 	usersSvc := services.NewUsersSvc(usersRepo, storer, s.cfg)
@@ -84,13 +148,32 @@ func (s *Server) InitMiddleware(storer storer.Storer) *middlewares.Middleware {
 	return mid
 }
 
-func (s *Server) InitRouter(mid *middlewares.Middleware, storer storer.Storer) {
-	r := NewRouter(s, mid, storer)
+func (s *Server) InitRouter(
+	mid *middlewares.Middleware,
+	storer storer.Storer,
+	gateway payment.Gateway,
+) {
+	r := NewRouter(s, mid, storer, gateway)
 
 	r.UsersRouter()
 	r.ArtsRouter()
 	r.TagsRouter()
 	r.CodesRouter()
+	r.BundlesRouter()
+	r.NotificationsRouter()
+	r.LedgerRouter()
+	r.RefundsRouter()
+	r.TipsRouter()
+	r.CouponsRouter()
+	r.PromotionsRouter()
+	r.PaymentsRouter()
+	r.SubscriptionsRouter()
+	r.CommissionsRouter()
+	r.AuctionsRouter()
+	r.RiskRouter()
+	r.WithdrawalsRouter()
+	r.CartsRouter()
+	r.OrdersRouter()
 	r.TestRouter()
 	r.PagesRouter()
 
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"io"
 	"net/http"
@@ -150,3 +151,32 @@ func CreateZipFile(filespath []string, zipDest, zipName string) error {
 
 	return nil
 }
+
+// AppendToZip returns a copy of the zip archive with the file added to it.
+func AppendToZip(archive []byte, name string, content []byte) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for _, f := range r.File {
+		if err := zipWriter.Copy(f); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := zipWriter.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,6 +1,9 @@
 package utils_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/DeepAung/deep-art/pkg/utils"
@@ -23,3 +26,47 @@ func TestJoin(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendToZip(t *testing.T) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	w, err := zipWriter.Create("art/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("file")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := utils.AppendToZip(buf.Bytes(), "art/LICENSE.txt", []byte("license"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]string{"art/file.txt": "file", "art/LICENSE.txt": "license"}
+	if len(r.File) != len(expect) {
+		t.Fatalf("expect %d files, got %d", len(expect), len(r.File))
+	}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expect[f.Name] {
+			t.Fatalf("%s: expect=%q, got=%q", f.Name, expect[f.Name], content)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by templ - DO NOT EDIT.
 
-// templ: version: v0.3.833
+// templ: version: v0.3.943
 package layouts
 
 //lint:file-ignore SA4006 This context is only used if a nested component is present.
@@ -63,7 +63,7 @@ func WithNav(navType navType, user types.User) templ.Component {
 					return templ_7745c5c3_Err
 				}
 			case Creator:
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<div class=\"flex flex-col md:flex-row gap-3 items-center\"><a class=\"flex-none text-xl font-semibold dark:text-white\" href=\"/creator\">DeepArt <span class=\"text-green-600\">Creator Page</span></a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/arts/create\">Create New Art</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/dashboard\">View Dashboard</a></div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<div class=\"flex flex-col md:flex-row gap-3 items-center\"><a class=\"flex-none text-xl font-semibold dark:text-white\" href=\"/creator\">DeepArt <span class=\"text-green-600\">Creator Page</span></a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/arts/create\">Create New Art</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/dashboard\">View Dashboard</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/bundles\">Bundles</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/coupons\">Coupons</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/promotions\">Promotions</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/subscriptions\">Subscriptions</a> <a class=\"flex-none text-md font-semibold hover:text-green-600 transition-colors\" href=\"/creator/trash\">Trash</a></div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
@@ -73,7 +73,7 @@ func WithNav(navType navType, user types.User) templ.Component {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div class=\"flex items-center justify-center gap-5 mt-5 md:justify-end md:mt-0 md:ps-5\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div class=\"flex items-center justify-center gap-5 mt-5 md:justify-end md:mt-0 md:ps-5\"><span hx-get=\"/api/notifications/badge\" hx-trigger=\"load, every 1m\" hx-swap=\"innerHTML\"></span> <span hx-get=\"/api/cart/badge\" hx-trigger=\"load, cart-changed from:body\" hx-swap=\"innerHTML\"></span>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -96,7 +96,7 @@ func WithNav(navType navType, user types.User) templ.Component {
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(user.Username)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/layouts/withNav.templ`, Line: 38, Col: 79}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/layouts/withNav.templ`, Line: 45, Col: 79}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
@@ -109,13 +109,13 @@ func WithNav(navType navType, user types.User) templ.Component {
 			var templ_7745c5c3_Var4 string
 			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(user.Email)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/layouts/withNav.templ`, Line: 39, Col: 79}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/layouts/withNav.templ`, Line: 46, Col: 79}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</p></div><svg class=\"hs-dropdown-open:rotate-180 size-4\" xmlns=\"http://www.w3.org/2000/svg\" width=\"24\" height=\"24\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><path d=\"m6 9 6 6 6-6\"></path></svg></button><div class=\"hs-dropdown-menu transition-[opacity,margin] duration hs-dropdown-open:opacity-100 opacity-0 hidden min-w-60 bg-white shadow-md rounded-lg p-2 mt-2 dark:bg-neutral-800 dark:border dark:border-neutral-700 dark:divide-neutral-700 after:h-4 after:absolute after:-bottom-4 after:start-0 after:w-full before:h-4 before:absolute before:-top-4 before:start-0 before:w-full\" aria-labelledby=\"hs-dropdown-default\"><a href=\"/me\" class=\"flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">My Profile</a> <span hx-post=\"/api/auth/signout\" class=\"cursor-pointer flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">Sign out</span></div></div></div></nav></header><main class=\"flex-auto\"><span hx-post=\"/api/auth/update-tokens\" hx-trigger=\"load, every 55m\" hx-swap=\"none\"></span>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</p></div><svg class=\"hs-dropdown-open:rotate-180 size-4\" xmlns=\"http://www.w3.org/2000/svg\" width=\"24\" height=\"24\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><path d=\"m6 9 6 6 6-6\"></path></svg></button><div class=\"hs-dropdown-menu transition-[opacity,margin] duration hs-dropdown-open:opacity-100 opacity-0 hidden min-w-60 bg-white shadow-md rounded-lg p-2 mt-2 dark:bg-neutral-800 dark:border dark:border-neutral-700 dark:divide-neutral-700 after:h-4 after:absolute after:-bottom-4 after:start-0 after:w-full before:h-4 before:absolute before:-top-4 before:start-0 before:w-full\" aria-labelledby=\"hs-dropdown-default\"><a href=\"/me\" class=\"flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">My Profile</a> <a href=\"/orders\" class=\"flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">Purchase History</a> <a href=\"/commissions\" class=\"flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">Commissions</a> <span hx-post=\"/api/auth/signout\" class=\"cursor-pointer flex items-center gap-x-3.5 py-2 px-3 rounded-lg text-sm text-gray-800 hover:bg-gray-100 focus:outline-none focus:bg-gray-100 dark:text-neutral-400 dark:hover:bg-neutral-700 dark:hover:text-neutral-300 dark:focus:bg-neutral-700\">Sign out</span></div></div></div></nav></header><main class=\"flex-auto\"><span hx-post=\"/api/auth/update-tokens\" hx-trigger=\"load, every 55m\" hx-swap=\"none\"></span>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1,6 +1,6 @@
 // Code generated by templ - DO NOT EDIT.
 
-// templ: version: v0.3.833
+// templ: version: v0.3.943
 package pages
 
 //lint:file-ignore SA4006 This context is only used if a nested component is present.
@@ -44,7 +44,7 @@ func AdminHome(user types.User) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div hx-get=\"/api/codes\" hx-trigger=\"ready from:body\"><h2 class=\"text-2xl text-center font-bold my-3\">Create & Edit Codes</h2><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div><hr class=\"my-8\"><div hx-get=\"/api/tags\" hx-trigger=\"ready from:body\"><h2 class=\"text-2xl text-center font-bold my-3\">Create & Edit Tags</h2><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div hx-get=\"/api/codes\" hx-trigger=\"ready from:body\"><h2 class=\"text-2xl text-center font-bold my-3\">Create & Edit Codes</h2><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div><hr class=\"my-8\"><div hx-get=\"/api/tags\" hx-trigger=\"ready from:body\"><h2 class=\"text-2xl text-center font-bold my-3\">Create & Edit Tags</h2><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Refund Requests</h2><div hx-get=\"/api/refunds/all\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Fraud Review</h2><div hx-get=\"/api/risk-flags\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Withdrawal Requests</h2><div hx-get=\"/api/withdrawals/all\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Disputed Commissions</h2><div hx-get=\"/api/commissions/disputed\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Coin Packages</h2><div hx-get=\"/api/coin-packages/all\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl text-center font-bold my-3\">Payments</h2><div hx-get=\"/api/payments\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></div><hr class=\"my-8\"><div hx-get=\"/api/ledger\" hx-trigger=\"ready from:body\"><h2 class=\"text-2xl text-center font-bold my-3\">Coin Ledger</h2><div class=\"flex flex-row gap-3 justify-center\"><div id=\"arts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
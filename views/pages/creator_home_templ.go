@@ -1,6 +1,6 @@
 // Code generated by templ - DO NOT EDIT.
 
-// templ: version: v0.3.833
+// templ: version: v0.3.943
 package pages
 
 //lint:file-ignore SA4006 This context is only used if a nested component is present.
@@ -45,7 +45,7 @@ func CreatorHome(user types.User) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"flex px-3 gap-5 items-center justify-between text-4xl sm:justify-center sm:gap-[200px]\"><h1 class=\"text-center font-bold my-3\">Your Arts</h1><a href=\"/creator/arts/create\"><i class=\"fa-solid fa-circle-plus hover:text-green-600\"></i></a></div><script x-data x-init=\"$store.manyArtsURL = &#39;/api/arts-with-art-type?artType=created&amp;withEdit=true&#39;\" src=\"/static/js/arts.js\"></script> ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"flex px-3 gap-5 items-center justify-between text-4xl sm:justify-center sm:gap-[200px]\"><h1 class=\"text-center font-bold my-3\">Your Arts</h1><a href=\"/creator/arts/create\"><i class=\"fa-solid fa-circle-plus hover:text-green-600\"></i></a></div><script x-data x-init=\"$store.manyArtsURL = '/api/arts-with-art-type?artType=created&withEdit=true'\" src=\"/static/js/arts.js\"></script> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -53,6 +53,10 @@ func CreatorHome(user types.User) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, " <hr class=\"my-8\"><div hx-get=\"/api/earnings\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"earnings-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div><hr class=\"my-8\"><section class=\"max-w-4xl w-full mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Sales</h2><div hx-get=\"/api/sales\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"sales-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><hr class=\"my-8\"><div hx-get=\"/api/withdrawals\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"withdrawals-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div><hr class=\"my-8\"><section class=\"max-w-2xl w-full mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Tips</h2><div hx-get=\"/api/tips\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"tips-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><hr class=\"my-8\"><section class=\"max-w-2xl w-full mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Refund Requests</h2><div hx-get=\"/api/refunds/incoming\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"refunds-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
 			return nil
 		})
 		templ_7745c5c3_Err = layouts.WithNav(layouts.Creator, user).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
@@ -1,6 +1,6 @@
 // Code generated by templ - DO NOT EDIT.
 
-// templ: version: v0.3.833
+// templ: version: v0.3.943
 package pages
 
 //lint:file-ignore SA4006 This context is only used if a nested component is present.
@@ -109,7 +109,7 @@ func MyProfile(me types.User, oauth types.OAuthInfo) templ.Component {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</div></section><!-- arts section --><section class=\"px-4 mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Arts</h2><nav class=\"border-b border-gray-200 dark:border-neutral-700\"><div x-data x-init=\"$store.manyArtsURL = &#39;/api/arts-with-art-type?artType=starred&#39;\" class=\"-mb-0.5 flex justify-center space-x-6\" aria-label=\"Tabs\" role=\"tablist\"><button @click=\"$store.manyArtsURL = &#39;/api/arts-with-art-type?artType=starred&#39;\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500 active\" id=\"horizontal-alignment-item-1\" data-hs-tab=\"#horizontal-alignment-1\" aria-controls=\"horizontal-alignment-1\" role=\"tab\">Starred Arts</button> <button @click=\"$store.manyArtsURL = &#39;/api/arts-with-art-type?artType=bought&#39;\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500\" id=\"horizontal-alignment-item-2\" data-hs-tab=\"#horizontal-alignment-2\" aria-controls=\"horizontal-alignment-2\" role=\"tab\">Bought Arts</button> <button @click=\"$store.manyArtsURL = &#39;/api/arts-with-art-type?artType=created&#39;\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500\" id=\"horizontal-alignment-item-3\" data-hs-tab=\"#horizontal-alignment-3\" aria-controls=\"horizontal-alignment-3\" role=\"tab\">Created Arts</button></div></nav><div id=\"arts-container\" class=\"mt-3\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</div></section><!-- buy coins section --><section class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Buy Coins</h2><div hx-get=\"/api/coin-packages\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"coin-packages-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><!-- wallet section --><section class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Wallet History</h2><div hx-get=\"/api/wallet\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"wallet-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><!-- downloads section --><section class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Downloads</h2><div hx-get=\"/api/downloads\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"downloads-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><!-- gifts section --><section class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Gifts</h2><div hx-get=\"/api/gifts\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"gifts-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><!-- refunds section --><section class=\"max-w-2xl mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Refund Requests</h2><div hx-get=\"/api/refunds\" hx-trigger=\"ready from:body\"><div class=\"flex flex-row gap-3 justify-center\"><div id=\"refunds-spinner\" class=\"animate-spin text-center inline-block size-6 border-[3px] border-current border-t-transparent text-blue-600 rounded-full dark:text-blue-500\" role=\"status\" aria-label=\"loading\"></div><span>Loading...</span></div></div></section><!-- arts section --><section class=\"px-4 mx-auto\"><h2 class=\"text-2xl font-semibold text-center mb-2\">Arts</h2><nav class=\"border-b border-gray-200 dark:border-neutral-700\"><div x-data x-init=\"$store.manyArtsURL = '/api/arts-with-art-type?artType=starred'\" class=\"-mb-0.5 flex justify-center space-x-6\" aria-label=\"Tabs\" role=\"tablist\"><button @click=\"$store.manyArtsURL = '/api/arts-with-art-type?artType=starred'\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500 active\" id=\"horizontal-alignment-item-1\" data-hs-tab=\"#horizontal-alignment-1\" aria-controls=\"horizontal-alignment-1\" role=\"tab\">Starred Arts</button> <button @click=\"$store.manyArtsURL = '/api/arts-with-art-type?artType=bought'\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500\" id=\"horizontal-alignment-item-2\" data-hs-tab=\"#horizontal-alignment-2\" aria-controls=\"horizontal-alignment-2\" role=\"tab\">Bought Arts</button> <button @click=\"$store.manyArtsURL = '/api/arts-with-art-type?artType=created'\" type=\"button\" class=\"hs-tab-active:font-semibold hs-tab-active:border-blue-600 hs-tab-active:text-blue-600 py-4 px-1 inline-flex items-center gap-x-2 border-b-2 border-transparent text-sm whitespace-nowrap text-gray-500 hover:text-blue-600 focus:outline-none focus:text-blue-600 disabled:opacity-50 disabled:pointer-events-none dark:text-neutral-400 dark:hover:text-blue-500\" id=\"horizontal-alignment-item-3\" data-hs-tab=\"#horizontal-alignment-3\" aria-controls=\"horizontal-alignment-3\" role=\"tab\">Created Arts</button></div></nav><div id=\"arts-container\" class=\"mt-3\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}